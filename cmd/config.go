@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"errors"
+	"fmt"
 	"log/slog"
 	"os"
 	"path/filepath"
@@ -10,6 +11,9 @@ import (
 	"time"
 
 	"github.com/spf13/viper"
+	"gooze.dev/pkg/gooze/internal/adapter"
+	"gooze.dev/pkg/gooze/internal/domain"
+	"gooze.dev/pkg/gooze/internal/domain/mutagens"
 	"gopkg.in/natefinch/lumberjack.v2"
 )
 
@@ -27,20 +31,92 @@ const (
 	runParallelFlagName = "parallel"
 
 	mutationTimeoutFlagName = "mutation-timeout"
+	timeoutFactorFlagName   = "timeout-factor"
+	timeoutConstantFlagName = "timeout-constant"
+
+	baselineFlagName         = "baseline"
+	baselineCoverageFlagName = "baseline-coverage"
+
+	flakyRerunsFlagName   = "flaky-reruns"
+	flakyBaselineFlagName = "flaky-baseline"
 
 	coverageProfileFlagName = "coverage-profile"
+	perTestCoverageFlagName = "per-test-coverage"
+
+	detectEquivalentFlagName = "detect-equivalent"
+
+	funcFlagName        = "func"
+	excludeFuncFlagName = "exclude-func"
+
+	sampleFlagName     = "sample"
+	maxPerFileFlagName = "max-per-file"
+	seedFlagName       = "seed"
+
+	sinceFlagName = "since"
+
+	testFlagFlagName = "test-flag"
+	tagsFlagName     = "tags"
+	testEnvFlagName  = "test-env"
 
-	runParallelConfigKey  = "run.parallel"
-	mutationTimeoutKey    = "run.mutation_timeout"
-	runCoverageProfileKey = "run.coverage_profile"
-	excludeConfigKey      = "paths.exclude"
+	runParallelConfigKey   = "run.parallel"
+	mutationTimeoutKey     = "run.mutation_timeout"
+	runCoverageProfileKey  = "run.coverage_profile"
+	runDetectEquivalentKey = "run.detect_equivalent"
+	runPerTestCoverageKey  = "run.per_test_coverage"
+	runBaselineKey         = "run.baseline"
+	runBaselineCoverageKey = "run.baseline_coverage"
+	runTimeoutFactorKey    = "run.timeout_factor"
+	runTimeoutConstantKey  = "run.timeout_constant"
+	runFlakyRerunsKey      = "run.flaky.reruns"
+	runFlakyBaselineKey    = "run.flaky.baseline"
+	runLimitsMemoryKey     = "run.limits.memory_mb"
+	runLimitsCPUKey        = "run.limits.cpu_seconds"
+	runLimitsOpenFilesKey  = "run.limits.open_files"
+	runSampleKey           = "run.sample"
+	runMaxPerFileKey       = "run.max_per_file"
+	runSeedKey             = "run.seed"
+	runSinceKey            = "run.since"
+	runTestFlagsKey        = "run.test.flags"
+	runTestTagsKey         = "run.test.tags"
+	runTestEnvKey          = "run.test.env"
+	runWorkspaceKey        = "run.workspace"
+	runSnapshotMinimalKey  = "run.snapshot.minimal"
+	runSnapshotIncludeKey  = "run.snapshot.include"
+	runSnapshotLinksKey    = "run.snapshot.hardlinks"
+	runCacheStableKey      = "run.cache.stable_paths"
+	runCacheTrimpathKey    = "run.cache.trimpath"
+	runCacheWarmUpKey      = "run.cache.warm_up"
+	runSchemataKey         = "run.schemata"
+	excludeConfigKey       = "paths.exclude"
+	skipGeneratedKey       = "paths.skip.generated"
+	skipVendorKey          = "paths.skip.vendor"
+	skipTestdataKey        = "paths.skip.testdata"
+	skipCgoKey             = "paths.skip.cgo"
+	skipIgnoredKey         = "paths.skip.ignored"
+	testsMappingsKey       = "tests.mappings"
+	testsReverseDepthKey   = "tests.reverse_deps.depth"
+	testsReverseBudgetKey  = "tests.reverse_deps.budget"
+	funcsMatchKey          = "funcs.match"
+	funcsExcludeKey        = "funcs.exclude"
+
+	mutagensLevelKey       = "mutagens.level"
+	mutagensNumbersKey     = "mutagens.numbers.variants"
+	mutagensComparisonKey  = "mutagens.comparison.mode"
+	mutagensRulesKey       = "mutagens.rules"
+	mutagensHigherOrderKey = "mutagens.higher_order"
 
 	defaultMutationTimeout = time.Minute * 2
+	defaultTimeoutFactor   = 3.0
+	defaultTimeoutConstant = time.Second * 10
 
 	defaultReportsDir  = ".gooze-reports"
 	defaultNoCache     = false
 	defaultRunParallel = 1
 
+	defaultTestsReverseBudget = 5
+
+	defaultMutagensLevel = string(domain.LevelDefault)
+
 	envPrefix = "GOOZE"
 
 	logFilenameKey   = "log.filename"
@@ -77,7 +153,48 @@ func init() {
 	viper.SetDefault(runParallelConfigKey, defaultRunParallel)
 	viper.SetDefault(mutationTimeoutKey, int64(defaultMutationTimeout.Seconds()))
 	viper.SetDefault(runCoverageProfileKey, "")
+	viper.SetDefault(runDetectEquivalentKey, false)
+	viper.SetDefault(runPerTestCoverageKey, false)
+	viper.SetDefault(runBaselineKey, true)
+	viper.SetDefault(runBaselineCoverageKey, "")
+	viper.SetDefault(runTimeoutFactorKey, defaultTimeoutFactor)
+	viper.SetDefault(runTimeoutConstantKey, int64(defaultTimeoutConstant.Seconds()))
+	viper.SetDefault(runFlakyRerunsKey, 0)
+	viper.SetDefault(runFlakyBaselineKey, false)
+	viper.SetDefault(runLimitsMemoryKey, 0)
+	viper.SetDefault(runLimitsCPUKey, 0)
+	viper.SetDefault(runLimitsOpenFilesKey, 0)
+	viper.SetDefault(runSampleKey, "")
+	viper.SetDefault(runMaxPerFileKey, 0)
+	viper.SetDefault(runSeedKey, int64(0))
+	viper.SetDefault(runSinceKey, "")
+	viper.SetDefault(runTestFlagsKey, []string{})
+	viper.SetDefault(runTestTagsKey, []string{})
+	viper.SetDefault(runTestEnvKey, []string{})
+	viper.SetDefault(runWorkspaceKey, string(domain.WorkspaceCopy))
+	viper.SetDefault(runSnapshotMinimalKey, false)
+	viper.SetDefault(runSnapshotIncludeKey, []string{})
+	viper.SetDefault(runSnapshotLinksKey, true)
+	viper.SetDefault(runCacheStableKey, true)
+	viper.SetDefault(runCacheTrimpathKey, true)
+	viper.SetDefault(runCacheWarmUpKey, false)
+	viper.SetDefault(runSchemataKey, false)
 	viper.SetDefault(excludeConfigKey, []string{})
+	viper.SetDefault(skipGeneratedKey, true)
+	viper.SetDefault(skipVendorKey, true)
+	viper.SetDefault(skipTestdataKey, true)
+	viper.SetDefault(skipCgoKey, true)
+	viper.SetDefault(skipIgnoredKey, true)
+	viper.SetDefault(testsMappingsKey, []adapter.TestMapping{})
+	viper.SetDefault(testsReverseDepthKey, 0)
+	viper.SetDefault(testsReverseBudgetKey, defaultTestsReverseBudget)
+	viper.SetDefault(funcsMatchKey, []string{})
+	viper.SetDefault(funcsExcludeKey, []string{})
+	viper.SetDefault(mutagensLevelKey, defaultMutagensLevel)
+	viper.SetDefault(mutagensNumbersKey, []string{})
+	viper.SetDefault(mutagensComparisonKey, "")
+	viper.SetDefault(mutagensRulesKey, []domain.EquivalenceRule{})
+	viper.SetDefault(mutagensHigherOrderKey, 0)
 
 	// Logging defaults (used by config/env and as fallbacks for flags).
 	viper.SetDefault(logFilenameKey, defaultLogFilename)
@@ -99,6 +216,132 @@ func init() {
 	}
 }
 
+// operatorConfig builds the mutagen operator configuration from config/env.
+func operatorConfig() (domain.OperatorConfig, error) {
+	numbers := viper.GetStringSlice(mutagensNumbersKey)
+
+	variants := make([]mutagens.NumberVariant, 0, len(numbers))
+	for _, variant := range numbers {
+		variant = strings.ToLower(strings.TrimSpace(variant))
+		if variant != "" {
+			variants = append(variants, mutagens.NumberVariant(variant))
+		}
+	}
+
+	var rules []domain.EquivalenceRule
+	if err := viper.UnmarshalKey(mutagensRulesKey, &rules); err != nil {
+		return domain.OperatorConfig{}, fmt.Errorf("invalid %s: %w", mutagensRulesKey, err)
+	}
+
+	config := domain.OperatorConfig{
+		Level:       domain.OperatorLevel(viper.GetString(mutagensLevelKey)),
+		Numbers:     variants,
+		Comparison:  mutagens.ComparisonMode(strings.ToLower(strings.TrimSpace(viper.GetString(mutagensComparisonKey)))),
+		Rules:       rules,
+		HigherOrder: viper.GetInt(mutagensHigherOrderKey),
+	}
+
+	return config.Resolve()
+}
+
+// skipRules builds the source discovery skip rules from config/env.
+func skipRules() adapter.SkipRules {
+	return adapter.SkipRules{
+		Generated: viper.GetBool(skipGeneratedKey),
+		Vendor:    viper.GetBool(skipVendorKey),
+		Testdata:  viper.GetBool(skipTestdataKey),
+		Cgo:       viper.GetBool(skipCgoKey),
+		Ignored:   viper.GetBool(skipIgnoredKey),
+	}
+}
+
+// resourceLimits builds the resource limits of mutant test runs from
+// config/env.
+func resourceLimits() adapter.ResourceLimits {
+	return adapter.ResourceLimits{
+		MemoryMB:   viper.GetInt(runLimitsMemoryKey),
+		CPUSeconds: viper.GetInt(runLimitsCPUKey),
+		OpenFiles:  viper.GetInt(runLimitsOpenFilesKey),
+	}
+}
+
+// testSettings builds the go test flags, build tags and environment of every
+// test run from config/env and the run flags.
+func testSettings() (adapter.TestSettings, error) {
+	settings := adapter.TestSettings{
+		Flags: viper.GetStringSlice(runTestFlagsKey),
+		Tags:  viper.GetStringSlice(runTestTagsKey),
+		Env:   viper.GetStringSlice(runTestEnvKey),
+	}
+
+	if err := settings.Validate(); err != nil {
+		return adapter.TestSettings{}, fmt.Errorf("invalid run.test settings: %w", err)
+	}
+
+	if mode, err := workspaceMode(); err == nil && mode == domain.WorkspaceOverlay && settings.HasFlag("overlay") {
+		return adapter.TestSettings{}, fmt.Errorf("invalid run.test settings: -overlay is set by the %s workspace", domain.WorkspaceOverlay)
+	}
+
+	return settings, nil
+}
+
+// snapshotSettings builds the settings of the module snapshots workspaces copy
+// from config/env. It reports false when modules are copied whole.
+func snapshotSettings() (adapter.SnapshotSettings, bool) {
+	if !viper.GetBool(runSnapshotMinimalKey) {
+		return adapter.SnapshotSettings{}, false
+	}
+
+	return adapter.SnapshotSettings{
+		Include:   viper.GetStringSlice(runSnapshotIncludeKey),
+		Hardlinks: viper.GetBool(runSnapshotLinksKey),
+	}, true
+}
+
+// orchestratorOptions builds the workspace options of the orchestrator from
+// config/env.
+func orchestratorOptions() ([]domain.OrchestratorOption, error) {
+	mode, err := workspaceMode()
+	if err != nil {
+		return nil, err
+	}
+
+	return []domain.OrchestratorOption{
+		domain.WithWorkspaceMode(mode),
+		domain.WithStableWorkspaces(viper.GetBool(runCacheStableKey)),
+		domain.WithWarmUp(viper.GetBool(runCacheWarmUpKey)),
+	}, nil
+}
+
+// workspaceMode reads how mutants are applied to the project from config/env.
+func workspaceMode() (domain.WorkspaceMode, error) {
+	mode := domain.WorkspaceMode(strings.ToLower(strings.TrimSpace(viper.GetString(runWorkspaceKey))))
+
+	switch mode {
+	case domain.WorkspaceCopy, domain.WorkspaceOverlay:
+		return mode, nil
+	}
+
+	return "", fmt.Errorf("invalid %s %q: want %s or %s", runWorkspaceKey, mode, domain.WorkspaceCopy, domain.WorkspaceOverlay)
+}
+
+// testMappings reads the rules that map sources to the tests of other packages
+// from the config file.
+func testMappings() ([]adapter.TestMapping, error) {
+	var mappings []adapter.TestMapping
+	if err := viper.UnmarshalKey(testsMappingsKey, &mappings); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", testsMappingsKey, err)
+	}
+
+	for _, mapping := range mappings {
+		if strings.TrimSpace(mapping.Sources) == "" || len(mapping.Tests) == 0 {
+			return nil, fmt.Errorf("invalid %s: each mapping needs sources and tests", testsMappingsKey)
+		}
+	}
+
+	return mappings, nil
+}
+
 func parseSlogLevel(value string, defaultLevel slog.Level) slog.Level {
 	level := strings.ToLower(strings.TrimSpace(value))
 	if level == "" {
@@ -3,7 +3,11 @@ package cmd
 import (
 	"testing"
 
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
+	"gooze.dev/pkg/gooze/internal/adapter"
+	"gooze.dev/pkg/gooze/internal/domain"
+	"gooze.dev/pkg/gooze/internal/domain/mutagens"
 )
 
 func TestConfigConstants(t *testing.T) {
@@ -16,6 +20,32 @@ func TestConfigConstants(t *testing.T) {
 	assert.Equal(t, "parallel", runParallelFlagName)
 	assert.Equal(t, "run.parallel", runParallelConfigKey)
 	assert.Equal(t, "paths.exclude", excludeConfigKey)
+	assert.Equal(t, "mutagens.level", mutagensLevelKey)
+	assert.Equal(t, "mutagens.numbers.variants", mutagensNumbersKey)
+	assert.Equal(t, "mutagens.comparison.mode", mutagensComparisonKey)
+	assert.Equal(t, "mutagens.rules", mutagensRulesKey)
+	assert.Equal(t, "tests.reverse_deps.depth", testsReverseDepthKey)
+	assert.Equal(t, "tests.reverse_deps.budget", testsReverseBudgetKey)
+	assert.Equal(t, "run.baseline", runBaselineKey)
+	assert.Equal(t, "run.baseline_coverage", runBaselineCoverageKey)
+	assert.Equal(t, "run.timeout_factor", runTimeoutFactorKey)
+	assert.Equal(t, "run.timeout_constant", runTimeoutConstantKey)
+	assert.Equal(t, "run.flaky.reruns", runFlakyRerunsKey)
+	assert.Equal(t, "run.flaky.baseline", runFlakyBaselineKey)
+	assert.Equal(t, "run.limits.memory_mb", runLimitsMemoryKey)
+	assert.Equal(t, "run.limits.cpu_seconds", runLimitsCPUKey)
+	assert.Equal(t, "run.limits.open_files", runLimitsOpenFilesKey)
+	assert.Equal(t, "run.test.flags", runTestFlagsKey)
+	assert.Equal(t, "run.test.tags", runTestTagsKey)
+	assert.Equal(t, "run.test.env", runTestEnvKey)
+	assert.Equal(t, "run.workspace", runWorkspaceKey)
+	assert.Equal(t, "run.snapshot.minimal", runSnapshotMinimalKey)
+	assert.Equal(t, "run.snapshot.include", runSnapshotIncludeKey)
+	assert.Equal(t, "run.snapshot.hardlinks", runSnapshotLinksKey)
+	assert.Equal(t, "run.cache.stable_paths", runCacheStableKey)
+	assert.Equal(t, "run.cache.trimpath", runCacheTrimpathKey)
+	assert.Equal(t, "run.cache.warm_up", runCacheWarmUpKey)
+	assert.Equal(t, "run.schemata", runSchemataKey)
 	assert.Equal(t, ".gooze-reports", defaultReportsDir)
 	assert.Equal(t, false, defaultNoCache)
 	assert.Equal(t, 1, defaultRunParallel)
@@ -26,3 +56,193 @@ func TestConfigVersionConstants(t *testing.T) {
 	assert.Equal(t, "version", configVersionKey)
 	assert.Equal(t, 1, currentConfigVersion)
 }
+
+func TestOperatorConfig(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		operators, err := operatorConfig()
+		assert.NoError(t, err)
+		assert.Equal(t, domain.LevelDefault, operators.Level)
+		assert.Equal(t, mutagens.DefaultNumberVariants, operators.Numbers)
+		assert.Equal(t, mutagens.ComparisonFull, operators.Comparison)
+	})
+
+	t.Run("level with per-mutagen override", func(t *testing.T) {
+		viper.Set(mutagensLevelKey, "Light")
+		viper.Set(mutagensNumbersKey, []string{" Increment ", "negate"})
+		defer viper.Set(mutagensLevelKey, defaultMutagensLevel)
+		defer viper.Set(mutagensNumbersKey, []string{})
+
+		operators, err := operatorConfig()
+		assert.NoError(t, err)
+		assert.Equal(t, domain.LevelLight, operators.Level)
+		assert.Equal(t, []mutagens.NumberVariant{mutagens.NumberIncrement, mutagens.NumberNegate}, operators.Numbers)
+		assert.Equal(t, mutagens.ComparisonBoundary, operators.Comparison)
+	})
+
+	t.Run("unknown comparison mode", func(t *testing.T) {
+		viper.Set(mutagensComparisonKey, "sideways")
+		defer viper.Set(mutagensComparisonKey, "")
+
+		_, err := operatorConfig()
+		assert.Error(t, err)
+	})
+
+	t.Run("equivalence rules", func(t *testing.T) {
+		viper.Set(mutagensRulesKey, []map[string]any{
+			{"call": "metrics.Inc*", "mutagens": []string{"Numbers"}},
+			{"call": "logger.Debug", "args": []int{0}},
+		})
+		defer viper.Set(mutagensRulesKey, []domain.EquivalenceRule{})
+
+		operators, err := operatorConfig()
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.EquivalenceRule{
+			{Call: "metrics.Inc*", Mutagens: []string{"numbers"}},
+			{Call: "logger.Debug", Args: []int{0}, Mutagens: []string{}},
+		}, operators.Rules)
+	})
+
+	t.Run("higher order", func(t *testing.T) {
+		viper.Set(mutagensHigherOrderKey, 3)
+		defer viper.Set(mutagensHigherOrderKey, 0)
+
+		operators, err := operatorConfig()
+		assert.NoError(t, err)
+		assert.Equal(t, 3, operators.HigherOrder)
+	})
+
+	t.Run("unknown rule mutagen", func(t *testing.T) {
+		viper.Set(mutagensRulesKey, []map[string]any{{"call": "slog.*", "mutagens": []string{"everything"}}})
+		defer viper.Set(mutagensRulesKey, []domain.EquivalenceRule{})
+
+		_, err := operatorConfig()
+		assert.Error(t, err)
+	})
+}
+
+func TestSkipRules(t *testing.T) {
+	assert.Equal(t, adapter.DefaultSkipRules(), skipRules())
+
+	viper.Set(skipVendorKey, false)
+	viper.Set(skipIgnoredKey, false)
+	defer viper.Set(skipVendorKey, true)
+	defer viper.Set(skipIgnoredKey, true)
+
+	assert.Equal(t, adapter.SkipRules{Generated: true, Testdata: true, Cgo: true}, skipRules())
+}
+
+func TestResourceLimits(t *testing.T) {
+	assert.Equal(t, adapter.ResourceLimits{}, resourceLimits())
+
+	viper.Set(runLimitsMemoryKey, 2048)
+	viper.Set(runLimitsCPUKey, 300)
+	viper.Set(runLimitsOpenFilesKey, 1024)
+	defer viper.Set(runLimitsMemoryKey, 0)
+	defer viper.Set(runLimitsCPUKey, 0)
+	defer viper.Set(runLimitsOpenFilesKey, 0)
+
+	assert.Equal(t, adapter.ResourceLimits{MemoryMB: 2048, CPUSeconds: 300, OpenFiles: 1024}, resourceLimits())
+}
+
+func TestTestSettings(t *testing.T) {
+	settings, err := testSettings()
+	assert.NoError(t, err)
+	assert.Equal(t, adapter.TestSettings{Flags: []string{}, Tags: []string{}, Env: []string{}}, settings)
+
+	// Environment variables rather than overrides, so the run flags bound to
+	// these keys still take precedence in later tests.
+	t.Setenv("GOOZE_RUN_TEST_FLAGS", "-short -mod=vendor")
+	t.Setenv("GOOZE_RUN_TEST_TAGS", "integration")
+	t.Setenv("GOOZE_RUN_TEST_ENV", "CGO_ENABLED=0")
+
+	settings, err = testSettings()
+	assert.NoError(t, err)
+	assert.Equal(t, adapter.TestSettings{
+		Flags: []string{"-short", "-mod=vendor"},
+		Tags:  []string{"integration"},
+		Env:   []string{"CGO_ENABLED=0"},
+	}, settings)
+
+	t.Setenv("GOOZE_RUN_TEST_FLAGS", "-run=TestA")
+
+	_, err = testSettings()
+	assert.Error(t, err)
+
+	// The overlay workspace passes its own -overlay.
+	t.Setenv("GOOZE_RUN_TEST_FLAGS", "-overlay=overlay.json")
+
+	_, err = testSettings()
+	assert.NoError(t, err)
+
+	t.Setenv("GOOZE_RUN_WORKSPACE", "overlay")
+
+	_, err = testSettings()
+	assert.Error(t, err)
+}
+
+func TestSnapshotSettings(t *testing.T) {
+	// Modules are copied whole unless minimal snapshots are asked for.
+	_, ok := snapshotSettings()
+	assert.False(t, ok)
+
+	t.Setenv("GOOZE_RUN_SNAPSHOT_MINIMAL", "true")
+
+	settings, ok := snapshotSettings()
+	assert.True(t, ok)
+	assert.Equal(t, adapter.SnapshotSettings{Include: []string{}, Hardlinks: true}, settings)
+
+	t.Setenv("GOOZE_RUN_SNAPSHOT_INCLUDE", "config/*.yaml fixtures")
+	t.Setenv("GOOZE_RUN_SNAPSHOT_HARDLINKS", "false")
+
+	settings, ok = snapshotSettings()
+	assert.True(t, ok)
+	assert.Equal(t, adapter.SnapshotSettings{Include: []string{"config/*.yaml", "fixtures"}}, settings)
+}
+
+func TestOrchestratorOptions(t *testing.T) {
+	opts, err := orchestratorOptions()
+	assert.NoError(t, err)
+	assert.Len(t, opts, 3)
+
+	t.Setenv("GOOZE_RUN_WORKSPACE", "symlink")
+
+	_, err = orchestratorOptions()
+	assert.Error(t, err)
+}
+
+func TestWorkspaceMode(t *testing.T) {
+	mode, err := workspaceMode()
+	assert.NoError(t, err)
+	assert.Equal(t, domain.WorkspaceCopy, mode)
+
+	t.Setenv("GOOZE_RUN_WORKSPACE", " Overlay ")
+
+	mode, err = workspaceMode()
+	assert.NoError(t, err)
+	assert.Equal(t, domain.WorkspaceOverlay, mode)
+
+	t.Setenv("GOOZE_RUN_WORKSPACE", "symlink")
+
+	_, err = workspaceMode()
+	assert.Error(t, err)
+}
+
+func TestTestMappings(t *testing.T) {
+	mappings, err := testMappings()
+	assert.NoError(t, err)
+	assert.Empty(t, mappings)
+
+	viper.Set(testsMappingsKey, []map[string]any{
+		{"sources": "internal/store", "tests": []string{"./test/integration/store"}},
+	})
+	defer viper.Set(testsMappingsKey, []adapter.TestMapping{})
+
+	mappings, err = testMappings()
+	assert.NoError(t, err)
+	assert.Equal(t, []adapter.TestMapping{{Sources: "internal/store", Tests: []string{"./test/integration/store"}}}, mappings)
+
+	viper.Set(testsMappingsKey, []map[string]any{{"sources": "internal/store"}})
+
+	_, err = testMappings()
+	assert.Error(t, err)
+}
@@ -20,10 +20,13 @@ import (
 )
 
 var goFileAdapter adapter.GoFileAdapter
+var localSourceFS *adapter.LocalSourceFSAdapter
+var localTestRunner *adapter.LocalTestRunnerAdapter
 var sourceFSAdapter adapter.SourceFSAdapter
 var reportStore adapter.ReportStore
 var testAdapter adapter.TestRunnerAdapter
 var ociRegistry adapter.OCIRegistry
+var gitAdapter adapter.GitAdapter
 var orchestrator domain.Orchestrator
 var mutagen domain.Mutagen
 var workflow domain.Workflow
@@ -49,12 +52,49 @@ func init() {
 	// Initialize shared dependencies.
 	ui = controller.NewUI(rootCmd, controller.IsTTY(os.Stdout))
 	goFileAdapter = adapter.NewLocalGoFileAdapter()
-	sourceFSAdapter = adapter.NewLocalSourceFSAdapter()
-	reportStore = adapter.NewReportStore()
-	testAdapter = adapter.NewLocalTestRunnerAdapter()
+
+	mappings, err := testMappings()
+	cobra.CheckErr(err)
+
+	sourceFSOptions := []adapter.SourceFSOption{
+		adapter.WithSkipRules(skipRules()),
+		adapter.WithTestMappings(mappings...),
+		adapter.WithReverseDependencyTests(viper.GetInt(testsReverseDepthKey), viper.GetInt(testsReverseBudgetKey)),
+	}
+	if snapshot, ok := snapshotSettings(); ok {
+		sourceFSOptions = append(sourceFSOptions, adapter.WithSnapshots(snapshot))
+	}
+
+	localSourceFS = adapter.NewLocalSourceFSAdapter(sourceFSOptions...)
+	sourceFSAdapter = localSourceFS
+
+	operators, err := operatorConfig()
+	cobra.CheckErr(err)
+
+	reportedTypes := operators.MutationTypes(domain.DefaultMutations...)
+	if operators.HigherOrder > 1 {
+		reportedTypes = append(reportedTypes, m.MutationHigherOrder)
+	}
+
+	reportStore = adapter.NewReportStore(adapter.WithMutationTypes(reportedTypes...))
+	localTestRunner = adapter.NewLocalTestRunnerAdapter(
+		adapter.WithResourceLimits(resourceLimits()),
+		adapter.WithTrimpath(viper.GetBool(runCacheTrimpathKey)),
+	)
+	testAdapter = localTestRunner
+
+	cobra.CheckErr(configureTestSettings())
+
 	ociRegistry = adapter.NewORASRegistry()
-	orchestrator = domain.NewOrchestrator(sourceFSAdapter, testAdapter)
-	mutagen = domain.NewMutagen(goFileAdapter, sourceFSAdapter)
+	gitAdapter = adapter.NewLocalGitAdapter()
+	orchestratorOpts, err := orchestratorOptions()
+	cobra.CheckErr(err)
+
+	orchestrator = domain.NewOrchestrator(sourceFSAdapter, testAdapter, orchestratorOpts...)
+	mutagen = domain.NewMutagen(goFileAdapter, sourceFSAdapter,
+		domain.WithOperators(operators),
+		domain.WithSchemata(viper.GetBool(runSchemataKey)),
+	)
 	workflow = domain.NewWorkflow(
 		sourceFSAdapter,
 		reportStore,
@@ -76,10 +116,14 @@ func init() {
 	}))
 }
 
-const pathPatternsHelp = `Supports Go-style path patterns:
-  - ./...          recursively scan current directory
-  - ./pkg/...      recursively scan pkg directory
-  - ./cmd ./pkg    scan multiple directories`
+const pathPatternsHelp = `Supports Go-style path patterns, resolved with go list:
+  - ./...                 recursively scan current directory
+  - ./pkg/...             recursively scan pkg directory
+  - ./cmd ./pkg           scan multiple directories
+  - example.com/svc/...   import path patterns
+  - all                   every package of the main module
+
+Only files that are part of the build (GOOS, GOARCH and build tags) are mutated.`
 
 const rootLongDescription = `Gooze is a mutation testing tool for Go that helps you assess the quality
 of your test suite by introducing small changes (mutations) to your code
@@ -93,6 +137,10 @@ const runLongDescription = `Run mutation testing for the given paths. With no pa
 Use --estimate to list source files and applicable mutation counts without
 running tests.
 
+Limit mutation to lines with file targets such as pkg/foo/bar.go:40-80 (or
+bar.go:40, bar.go:10-20,40), or to the lines changed since a git revision with
+--since origin/main.
+
 ` + pathPatternsHelp
 
 // rootCmd represents the base command when called without any subcommands.
@@ -151,6 +199,21 @@ func configureRootFlags(cmd *cobra.Command) {
 	cmd.PersistentFlags().StringVar(&logOutputFlag, "log-output", "", "path to the log output file")
 }
 
+// configureTestSettings applies the test settings to the adapters resolving
+// and running tests. The run command applies them again once its flags, which
+// may override them, are parsed.
+func configureTestSettings() error {
+	settings, err := testSettings()
+	if err != nil {
+		return err
+	}
+
+	adapter.WithBuildSettings(settings)(localSourceFS)
+	adapter.WithTestSettings(settings)(localTestRunner)
+
+	return nil
+}
+
 // bindFlagToConfig wires a Cobra flag to a Viper key so config/env values feed the flag.
 func bindFlagToConfig(flag *pflag.Flag, key string) {
 	if flag == nil {
@@ -3,6 +3,12 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"math"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -13,10 +19,27 @@ import (
 )
 
 var mutationTimeoutFlag int64
+var timeoutFactorFlag float64
+var timeoutConstantFlag int64
+var runBaselineFlag bool
+var runBaselineCoverageFlag string
+var runFlakyRerunsFlag int
+var runFlakyBaselineFlag bool
 var runParallelFlag int
 var runShardFlag string
 var runEstimateFlag bool
 var runCoverageProfileFlag string
+var runDetectEquivalentFlag bool
+var runPerTestCoverageFlag bool
+var runSampleFlag string
+var runMaxPerFileFlag int
+var runSeedFlag int64
+var runFuncFlag []string
+var runExcludeFuncFlag []string
+var runSinceFlag string
+var runTestFlagFlag []string
+var runTagsFlag []string
+var runTestEnvFlag []string
 
 // runCmd represents the run command.
 var runCmd = newRunCmd()
@@ -33,29 +56,63 @@ func newRunCmd() *cobra.Command {
 				args = []string{"./..."}
 			}
 
+			if err := configureTestSettings(); err != nil {
+				return err
+			}
+
+			ctx := context.Background()
+
+			paths, lines, err := resolveTargets(ctx, args, viper.GetString(runSinceKey))
+			if err != nil {
+				return err
+			}
+
 			reportsPath := m.Path(viper.GetString(outputFlagName))
 			estimateArgs := domain.EstimateArgs{
-				Paths:    parsePaths(args),
+				Paths:    paths,
 				Exclude:  viper.GetStringSlice(excludeConfigKey),
 				UseCache: !viper.GetBool(noCacheFlagName),
 				Reports:  reportsPath,
+				Funcs: domain.FuncFilter{
+					Match:   viper.GetStringSlice(funcsMatchKey),
+					Exclude: viper.GetStringSlice(funcsExcludeKey),
+				},
+				Lines: lines,
 			}
 
 			if runEstimateFlag {
-				return workflow.Estimate(context.Background(), estimateArgs)
+				return workflow.Estimate(ctx, estimateArgs)
 			}
 
 			shardIndex, totalShards := parseShardFlag(runShardFlag)
 			timeoutSeconds := viper.GetInt64(mutationTimeoutKey)
 
-			return workflow.Test(context.Background(), domain.TestArgs{
-				EstimateArgs:    estimateArgs,
-				Reports:         reportsPath,
-				Threads:         viper.GetInt(runParallelConfigKey),
-				ShardIndex:      shardIndex,
-				TotalShardCount: totalShards,
-				MutationTimeout: time.Duration(timeoutSeconds) * time.Second,
-				CoverageProfile: m.Path(viper.GetString(runCoverageProfileKey)),
+			rate, err := parseSampleRate(viper.GetString(runSampleKey))
+			if err != nil {
+				return err
+			}
+
+			return workflow.Test(ctx, domain.TestArgs{
+				EstimateArgs:     estimateArgs,
+				Reports:          reportsPath,
+				Threads:          viper.GetInt(runParallelConfigKey),
+				ShardIndex:       shardIndex,
+				TotalShardCount:  totalShards,
+				MutationTimeout:  time.Duration(timeoutSeconds) * time.Second,
+				CoverageProfile:  m.Path(viper.GetString(runCoverageProfileKey)),
+				DetectEquivalent: viper.GetBool(runDetectEquivalentKey),
+				PerTestCoverage:  viper.GetBool(runPerTestCoverageKey),
+				Baseline:         viper.GetBool(runBaselineKey),
+				BaselineCoverage: m.Path(viper.GetString(runBaselineCoverageKey)),
+				TimeoutFactor:    viper.GetFloat64(runTimeoutFactorKey),
+				TimeoutConstant:  time.Duration(viper.GetInt64(runTimeoutConstantKey)) * time.Second,
+				FlakyReruns:      viper.GetInt(runFlakyRerunsKey),
+				FlakyBaseline:    viper.GetBool(runFlakyBaselineKey),
+				Sampling: domain.Sampling{
+					Rate:       rate,
+					MaxPerFile: viper.GetInt(runMaxPerFileKey),
+					Seed:       viper.GetInt64(runSeedKey),
+				},
 			})
 		},
 	}
@@ -70,16 +127,199 @@ func init() {
 }
 
 func configureRunFlags(cmd *cobra.Command) {
-	cmd.Flags().Int64Var(&mutationTimeoutFlag, mutationTimeoutFlagName, viper.GetInt64(mutationTimeoutKey), "timeout duration for testing a mutation in seconds")
+	cmd.Flags().Int64Var(&mutationTimeoutFlag, mutationTimeoutFlagName, viper.GetInt64(mutationTimeoutKey), "timeout duration for testing a mutation in seconds, used when its tests have no baseline duration")
 	bindFlagToConfig(cmd.Flags().Lookup(mutationTimeoutFlagName), mutationTimeoutKey)
 
+	cmd.Flags().BoolVar(&runBaselineFlag, baselineFlagName, viper.GetBool(runBaselineKey), "run the affected tests once on the unmutated code first, abort if they fail, and derive each mutation's timeout from their duration")
+	bindFlagToConfig(cmd.Flags().Lookup(baselineFlagName), runBaselineKey)
+	cmd.Flags().Float64Var(&timeoutFactorFlag, timeoutFactorFlagName, viper.GetFloat64(runTimeoutFactorKey), "mutation timeout as a multiple of the baseline duration of its tests (0 to always use --mutation-timeout)")
+	bindFlagToConfig(cmd.Flags().Lookup(timeoutFactorFlagName), runTimeoutFactorKey)
+	cmd.Flags().Int64Var(&timeoutConstantFlag, timeoutConstantFlagName, viper.GetInt64(runTimeoutConstantKey), "seconds added to the baseline-derived mutation timeout")
+	bindFlagToConfig(cmd.Flags().Lookup(timeoutConstantFlagName), runTimeoutConstantKey)
+	cmd.Flags().StringVar(&runBaselineCoverageFlag, baselineCoverageFlagName, viper.GetString(runBaselineCoverageKey), "record coverage during the baseline run, write the profile to this path and use it as --coverage-profile")
+	bindFlagToConfig(cmd.Flags().Lookup(baselineCoverageFlagName), runBaselineCoverageKey)
+
+	cmd.Flags().IntVar(&runFlakyRerunsFlag, flakyRerunsFlagName, viper.GetInt(runFlakyRerunsKey), "run the tests of surviving and timed-out mutations this many more times; mutations whose outcome changes are reported as flaky")
+	bindFlagToConfig(cmd.Flags().Lookup(flakyRerunsFlagName), runFlakyRerunsKey)
+	cmd.Flags().BoolVar(&runFlakyBaselineFlag, flakyBaselineFlagName, viper.GetBool(runFlakyBaselineKey), "also run the baseline --flaky-reruns more times and abort if any run fails")
+	bindFlagToConfig(cmd.Flags().Lookup(flakyBaselineFlagName), runFlakyBaselineKey)
+
 	cmd.Flags().IntVarP(&runParallelFlag, runParallelFlagName, "p", viper.GetInt(runParallelConfigKey), "number of parallel workers for mutation testing")
 	bindFlagToConfig(cmd.Flags().Lookup(runParallelFlagName), runParallelConfigKey)
 	cmd.Flags().StringVarP(&runShardFlag, "shard", "s", "", "shard index and total shard count in the format INDEX/TOTAL (e.g., 0/3)")
 	cmd.Flags().BoolVar(&runEstimateFlag, "estimate", false, "list source files and applicable mutation counts without running tests")
 
+	cmd.Flags().StringArrayVar(&runFuncFlag, funcFlagName, viper.GetStringSlice(funcsMatchKey), "only mutate functions matching regex, by name or Receiver.Method (can be repeated)")
+	bindFlagToConfig(cmd.Flags().Lookup(funcFlagName), funcsMatchKey)
+	cmd.Flags().StringArrayVar(&runExcludeFuncFlag, excludeFuncFlagName, viper.GetStringSlice(funcsExcludeKey), "do not mutate functions matching regex, by name or Receiver.Method (can be repeated)")
+	bindFlagToConfig(cmd.Flags().Lookup(excludeFuncFlagName), funcsExcludeKey)
+
+	cmd.Flags().StringVar(&runSinceFlag, sinceFlagName, viper.GetString(runSinceKey), "only mutate the lines changed since the merge base with this git revision (e.g., origin/main)")
+	bindFlagToConfig(cmd.Flags().Lookup(sinceFlagName), runSinceKey)
+
+	cmd.Flags().StringArrayVar(&runTestFlagFlag, testFlagFlagName, viper.GetStringSlice(runTestFlagsKey), "flag passed to every go test run, as -name=value (e.g. -short, -race, -mod=vendor; can be repeated)")
+	bindFlagToConfig(cmd.Flags().Lookup(testFlagFlagName), runTestFlagsKey)
+	cmd.Flags().StringSliceVar(&runTagsFlag, tagsFlagName, viper.GetStringSlice(runTestTagsKey), "comma-separated build tags for go test and for deciding which files are part of the build")
+	bindFlagToConfig(cmd.Flags().Lookup(tagsFlagName), runTestTagsKey)
+	cmd.Flags().StringArrayVar(&runTestEnvFlag, testEnvFlagName, viper.GetStringSlice(runTestEnvKey), "KEY=VALUE environment variable for every go test run (e.g. CGO_ENABLED=0; can be repeated)")
+	bindFlagToConfig(cmd.Flags().Lookup(testEnvFlagName), runTestEnvKey)
+
 	cmd.Flags().StringVar(&runCoverageProfileFlag, coverageProfileFlagName, viper.GetString(runCoverageProfileKey), "path to a Go coverage profile; mutations on uncovered lines are reported as not_covered without running tests")
 	bindFlagToConfig(cmd.Flags().Lookup(coverageProfileFlagName), runCoverageProfileKey)
+	cmd.Flags().BoolVar(&runPerTestCoverageFlag, perTestCoverageFlagName, viper.GetBool(runPerTestCoverageKey), "map the lines each test reaches first, then run only the tests reaching a mutated line; mutations no test reaches are reported as not_covered")
+	bindFlagToConfig(cmd.Flags().Lookup(perTestCoverageFlagName), runPerTestCoverageKey)
+
+	cmd.Flags().BoolVar(&runDetectEquivalentFlag, detectEquivalentFlagName, viper.GetBool(runDetectEquivalentKey), "compile surviving mutants and report those that compile to the original code as equivalent")
+	bindFlagToConfig(cmd.Flags().Lookup(detectEquivalentFlagName), runDetectEquivalentKey)
+
+	cmd.Flags().StringVar(&runSampleFlag, sampleFlagName, viper.GetString(runSampleKey), "test a random sample of the mutants of each file, as a percentage (20%) or fraction (0.2); the rest are reported as skipped")
+	bindFlagToConfig(cmd.Flags().Lookup(sampleFlagName), runSampleKey)
+	cmd.Flags().IntVar(&runMaxPerFileFlag, maxPerFileFlagName, viper.GetInt(runMaxPerFileKey), "test at most this many mutants per file, spread across mutation types (0 for no cap)")
+	bindFlagToConfig(cmd.Flags().Lookup(maxPerFileFlagName), runMaxPerFileKey)
+	cmd.Flags().Int64Var(&runSeedFlag, seedFlagName, viper.GetInt64(runSeedKey), "seed for --sample and --max-per-file; the same seed selects the same mutants")
+	bindFlagToConfig(cmd.Flags().Lookup(seedFlagName), runSeedKey)
+}
+
+// lineTarget matches a file target with line ranges, such as "foo.go:40-80",
+// "foo.go:40" or "foo.go:10-20,40".
+var lineTarget = regexp.MustCompile(`^(.+\.go):(\d+(?:-\d+)?(?:,\d+(?:-\d+)?)*)$`)
+
+// resolveTargets splits the run arguments into the paths to scan and the lines
+// to mutate. Lines come from file:lines targets and, when since is set, from
+// the git diff against it. The selection is nil when neither names any lines;
+// otherwise plain .go file targets are selected whole.
+func resolveTargets(ctx context.Context, args []string, since string) ([]m.Path, domain.LineSelection, error) {
+	var (
+		selection domain.LineSelection
+		plain     []string
+		files     []string
+	)
+
+	for _, arg := range args {
+		match := lineTarget.FindStringSubmatch(arg)
+		if match == nil {
+			plain = append(plain, arg)
+
+			if strings.HasSuffix(arg, ".go") {
+				files = append(files, arg)
+			}
+
+			continue
+		}
+
+		ranges, err := parseLineRanges(match[2])
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid target %q: %w", arg, err)
+		}
+
+		if selection == nil {
+			selection = domain.LineSelection{}
+		}
+
+		if err := selectLines(selection, match[1], ranges...); err != nil {
+			return nil, nil, err
+		}
+
+		if !slices.Contains(plain, match[1]) {
+			plain = append(plain, match[1])
+		}
+	}
+
+	if since != "" {
+		changed, err := gitAdapter.ChangedLines(ctx, since)
+		if err != nil {
+			return nil, nil, fmt.Errorf("resolve changes since %s: %w", since, err)
+		}
+
+		if selection == nil {
+			selection = domain.LineSelection{}
+		}
+
+		for path, ranges := range changed {
+			for _, r := range ranges {
+				selection.Add(path, r)
+			}
+		}
+	}
+
+	if selection != nil {
+		for _, file := range files {
+			if err := selectLines(selection, file, m.LineRange{Start: 1, End: math.MaxInt}); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	return parsePaths(plain), selection, nil
+}
+
+func selectLines(selection domain.LineSelection, file string, ranges ...m.LineRange) error {
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return fmt.Errorf("resolve %s: %w", file, err)
+	}
+
+	for _, r := range ranges {
+		selection.Add(m.Path(abs), r)
+	}
+
+	return nil
+}
+
+// parseLineRanges parses comma-separated line ranges ("10-20,40").
+func parseLineRanges(spec string) ([]m.LineRange, error) {
+	parts := strings.Split(spec, ",")
+	ranges := make([]m.LineRange, 0, len(parts))
+
+	for _, part := range parts {
+		startText, endText, isRange := strings.Cut(part, "-")
+
+		start, err := strconv.Atoi(startText)
+		if err != nil {
+			return nil, err
+		}
+
+		end := start
+		if isRange {
+			if end, err = strconv.Atoi(endText); err != nil {
+				return nil, err
+			}
+		}
+
+		if start < 1 || end < start {
+			return nil, fmt.Errorf("invalid line range %q", part)
+		}
+
+		ranges = append(ranges, m.LineRange{Start: start, End: end})
+	}
+
+	return ranges, nil
+}
+
+// parseSampleRate parses a sample rate given as a percentage ("20%") or a
+// fraction ("0.2"). An empty rate samples everything.
+func parseSampleRate(sample string) (float64, error) {
+	sample = strings.TrimSpace(sample)
+	if sample == "" {
+		return 0, nil
+	}
+
+	percent := strings.HasSuffix(sample, "%")
+
+	rate, err := strconv.ParseFloat(strings.TrimSuffix(sample, "%"), 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid --%s %q: %w", sampleFlagName, sample, err)
+	}
+
+	if percent {
+		rate /= 100
+	}
+
+	if rate <= 0 || rate > 1 {
+		return 0, fmt.Errorf("invalid --%s %q: must be between 0 and 100%%", sampleFlagName, sample)
+	}
+
+	return rate, nil
 }
 
 func parseShardFlag(shard string) (int, int) {
@@ -2,6 +2,9 @@ package cmd
 
 import (
 	"bytes"
+	"context"
+	"math"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -9,6 +12,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	adaptermocks "gooze.dev/pkg/gooze/internal/adapter/mocks"
 	"gooze.dev/pkg/gooze/internal/domain"
 	domainmocks "gooze.dev/pkg/gooze/internal/domain/mocks"
 	m "gooze.dev/pkg/gooze/internal/model"
@@ -245,3 +249,264 @@ func TestNewRunCmd(t *testing.T) {
 	shardFlag := cmd.Flags().Lookup("shard")
 	assert.NotNil(t, shardFlag)
 }
+
+func TestRunCmd_DetectEquivalentFlag(t *testing.T) {
+	mockWorkflow := domainmocks.NewMockWorkflow(t)
+
+	cmd := newRootCmd()
+	cmd.AddCommand(newRunCmd())
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+
+	originalWorkflow := workflow
+	workflow = mockWorkflow
+	defer func() { workflow = originalWorkflow }()
+	defer viper.Set(runDetectEquivalentKey, false)
+
+	mockWorkflow.On("Test", mock.Anything, mock.MatchedBy(func(args domain.TestArgs) bool {
+		return args.DetectEquivalent
+	})).Return(nil)
+
+	cmd.SetArgs([]string{"run", "--detect-equivalent", "./..."})
+	require.NoError(t, cmd.Execute())
+
+	mockWorkflow.AssertExpectations(t)
+}
+
+func TestRunCmd_PerTestCoverageFlag(t *testing.T) {
+	mockWorkflow := domainmocks.NewMockWorkflow(t)
+
+	cmd := newRootCmd()
+	cmd.AddCommand(newRunCmd())
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+
+	originalWorkflow := workflow
+	workflow = mockWorkflow
+	defer func() { workflow = originalWorkflow }()
+	defer viper.Set(runPerTestCoverageKey, false)
+
+	mockWorkflow.On("Test", mock.Anything, mock.MatchedBy(func(args domain.TestArgs) bool {
+		return args.PerTestCoverage
+	})).Return(nil)
+
+	cmd.SetArgs([]string{"run", "--per-test-coverage", "./..."})
+	require.NoError(t, cmd.Execute())
+
+	mockWorkflow.AssertExpectations(t)
+}
+
+func TestRunCmd_BaselineFlags(t *testing.T) {
+	mockWorkflow := domainmocks.NewMockWorkflow(t)
+
+	cmd := newRootCmd()
+	cmd.AddCommand(newRunCmd())
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+
+	originalWorkflow := workflow
+	workflow = mockWorkflow
+	defer func() { workflow = originalWorkflow }()
+	defer viper.Set(runBaselineKey, true)
+	defer viper.Set(runBaselineCoverageKey, "")
+	defer viper.Set(runTimeoutFactorKey, defaultTimeoutFactor)
+	defer viper.Set(runTimeoutConstantKey, int64(defaultTimeoutConstant.Seconds()))
+
+	mockWorkflow.On("Test", mock.Anything, mock.MatchedBy(func(args domain.TestArgs) bool {
+		return !args.Baseline && args.BaselineCoverage == "cover.out" &&
+			args.TimeoutFactor == 1.5 && args.TimeoutConstant == 5*time.Second
+	})).Return(nil)
+
+	cmd.SetArgs([]string{"run", "--baseline=false", "--baseline-coverage", "cover.out", "--timeout-factor", "1.5", "--timeout-constant", "5", "./..."})
+	require.NoError(t, cmd.Execute())
+
+	mockWorkflow.AssertExpectations(t)
+}
+
+func TestRunCmd_FlakyFlags(t *testing.T) {
+	mockWorkflow := domainmocks.NewMockWorkflow(t)
+
+	cmd := newRootCmd()
+	cmd.AddCommand(newRunCmd())
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+
+	originalWorkflow := workflow
+	workflow = mockWorkflow
+	defer func() { workflow = originalWorkflow }()
+	defer viper.Set(runFlakyRerunsKey, 0)
+	defer viper.Set(runFlakyBaselineKey, false)
+
+	mockWorkflow.On("Test", mock.Anything, mock.MatchedBy(func(args domain.TestArgs) bool {
+		return args.FlakyReruns == 2 && args.FlakyBaseline
+	})).Return(nil)
+
+	cmd.SetArgs([]string{"run", "--flaky-reruns", "2", "--flaky-baseline", "./..."})
+	require.NoError(t, cmd.Execute())
+
+	mockWorkflow.AssertExpectations(t)
+}
+
+func TestRunCmd_TestSettingsFlags(t *testing.T) {
+	mockWorkflow := domainmocks.NewMockWorkflow(t)
+
+	cmd := newRootCmd()
+	cmd.AddCommand(newRunCmd())
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+
+	originalWorkflow := workflow
+	workflow = mockWorkflow
+	defer func() { workflow = originalWorkflow }()
+	defer func() { require.NoError(t, configureTestSettings()) }()
+	defer viper.Set(runTestFlagsKey, []string{})
+	defer viper.Set(runTestTagsKey, []string{})
+	defer viper.Set(runTestEnvKey, []string{})
+
+	mockWorkflow.On("Estimate", mock.Anything, mock.Anything).Return(nil)
+
+	cmd.SetArgs([]string{"run", "--estimate", "--test-flag=-short", "--test-flag=-race", "--tags", "integration,e2e", "--test-env", "CGO_ENABLED=0", "./..."})
+	require.NoError(t, cmd.Execute())
+
+	settings, err := testSettings()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"-short", "-race"}, settings.Flags)
+	assert.Equal(t, []string{"integration", "e2e"}, settings.Tags)
+	assert.Equal(t, []string{"CGO_ENABLED=0"}, settings.Env)
+
+	cmd.SetArgs([]string{"run", "--estimate", "--test-flag=-run=TestA", "./..."})
+	require.Error(t, cmd.Execute())
+}
+
+func TestRunCmd_SamplingFlags(t *testing.T) {
+	mockWorkflow := domainmocks.NewMockWorkflow(t)
+
+	cmd := newRootCmd()
+	cmd.AddCommand(newRunCmd())
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+
+	originalWorkflow := workflow
+	workflow = mockWorkflow
+	defer func() { workflow = originalWorkflow }()
+	defer viper.Set(runSampleKey, "")
+	defer viper.Set(runMaxPerFileKey, 0)
+	defer viper.Set(runSeedKey, int64(0))
+
+	mockWorkflow.On("Test", mock.Anything, mock.MatchedBy(func(args domain.TestArgs) bool {
+		return args.Sampling == domain.Sampling{Rate: 0.2, MaxPerFile: 5, Seed: 42}
+	})).Return(nil)
+
+	cmd.SetArgs([]string{"run", "--sample", "20%", "--max-per-file", "5", "--seed", "42", "./..."})
+	require.NoError(t, cmd.Execute())
+
+	mockWorkflow.AssertExpectations(t)
+}
+
+func TestParseSampleRate(t *testing.T) {
+	for sample, want := range map[string]float64{"": 0, "20%": 0.2, "0.5": 0.5, " 100% ": 1} {
+		rate, err := parseSampleRate(sample)
+		require.NoError(t, err, sample)
+		assert.InDelta(t, want, rate, 1e-9, sample)
+	}
+
+	for _, sample := range []string{"abc", "0", "150%", "-1"} {
+		_, err := parseSampleRate(sample)
+		assert.Error(t, err, sample)
+	}
+}
+
+func TestRunCmd_FuncFlags(t *testing.T) {
+	mockWorkflow := domainmocks.NewMockWorkflow(t)
+
+	cmd := newRootCmd()
+	cmd.AddCommand(newRunCmd())
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+
+	originalWorkflow := workflow
+	workflow = mockWorkflow
+	defer func() { workflow = originalWorkflow }()
+	defer viper.Set(funcsMatchKey, []string{})
+	defer viper.Set(funcsExcludeKey, []string{})
+
+	mockWorkflow.On("Estimate", mock.Anything, mock.MatchedBy(func(args domain.EstimateArgs) bool {
+		return assert.ObjectsAreEqual(domain.FuncFilter{Match: []string{"Parse.*", `Config\.Load`}, Exclude: []string{"String$"}}, args.Funcs)
+	})).Return(nil)
+
+	cmd.SetArgs([]string{"run", "--estimate", "--func", "Parse.*", "--func", `Config\.Load`, "--exclude-func", "String$", "./..."})
+	require.NoError(t, cmd.Execute())
+
+	mockWorkflow.AssertExpectations(t)
+}
+
+func TestRunCmd_LineTargets(t *testing.T) {
+	mockWorkflow := domainmocks.NewMockWorkflow(t)
+
+	cmd := newRootCmd()
+	cmd.AddCommand(newRunCmd())
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+
+	originalWorkflow := workflow
+	workflow = mockWorkflow
+	defer func() { workflow = originalWorkflow }()
+
+	bar, err := filepath.Abs("pkg/foo/bar.go")
+	require.NoError(t, err)
+	baz, err := filepath.Abs("pkg/foo/baz.go")
+	require.NoError(t, err)
+
+	mockWorkflow.On("Estimate", mock.Anything, mock.MatchedBy(func(args domain.EstimateArgs) bool {
+		return assert.ObjectsAreEqual([]m.Path{"pkg/foo/bar.go", "pkg/foo/baz.go"}, args.Paths) &&
+			assert.ObjectsAreEqual(domain.LineSelection{
+				m.Path(bar): {{Start: 40, End: 80}, {Start: 90, End: 90}},
+				m.Path(baz): {{Start: 1, End: math.MaxInt}},
+			}, args.Lines)
+	})).Return(nil)
+
+	cmd.SetArgs([]string{"run", "--estimate", "pkg/foo/bar.go:40-80", "pkg/foo/baz.go", "pkg/foo/bar.go:90"})
+	require.NoError(t, cmd.Execute())
+
+	mockWorkflow.AssertExpectations(t)
+}
+
+func TestRunCmd_Since(t *testing.T) {
+	mockWorkflow := domainmocks.NewMockWorkflow(t)
+	mockGit := adaptermocks.NewMockGitAdapter(t)
+
+	cmd := newRootCmd()
+	cmd.AddCommand(newRunCmd())
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+
+	originalWorkflow, originalGit := workflow, gitAdapter
+	workflow, gitAdapter = mockWorkflow, mockGit
+	defer func() { workflow, gitAdapter = originalWorkflow, originalGit }()
+	defer viper.Set(runSinceKey, "")
+
+	changed := map[m.Path][]m.LineRange{"/abs/pkg/foo.go": {{Start: 3, End: 7}}}
+	mockGit.EXPECT().ChangedLines(mock.Anything, "origin/main").Return(changed, nil).Once()
+
+	mockWorkflow.On("Estimate", mock.Anything, mock.MatchedBy(func(args domain.EstimateArgs) bool {
+		return assert.ObjectsAreEqual([]m.Path{"./..."}, args.Paths) &&
+			assert.ObjectsAreEqual(domain.LineSelection(changed), args.Lines)
+	})).Return(nil)
+
+	cmd.SetArgs([]string{"run", "--estimate", "--since", "origin/main"})
+	require.NoError(t, cmd.Execute())
+
+	mockWorkflow.AssertExpectations(t)
+}
+
+func TestResolveTargets(t *testing.T) {
+	paths, lines, err := resolveTargets(context.Background(), []string{"./...", "cmd"}, "")
+	require.NoError(t, err)
+	assert.Equal(t, []m.Path{"./...", "cmd"}, paths)
+	assert.Nil(t, lines, "no selection without line targets")
+
+	for _, target := range []string{"foo.go:0", "foo.go:80-40", "foo.go:1-2,0"} {
+		_, _, err := resolveTargets(context.Background(), []string{target}, "")
+		assert.Error(t, err, target)
+	}
+}
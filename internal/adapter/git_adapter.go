@@ -0,0 +1,129 @@
+package adapter
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	m "gooze.dev/pkg/gooze/internal/model"
+)
+
+// GitAdapter abstracts the git operations used to target changed code.
+type GitAdapter interface {
+	// ChangedLines returns the lines of Go files added or modified since the
+	// merge base of HEAD and the given revision, uncommitted changes included,
+	// keyed by absolute file path.
+	ChangedLines(ctx context.Context, since string) (map[m.Path][]m.LineRange, error)
+}
+
+// LocalGitAdapter runs the local git binary in the working directory.
+type LocalGitAdapter struct {
+}
+
+// NewLocalGitAdapter constructs a LocalGitAdapter.
+func NewLocalGitAdapter() *LocalGitAdapter {
+	return &LocalGitAdapter{}
+}
+
+// ChangedLines diffs the working tree against the merge base of HEAD and since.
+// Only files under the working directory are reported. Untracked files are not
+// part of the diff and so are not reported either.
+func (a *LocalGitAdapter) ChangedLines(ctx context.Context, since string) (map[m.Path][]m.LineRange, error) {
+	// Paths are made relative to the working directory and resolved the way the
+	// source scanner resolves them, so they match even under a symlinked path.
+	root, err := filepath.Abs(".")
+	if err != nil {
+		return nil, fmt.Errorf("resolve working directory: %w", err)
+	}
+
+	base, err := a.git(ctx, "merge-base", since, "HEAD")
+	if err != nil {
+		return nil, err
+	}
+
+	// The prefixes are set so that the user's diff settings, such as
+	// diff.noprefix or diff.mnemonicPrefix, do not change the file names.
+	diff, err := a.git(ctx, "diff", "--unified=0", "--no-color", "--no-ext-diff", "--relative",
+		"--src-prefix=a/", "--dst-prefix=b/", strings.TrimSpace(base), "--", "*.go")
+	if err != nil {
+		return nil, err
+	}
+
+	return parseDiffHunks(root, diff), nil
+}
+
+func (a *LocalGitAdapter) git(ctx context.Context, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+
+	var stdout, stderr bytes.Buffer
+
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.String(), nil
+}
+
+// hunkHeader matches the "@@ -a,b +c,d @@" header of a unified diff hunk and
+// captures the new file's start line and optional line count.
+var hunkHeader = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)(?:,(\d+))? @@`)
+
+// parseDiffHunks collects the new-file line ranges of a zero-context unified
+// diff. Hunks that only delete lines add nothing, nor do deleted files.
+func parseDiffHunks(root, diff string) map[m.Path][]m.LineRange {
+	changed := map[m.Path][]m.LineRange{}
+
+	var file m.Path
+
+	previous := ""
+
+	scanner := bufio.NewScanner(strings.NewReader(diff))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		header := strings.HasPrefix(previous, "--- ")
+		previous = line
+
+		switch {
+		// The new file name follows the old one; an added line can look alike.
+		case header && strings.HasPrefix(line, "+++ "):
+			name := strings.TrimPrefix(line, "+++ ")
+			if name == "/dev/null" {
+				file = ""
+				continue
+			}
+
+			file = m.Path(filepath.Join(root, filepath.FromSlash(strings.TrimPrefix(name, "b/"))))
+		case file != "" && strings.HasPrefix(line, "@@"):
+			match := hunkHeader.FindStringSubmatch(line)
+			if match == nil {
+				continue
+			}
+
+			start, _ := strconv.Atoi(match[1])
+
+			count := 1
+			if match[2] != "" {
+				count, _ = strconv.Atoi(match[2])
+			}
+
+			if count == 0 {
+				continue
+			}
+
+			changed[file] = append(changed[file], m.LineRange{Start: start, End: start + count - 1})
+		}
+	}
+
+	return changed
+}
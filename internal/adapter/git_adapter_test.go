@@ -0,0 +1,109 @@
+package adapter
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	m "gooze.dev/pkg/gooze/internal/model"
+)
+
+func TestParseDiffHunks(t *testing.T) {
+	diff := `diff --git a/pkg/foo.go b/pkg/foo.go
+index 1111111..2222222 100644
+--- a/pkg/foo.go
++++ b/pkg/foo.go
+@@ -3,0 +4,2 @@ func Foo() {
++	a := 1
++	b := 2
+@@ -10 +12 @@ func Bar() {
+-	return 1
++	return 2
+@@ -20,3 +22,0 @@ func Baz() {
+-	x()
+-	y()
+-	z()
+diff --git a/pkg/old.go b/pkg/old.go
+deleted file mode 100644
+--- a/pkg/old.go
++++ /dev/null
+@@ -1,3 +0,0 @@
+-package pkg
+`
+
+	got := parseDiffHunks("/repo", diff)
+	want := map[m.Path][]m.LineRange{
+		m.Path(filepath.Join("/repo", "pkg", "foo.go")): {{Start: 4, End: 5}, {Start: 12, End: 12}},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("parseDiffHunks() = %v, want %v", got, want)
+	}
+}
+
+func TestLocalGitAdapter_ChangedLines(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	run := func(args ...string) {
+		t.Helper()
+
+		cmd := exec.Command("git", args...)
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@t", "GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@t")
+
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+
+	write := func(content string) {
+		t.Helper()
+
+		if err := os.WriteFile(filepath.Join(dir, "foo.go"), []byte(content), 0o600); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+
+	run("init", "-q", "-b", "main")
+	// Diff prefix settings must not change the reported file names.
+	run("config", "diff.mnemonicPrefix", "true")
+	write("package foo\n\nfunc Foo() int {\n\treturn 1\n}\n")
+	run("add", ".")
+	run("commit", "-q", "-m", "base")
+	run("checkout", "-q", "-b", "feature")
+	write("package foo\n\nfunc Foo() int {\n\treturn 2\n}\n")
+	run("commit", "-q", "-am", "change")
+	// Uncommitted changes count as well.
+	write("package foo\n\nfunc Foo() int {\n\treturn 2\n}\n\nfunc Bar() {}\n")
+
+	changed, err := NewLocalGitAdapter().ChangedLines(context.Background(), "main")
+	if err != nil {
+		t.Fatalf("ChangedLines() error = %v", err)
+	}
+
+	want := map[m.Path][]m.LineRange{
+		m.Path(filepath.Join(dir, "foo.go")): {{Start: 4, End: 4}, {Start: 6, End: 7}},
+	}
+
+	if !reflect.DeepEqual(changed, want) {
+		t.Fatalf("ChangedLines() = %v, want %v", changed, want)
+	}
+
+	run("config", "diff.noprefix", "true")
+
+	changed, err = NewLocalGitAdapter().ChangedLines(context.Background(), "main")
+	if err != nil {
+		t.Fatalf("ChangedLines() with diff.noprefix error = %v", err)
+	}
+
+	if !reflect.DeepEqual(changed, want) {
+		t.Fatalf("ChangedLines() with diff.noprefix = %v, want %v", changed, want)
+	}
+}
@@ -1,11 +1,20 @@
 package adapter
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
 	"go/ast"
 	"go/parser"
 	"go/token"
+	"go/types"
 	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"golang.org/x/tools/go/packages"
 )
 
 // GoFileAdapter encapsulates Go-specific parsing and scope-detection logic so
@@ -14,10 +23,39 @@ import (
 type GoFileAdapter interface {
 	// Parse builds an AST using the provided file set and optional source bytes.
 	Parse(ctx context.Context, fileSet *token.FileSet, filename string, src []byte) (*ast.File, error)
+	// LoadPackage loads and type-checks the package containing filename, using
+	// src as that file's content.
+	LoadPackage(ctx context.Context, filename string, src []byte) (*TypedPackage, error)
+	// TypeCheck reports an error if pkg no longer type-checks once the content
+	// of its file is replaced with src.
+	TypeCheck(ctx context.Context, pkg *TypedPackage, src []byte) error
 }
 
-// LocalGoFileAdapter provides a concrete GoFileAdapter backed by go/parser.
-type LocalGoFileAdapter struct{}
+// TypedPackage is a type-checked package, loaded for one of its files.
+type TypedPackage struct {
+	// Fset holds the positions of every file in the package.
+	Fset *token.FileSet
+	// File is the syntax of the file the package was loaded for.
+	File *ast.File
+	// Info records the types and objects of the package's syntax.
+	Info *types.Info
+
+	filename string
+	files    []*ast.File
+	index    int
+	config   types.Config
+	path     string
+	contents map[string][]byte
+}
+
+// LocalGoFileAdapter provides a concrete GoFileAdapter backed by go/parser and
+// golang.org/x/tools/go/packages.
+type LocalGoFileAdapter struct {
+	mu sync.Mutex
+	// last is the most recently loaded package. Sources are usually visited
+	// directory by directory, so consecutive files tend to share a package.
+	last *TypedPackage
+}
 
 // NewLocalGoFileAdapter constructs a LocalGoFileAdapter.
 func NewLocalGoFileAdapter() *LocalGoFileAdapter {
@@ -33,3 +71,205 @@ func (a *LocalGoFileAdapter) Parse(ctx context.Context, fileSet *token.FileSet,
 
 	return parser.ParseFile(fileSet, filename, src, parser.ParseComments)
 }
+
+const loadPackageMode = packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles |
+	packages.NeedImports | packages.NeedTypes | packages.NeedTypesInfo | packages.NeedSyntax |
+	packages.NeedTypesSizes | packages.NeedModule
+
+// LoadPackage loads the package containing filename with go/packages. It fails
+// if the package (with src as filename's content) does not type-check, since
+// type information of a broken package cannot be trusted.
+func (a *LocalGoFileAdapter) LoadPackage(ctx context.Context, filename string, src []byte) (*TypedPackage, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	abs, err := filepath.Abs(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	if cached := a.cached(abs, src); cached != nil {
+		return cached, nil
+	}
+
+	fset := token.NewFileSet()
+	cfg := &packages.Config{
+		Context: ctx,
+		Mode:    loadPackageMode,
+		Dir:     filepath.Dir(abs),
+		Fset:    fset,
+		Overlay: map[string][]byte{abs: src},
+	}
+
+	pkgs, err := packages.Load(cfg, "file="+abs)
+	if err != nil {
+		return nil, fmt.Errorf("load package of %s: %w", filename, err)
+	}
+
+	if len(pkgs) != 1 {
+		return nil, fmt.Errorf("load package of %s: found %d packages", filename, len(pkgs))
+	}
+
+	pkg := pkgs[0]
+	if len(pkg.Errors) > 0 {
+		return nil, fmt.Errorf("load package of %s: %v", filename, pkg.Errors[0])
+	}
+
+	typed, err := newTypedPackage(pkg, fset, abs, src)
+	if err != nil {
+		return nil, err
+	}
+
+	a.mu.Lock()
+	a.last = typed
+	a.mu.Unlock()
+
+	return typed, nil
+}
+
+// TypeCheck re-checks pkg with its file replaced by src. Only the package
+// itself is checked; its imports come from the already loaded package.
+func (a *LocalGoFileAdapter) TypeCheck(ctx context.Context, pkg *TypedPackage, src []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if pkg == nil {
+		return errors.New("missing package")
+	}
+
+	// Parsing into the package's file set keeps positions comparable across
+	// files. The mutant is dropped from it afterwards, as the package is reused
+	// for every mutant of the file.
+	file, err := parser.ParseFile(pkg.Fset, pkg.filename, src, parser.SkipObjectResolution)
+	if file != nil {
+		defer pkg.Fset.RemoveFile(pkg.Fset.File(file.Pos()))
+	}
+
+	if err != nil {
+		return err
+	}
+
+	files := make([]*ast.File, len(pkg.files))
+	copy(files, pkg.files)
+	files[pkg.index] = file
+
+	config := pkg.config
+	if _, err := config.Check(pkg.path, pkg.Fset, files, nil); err != nil {
+		// Type errors resolve their position when formatted, which needs the
+		// mutant still in the file set.
+		return errors.New(err.Error())
+	}
+
+	return nil
+}
+
+// cached returns the last loaded package when it contains filename with
+// exactly src as content, and every other file is unchanged on disk.
+func (a *LocalGoFileAdapter) cached(filename string, src []byte) *TypedPackage {
+	a.mu.Lock()
+	last := a.last
+	a.mu.Unlock()
+
+	if last == nil {
+		return nil
+	}
+
+	content, ok := last.contents[filename]
+	if !ok || !bytes.Equal(content, src) {
+		return nil
+	}
+
+	for name, content := range last.contents {
+		if name == filename {
+			continue
+		}
+
+		current, err := os.ReadFile(name)
+		if err != nil || !bytes.Equal(current, content) {
+			return nil
+		}
+	}
+
+	for i, file := range last.files {
+		if last.Fset.Position(file.Package).Filename == filename {
+			return last.withFile(i, filename)
+		}
+	}
+
+	return nil
+}
+
+func newTypedPackage(pkg *packages.Package, fset *token.FileSet, filename string, src []byte) (*TypedPackage, error) {
+	if len(pkg.Syntax) != len(pkg.CompiledGoFiles) {
+		return nil, fmt.Errorf("load package of %s: incomplete syntax", filename)
+	}
+
+	contents := make(map[string][]byte, len(pkg.CompiledGoFiles))
+	index := -1
+
+	for i, name := range pkg.CompiledGoFiles {
+		if name == filename {
+			index = i
+			contents[name] = src
+
+			continue
+		}
+
+		content, err := os.ReadFile(name)
+		if err != nil {
+			return nil, fmt.Errorf("load package of %s: %w", filename, err)
+		}
+
+		contents[name] = content
+	}
+
+	if index < 0 {
+		// Excluded by build constraints, or rewritten by cgo.
+		return nil, fmt.Errorf("load package of %s: file is not compiled into package %s", filename, pkg.PkgPath)
+	}
+
+	config := types.Config{
+		Importer: importerFunc(func(path string) (*types.Package, error) {
+			imported, ok := pkg.Imports[path]
+			if !ok || imported.Types == nil {
+				return nil, fmt.Errorf("package %q not imported by %s", path, pkg.PkgPath)
+			}
+
+			return imported.Types, nil
+		}),
+		Sizes: pkg.TypesSizes,
+	}
+
+	if pkg.Module != nil && pkg.Module.GoVersion != "" {
+		config.GoVersion = "go" + pkg.Module.GoVersion
+	}
+
+	typed := &TypedPackage{
+		Fset:     fset,
+		Info:     pkg.TypesInfo,
+		files:    pkg.Syntax,
+		config:   config,
+		path:     pkg.PkgPath,
+		contents: contents,
+	}
+
+	return typed.withFile(index, filename), nil
+}
+
+// withFile returns a copy of p focused on the file at index.
+func (p *TypedPackage) withFile(index int, filename string) *TypedPackage {
+	focused := *p
+	focused.File = p.files[index]
+	focused.filename = filename
+	focused.index = index
+
+	return &focused
+}
+
+type importerFunc func(path string) (*types.Package, error)
+
+func (f importerFunc) Import(path string) (*types.Package, error) {
+	return f(path)
+}
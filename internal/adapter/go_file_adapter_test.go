@@ -1,8 +1,10 @@
 package adapter
 
 import (
+	"bytes"
 	"context"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"go/token"
@@ -44,3 +46,89 @@ func TestLocalGoFileAdapter_Parse_ContextCancellation(t *testing.T) {
 		t.Fatalf("Parse() expected error due to context cancellation")
 	}
 }
+
+func TestLocalGoFileAdapter_LoadPackage(t *testing.T) {
+	adapter := NewLocalGoFileAdapter()
+
+	exampleFile := filepath.Join(examplePath(t, "basic"), "main.go")
+	content := readFileBytes(t, exampleFile)
+
+	pkg, err := adapter.LoadPackage(context.Background(), exampleFile, content)
+	if err != nil {
+		t.Fatalf("LoadPackage() error = %v", err)
+	}
+
+	if pkg.File == nil || pkg.File.Name.Name != "main" {
+		t.Fatalf("LoadPackage() file = %v, want package main", pkg.File)
+	}
+
+	if pkg.Info == nil || len(pkg.Info.Types) == 0 {
+		t.Fatalf("LoadPackage() expected type information")
+	}
+
+	again, err := adapter.LoadPackage(context.Background(), exampleFile, content)
+	if err != nil {
+		t.Fatalf("LoadPackage() second call error = %v", err)
+	}
+
+	if again.File != pkg.File {
+		t.Fatalf("LoadPackage() expected the unchanged package to be reused")
+	}
+}
+
+func TestLocalGoFileAdapter_LoadPackage_TypeError(t *testing.T) {
+	adapter := NewLocalGoFileAdapter()
+
+	exampleFile := filepath.Join(examplePath(t, "basic"), "main.go")
+	broken := []byte("package main\n\nfunc main() {\n\tvar s string = 1\n\t_ = s\n}\n")
+
+	if _, err := adapter.LoadPackage(context.Background(), exampleFile, broken); err == nil {
+		t.Fatalf("LoadPackage() expected error for a package that does not type-check")
+	}
+}
+
+func TestLocalGoFileAdapter_TypeCheck(t *testing.T) {
+	adapter := NewLocalGoFileAdapter()
+
+	exampleFile := filepath.Join(examplePath(t, "basic"), "main.go")
+	content := readFileBytes(t, exampleFile)
+
+	pkg, err := adapter.LoadPackage(context.Background(), exampleFile, content)
+	if err != nil {
+		t.Fatalf("LoadPackage() error = %v", err)
+	}
+
+	countFiles := func() int {
+		files := 0
+
+		pkg.Fset.Iterate(func(*token.File) bool {
+			files++
+			return true
+		})
+
+		return files
+	}
+
+	loaded := countFiles()
+
+	valid := bytes.Replace(content, []byte("3+5"), []byte("3-5"), 1)
+	if err := adapter.TypeCheck(context.Background(), pkg, valid); err != nil {
+		t.Fatalf("TypeCheck() unexpected error for valid mutant: %v", err)
+	}
+
+	invalid := bytes.Replace(content, []byte("3+5"), []byte("\"3\"-5"), 1)
+
+	err = adapter.TypeCheck(context.Background(), pkg, invalid)
+	if err == nil {
+		t.Fatalf("TypeCheck() expected error for mutant that does not type-check")
+	}
+
+	if !strings.Contains(err.Error(), "main.go:") {
+		t.Fatalf("TypeCheck() error lost the mutant's position: %v", err)
+	}
+
+	// Checked mutants do not accumulate in the package's file set.
+	if files := countFiles(); files != loaded {
+		t.Fatalf("TypeCheck() left %d files in the file set, want %d", files, loaded)
+	}
+}
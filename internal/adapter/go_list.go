@@ -0,0 +1,160 @@
+package adapter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/build"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// listedPackage holds the fields of `go list -json` output that source
+// discovery, the import graph and module snapshots use.
+type listedPackage struct {
+	Dir             string
+	ImportPath      string
+	GoFiles         []string
+	CgoFiles        []string
+	CFiles          []string
+	CXXFiles        []string
+	MFiles          []string
+	HFiles          []string
+	FFiles          []string
+	SFiles          []string
+	SwigFiles       []string
+	SwigCXXFiles    []string
+	SysoFiles       []string
+	EmbedFiles      []string
+	TestGoFiles     []string
+	XTestGoFiles    []string
+	TestEmbedFiles  []string
+	XTestEmbedFiles []string
+	Imports         []string
+	TestImports     []string
+	XTestImports    []string
+	Standard        bool
+	Module          *struct{ Main bool }
+	Error           *struct{ Err string }
+}
+
+const (
+	// listFields selects the listedPackage fields source discovery uses, so go
+	// list skips the rest.
+	listFields = "Dir,ImportPath,GoFiles,CgoFiles,Standard,Module,Error"
+	// importFields selects the fields the import graph is built from.
+	importFields = "Dir,ImportPath,Imports,TestImports,XTestImports,Standard,Module"
+)
+
+// goList resolves the package patterns in dir with `go list -e -json`, honoring
+// the adapter's build tags, build flags and the go environment (GOOS, GOARCH,
+// GOFLAGS, ...).
+func (a *LocalSourceFSAdapter) goList(ctx context.Context, dir string, patterns ...string) ([]listedPackage, error) {
+	return a.runGoList(ctx, dir, []string{"-json=" + listFields}, patterns...)
+}
+
+func (a *LocalSourceFSAdapter) runGoList(ctx context.Context, dir string, flags []string, patterns ...string) ([]listedPackage, error) {
+	args := append([]string{"list", "-e"}, flags...)
+	if len(a.buildTags) > 0 {
+		args = append(args, "-tags="+strings.Join(a.buildTags, ","))
+	}
+
+	args = append(args, a.buildFlags...)
+	args = append(args, "--")
+	args = append(args, patterns...)
+
+	cmd := exec.CommandContext(ctx, "go", args...)
+	cmd.Dir = dir
+
+	if len(a.buildEnv) > 0 {
+		cmd.Env = append(os.Environ(), a.buildEnv...)
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("go list %s: %w: %s", strings.Join(patterns, " "), err, strings.TrimSpace(stderr.String()))
+	}
+
+	var packages []listedPackage
+
+	decoder := json.NewDecoder(&stdout)
+	for {
+		var pkg listedPackage
+		if err := decoder.Decode(&pkg); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			return nil, fmt.Errorf("decode go list output: %w", err)
+		}
+
+		packages = append(packages, pkg)
+	}
+
+	return packages, nil
+}
+
+// buildFiles returns the absolute paths of the non-test Go files of a package
+// that are part of the build. Packages outside the main module(s), such as
+// dependencies matched by "all", are left out.
+func (p listedPackage) buildFiles() []string {
+	if !p.inMainModule() {
+		return nil
+	}
+
+	files := make([]string, 0, len(p.GoFiles)+len(p.CgoFiles))
+	for _, name := range p.GoFiles {
+		files = append(files, filepath.Join(p.Dir, name))
+	}
+
+	for _, name := range p.CgoFiles {
+		files = append(files, filepath.Join(p.Dir, name))
+	}
+
+	return files
+}
+
+// inMainModule reports whether the package belongs to the main module(s)
+// rather than the standard library or a dependency.
+func (p listedPackage) inMainModule() bool {
+	return !p.Standard && (p.Module == nil || p.Module.Main)
+}
+
+// buildContext is the go/build context used to check the build constraints of
+// files that are not resolved through go list.
+func (a *LocalSourceFSAdapter) buildContext() build.Context {
+	ctxt := build.Default
+	ctxt.BuildTags = append([]string(nil), a.buildTags...)
+	applyBuildEnv(&ctxt, a.buildEnv)
+
+	for _, flag := range a.buildFlags {
+		if name, _ := flagName(flag); toolBuildFlags[name] {
+			ctxt.ToolTags = append(ctxt.ToolTags, name)
+		}
+	}
+
+	return ctxt
+}
+
+// matchesBuild reports whether the file's build constraints (//go:build lines
+// and _GOOS/_GOARCH name suffixes) are satisfied. Files that cannot be checked
+// are kept, so the parser decides whether they are valid sources.
+func (a *LocalSourceFSAdapter) matchesBuild(path string) bool {
+	ctxt := a.buildContext()
+
+	match, err := ctxt.MatchFile(filepath.Dir(path), filepath.Base(path))
+	if err != nil {
+		return true
+	}
+
+	return match
+}
@@ -0,0 +1,320 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	m "gooze.dev/pkg/gooze/internal/model"
+)
+
+// CopyModule copies the module rooted at root into dst, along with the other
+// modules its build reads from disk: those of the go.work file governing it
+// and their local replacements or, without one, the local replacements of the
+// module. The modules keep their layout relative to each other, and the
+// go.work file is copied alongside them. With snapshots, only the files their
+// builds and tests need are copied.
+func (a *LocalSourceFSAdapter) CopyModule(ctx context.Context, root, dst m.Path) (m.Path, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	workFile, dirs, err := a.localModules(string(root))
+	if err != nil {
+		return "", err
+	}
+
+	base := commonDir(dirs)
+	if workFile != "" {
+		base = commonDir([]string{base, filepath.Dir(workFile)})
+	}
+
+	if a.snapshot != nil {
+		err = a.snapshotModule(ctx, string(root), dirs, base, string(dst))
+	} else {
+		err = a.copyModuleDirs(ctx, dirs, base, string(dst))
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	if workFile != "" {
+		for _, file := range []string{workFile, workFile + ".sum"} {
+			info, err := os.Stat(file)
+			if err != nil {
+				continue
+			}
+
+			rel, err := filepath.Rel(base, file)
+			if err != nil {
+				return "", err
+			}
+
+			if err := a.copyFile(file, filepath.Join(string(dst), rel), info.Mode()); err != nil {
+				return "", fmt.Errorf("copy %s: %w", file, err)
+			}
+		}
+	}
+
+	rel, err := filepath.Rel(base, string(root))
+	if err != nil {
+		return "", err
+	}
+
+	return m.Path(filepath.Join(string(dst), rel)), nil
+}
+
+// copyModuleDirs copies the module directories whole into dst, keeping their
+// paths relative to base.
+func (a *LocalSourceFSAdapter) copyModuleDirs(ctx context.Context, dirs []string, base, dst string) error {
+	for _, dir := range outermostDirs(dirs) {
+		rel, err := filepath.Rel(base, dir)
+		if err != nil {
+			return err
+		}
+
+		if err := a.CopyDir(ctx, m.Path(dir), m.Path(filepath.Join(dst, rel))); err != nil {
+			return fmt.Errorf("copy module %s: %w", dir, err)
+		}
+	}
+
+	return nil
+}
+
+// modulePath returns the module path declared by the go.mod file in root.
+func (a *LocalSourceFSAdapter) modulePath(root m.Path) string {
+	a.mu.Lock()
+	path, ok := a.modulePaths[root]
+	a.mu.Unlock()
+
+	if ok {
+		return path
+	}
+
+	// #nosec G304 -- root is a module directory found by FindProjectRoot
+	data, err := os.ReadFile(filepath.Join(string(root), "go.mod"))
+	if err == nil {
+		path = modfile.ModulePath(data)
+	}
+
+	a.mu.Lock()
+	if a.modulePaths == nil {
+		a.modulePaths = map[m.Path]string{}
+	}
+
+	a.modulePaths[root] = path
+	a.mu.Unlock()
+
+	return path
+}
+
+// localModules returns the go.work file governing the module in root, if any,
+// and the directories of the modules its build reads from disk, root included.
+func (a *LocalSourceFSAdapter) localModules(root string) (string, []string, error) {
+	workFile := a.goWorkFile(root)
+	if workFile == "" {
+		replaced, err := localReplacements(filepath.Join(root, "go.mod"))
+		if err != nil {
+			return "", nil, err
+		}
+
+		return "", uniqueDirs(append([]string{root}, replaced...)), nil
+	}
+
+	// #nosec G304 -- workFile is the go.work file the go command would use
+	data, err := os.ReadFile(workFile)
+	if err != nil {
+		return "", nil, fmt.Errorf("read %s: %w", workFile, err)
+	}
+
+	work, err := modfile.ParseWork(workFile, data, nil)
+	if err != nil {
+		return "", nil, fmt.Errorf("parse %s: %w", workFile, err)
+	}
+
+	workDir := filepath.Dir(workFile)
+	dirs := []string{root}
+
+	for _, use := range work.Use {
+		dir := resolveDir(workDir, use.Path)
+		dirs = append(dirs, dir)
+
+		replaced, err := localReplacements(filepath.Join(dir, "go.mod"))
+		if err != nil {
+			return "", nil, err
+		}
+
+		dirs = append(dirs, replaced...)
+	}
+
+	for _, replace := range work.Replace {
+		if isLocalReplacement(replace.New) {
+			dirs = append(dirs, resolveDir(workDir, replace.New.Path))
+		}
+	}
+
+	return workFile, uniqueDirs(dirs), nil
+}
+
+// goWorkFile returns the go.work file the go command uses for the module in
+// root: the one GOWORK names or else the nearest in root and its parents. It
+// returns "" in module mode.
+func (a *LocalSourceFSAdapter) goWorkFile(root string) string {
+	gowork, ok := lookupEnv(a.buildEnv, "GOWORK")
+	if !ok {
+		gowork = os.Getenv("GOWORK")
+	}
+
+	switch gowork {
+	case "off":
+		return ""
+	case "":
+	default:
+		return resolveDir(root, gowork)
+	}
+
+	for dir := root; ; {
+		file := filepath.Join(dir, "go.work")
+		if info, err := os.Stat(file); err == nil && !info.IsDir() {
+			return file
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+
+		dir = parent
+	}
+}
+
+// localReplacements returns the directories the go.mod file at path replaces
+// modules with.
+func localReplacements(path string) ([]string, error) {
+	// #nosec G304 -- path is the go.mod file of a module of the build
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+
+	file, err := modfile.Parse(path, data, nil)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	var dirs []string
+
+	for _, replace := range file.Replace {
+		if isLocalReplacement(replace.New) {
+			dirs = append(dirs, resolveDir(filepath.Dir(path), replace.New.Path))
+		}
+	}
+
+	return dirs, nil
+}
+
+// isLocalReplacement reports whether a replacement is a directory rather than
+// a module version.
+func isLocalReplacement(replacement module.Version) bool {
+	path := filepath.ToSlash(replacement.Path)
+
+	return replacement.Version == "" &&
+		(filepath.IsAbs(replacement.Path) || strings.HasPrefix(path, "./") || strings.HasPrefix(path, "../"))
+}
+
+// lookupEnv returns the value of key in a KEY=VALUE list, the last one winning
+// like in os/exec.
+func lookupEnv(env []string, key string) (string, bool) {
+	value, found := "", false
+
+	for _, variable := range env {
+		if k, v, ok := strings.Cut(variable, "="); ok && k == key {
+			value, found = v, true
+		}
+	}
+
+	return value, found
+}
+
+func resolveDir(base, path string) string {
+	if filepath.IsAbs(path) {
+		return filepath.Clean(path)
+	}
+
+	return filepath.Join(base, filepath.FromSlash(path))
+}
+
+func uniqueDirs(dirs []string) []string {
+	seen := make(map[string]bool, len(dirs))
+	unique := make([]string, 0, len(dirs))
+
+	for _, dir := range dirs {
+		if !seen[dir] {
+			seen[dir] = true
+			unique = append(unique, dir)
+		}
+	}
+
+	return unique
+}
+
+// outermostDirs returns the directories not nested in another of them, in
+// lexical order.
+func outermostDirs(dirs []string) []string {
+	sorted := append([]string(nil), dirs...)
+	sort.Strings(sorted)
+
+	var outermost []string
+
+	for _, dir := range sorted {
+		nested := false
+
+		for _, parent := range outermost {
+			if containsDir(parent, dir) {
+				nested = true
+				break
+			}
+		}
+
+		if !nested {
+			outermost = append(outermost, dir)
+		}
+	}
+
+	return outermost
+}
+
+// commonDir returns the deepest directory containing every one of dirs.
+func commonDir(dirs []string) string {
+	common := dirs[0]
+
+	for _, dir := range dirs[1:] {
+		for !containsDir(common, dir) {
+			parent := filepath.Dir(common)
+			if parent == common {
+				break
+			}
+
+			common = parent
+		}
+	}
+
+	return common
+}
+
+// containsDir reports whether dir is parent or lies below it.
+func containsDir(parent, dir string) bool {
+	rel, err := filepath.Rel(parent, dir)
+
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,157 @@
+package adapter
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	m "gooze.dev/pkg/gooze/internal/model"
+)
+
+// writeWorkspace lays out a go.work workspace with an app module importing a
+// shared module, and returns its root.
+func writeWorkspace(t *testing.T) string {
+	t.Helper()
+
+	// Workspace mode rejects a -mod=mod inherited from the environment.
+	t.Setenv("GOFLAGS", "")
+
+	root := t.TempDir()
+	mustMkdir(t, filepath.Join(root, "app"))
+	mustMkdir(t, filepath.Join(root, "shared"))
+
+	writeTestFile(t, filepath.Join(root, "go.work"), "go 1.21\n\nuse (\n\t./app\n\t./shared\n)\n")
+	writeTestFile(t, filepath.Join(root, "shared", "go.mod"), "module example.com/shared\n\ngo 1.21\n")
+	writeTestFile(t, filepath.Join(root, "shared", "shared.go"), "package shared\n\nfunc Double(n int) int { return n * 2 }\n")
+	writeTestFile(t, filepath.Join(root, "app", "go.mod"), "module example.com/app\n\ngo 1.21\n")
+	writeTestFile(t, filepath.Join(root, "app", "app.go"), "package app\n\nimport \"example.com/shared\"\n\nfunc Quad(n int) int { return shared.Double(shared.Double(n)) }\n")
+	writeTestFile(t, filepath.Join(root, "app", "app_test.go"), "package app\n\nimport \"testing\"\n\nfunc TestQuad(t *testing.T) {\n\tif Quad(1) != 4 {\n\t\tt.Fatal(\"want 4\")\n\t}\n}\n")
+
+	return root
+}
+
+func TestLocalSourceFSAdapter_CopyModule(t *testing.T) {
+	t.Run("copies the workspace modules and go.work", func(t *testing.T) {
+		t.Setenv("GOWORK", "")
+
+		root := writeWorkspace(t)
+		dst := t.TempDir()
+
+		moduleDir, err := NewLocalSourceFSAdapter().CopyModule(context.Background(), m.Path(filepath.Join(root, "app")), m.Path(dst))
+		require.NoError(t, err)
+
+		assert.Equal(t, m.Path(filepath.Join(dst, "app")), moduleDir)
+		assert.FileExists(t, filepath.Join(dst, "go.work"))
+		assert.FileExists(t, filepath.Join(dst, "shared", "shared.go"))
+
+		output, err := NewLocalTestRunnerAdapter().RunGoTest(context.Background(), string(moduleDir), "./...", "", "")
+		require.NoError(t, err, output)
+		assert.Contains(t, output, "--- PASS: TestQuad")
+	})
+
+	t.Run("snapshots the workspace modules the build needs", func(t *testing.T) {
+		t.Setenv("GOWORK", "")
+
+		root := writeWorkspace(t)
+		writeTestFile(t, filepath.Join(root, "shared", "README.md"), "shared\n")
+
+		dst := t.TempDir()
+
+		moduleDir, err := NewLocalSourceFSAdapter(WithSnapshots(SnapshotSettings{Hardlinks: true})).
+			CopyModule(context.Background(), m.Path(filepath.Join(root, "app")), m.Path(dst))
+		require.NoError(t, err)
+
+		assert.FileExists(t, filepath.Join(dst, "go.work"))
+		assert.FileExists(t, filepath.Join(dst, "shared", "go.mod"))
+		assert.FileExists(t, filepath.Join(dst, "shared", "shared.go"))
+		assert.NoFileExists(t, filepath.Join(dst, "shared", "README.md"))
+
+		output, err := NewLocalTestRunnerAdapter().RunGoTest(context.Background(), string(moduleDir), "./...", "", "")
+		require.NoError(t, err, output)
+	})
+
+	t.Run("copies local replacements without go.work", func(t *testing.T) {
+		t.Setenv("GOWORK", "")
+
+		root := writeWorkspace(t)
+		require.NoError(t, os.Remove(filepath.Join(root, "go.work")))
+		writeTestFile(t, filepath.Join(root, "app", "go.mod"), "module example.com/app\n\ngo 1.21\n\nrequire example.com/shared v0.0.0\n\nreplace example.com/shared => ../shared\n")
+
+		dst := t.TempDir()
+
+		moduleDir, err := NewLocalSourceFSAdapter().CopyModule(context.Background(), m.Path(filepath.Join(root, "app")), m.Path(dst))
+		require.NoError(t, err)
+
+		assert.Equal(t, m.Path(filepath.Join(dst, "app")), moduleDir)
+		assert.NoFileExists(t, filepath.Join(dst, "go.work"))
+		assert.FileExists(t, filepath.Join(dst, "shared", "shared.go"))
+
+		output, err := NewLocalTestRunnerAdapter().RunGoTest(context.Background(), string(moduleDir), "./...", "", "")
+		require.NoError(t, err, output)
+	})
+
+	t.Run("copies only the module when GOWORK is off", func(t *testing.T) {
+		root := writeWorkspace(t)
+		dst := t.TempDir()
+
+		settings := TestSettings{Env: []string{"GOWORK=off"}}
+
+		moduleDir, err := NewLocalSourceFSAdapter(WithBuildSettings(settings)).CopyModule(context.Background(), m.Path(filepath.Join(root, "app")), m.Path(dst))
+		require.NoError(t, err)
+
+		assert.Equal(t, m.Path(dst), moduleDir)
+		assert.FileExists(t, filepath.Join(dst, "app.go"))
+		assert.NoFileExists(t, filepath.Join(dst, "go.work"))
+		assert.NoDirExists(t, filepath.Join(dst, "shared"))
+	})
+
+	t.Run("copies a standalone module as is", func(t *testing.T) {
+		t.Setenv("GOWORK", "")
+
+		root := t.TempDir()
+		writeTestFile(t, filepath.Join(root, "go.mod"), "module example.com/project\n\ngo 1.21\n")
+		writeTestFile(t, filepath.Join(root, "main.go"), "package main\n")
+
+		dst := t.TempDir()
+
+		moduleDir, err := NewLocalSourceFSAdapter().CopyModule(context.Background(), m.Path(root), m.Path(dst))
+		require.NoError(t, err)
+
+		assert.Equal(t, m.Path(dst), moduleDir)
+		assert.FileExists(t, filepath.Join(dst, "main.go"))
+	})
+}
+
+func TestLocalSourceFSAdapter_Get_SetsModule(t *testing.T) {
+	t.Setenv("GOWORK", "")
+
+	root := writeWorkspace(t)
+
+	sources, err := NewLocalSourceFSAdapter().Get(context.Background(), []m.Path{m.Path(filepath.Join(root, "app"))})
+	require.NoError(t, err)
+
+	source := findSourceV2ByOrigin(sources, filepath.Join(root, "app", "app.go"))
+	require.NotNil(t, source)
+	assert.Equal(t, "example.com/app", source.Module)
+}
+
+func TestOutermostDirs(t *testing.T) {
+	t.Parallel()
+
+	dirs := []string{"/work/shared", "/work", "/work/app/internal", "/other"}
+
+	assert.Equal(t, []string{"/other", "/work"}, outermostDirs(dirs))
+	assert.Equal(t, []string{"/work/app/internal", "/work/shared"}, outermostDirs([]string{"/work/shared", "/work/app/internal"}))
+}
+
+func TestCommonDir(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "/work", commonDir([]string{"/work/app", "/work/shared"}))
+	assert.Equal(t, "/work/app", commonDir([]string{"/work/app", "/work/app/internal"}))
+	assert.Equal(t, "/work", commonDir([]string{"/work/app", "/work/application"}))
+	assert.Equal(t, "/", commonDir([]string{"/work", "/other"}))
+}
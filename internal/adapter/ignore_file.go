@@ -0,0 +1,235 @@
+package adapter
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// ignoreFileNames are the files, in gitignore syntax, whose patterns exclude
+// paths from source discovery.
+var ignoreFileNames = []string{".gitignore", ".goozeignore"}
+
+// ignoreRule is one pattern line of an ignore file.
+type ignoreRule struct {
+	// base is the directory of the ignore file; patterns match paths below it.
+	base    string
+	pattern *regexp.Regexp
+	negate  bool
+	dirOnly bool
+}
+
+// ignoreMatcher evaluates the ignore files of a tree, from its top directory
+// down to the directory of each path it checks. Ignore files are read once per
+// directory.
+type ignoreMatcher struct {
+	top    string
+	loaded map[string][]ignoreRule
+}
+
+func newIgnoreMatcher(top string) *ignoreMatcher {
+	return &ignoreMatcher{top: top, loaded: map[string][]ignoreRule{}}
+}
+
+// ignoreTop returns the directory ignore files are read from: the root of the
+// git repository containing dir, else its module root, else dir itself.
+func ignoreTop(dir string) string {
+	moduleRoot := ""
+
+	for current := dir; ; {
+		if _, err := os.Stat(filepath.Join(current, ".git")); err == nil {
+			return current
+		}
+
+		if moduleRoot == "" {
+			if _, err := os.Stat(filepath.Join(current, "go.mod")); err == nil {
+				moduleRoot = current
+			}
+		}
+
+		parent := filepath.Dir(current)
+		if parent == current {
+			break
+		}
+
+		current = parent
+	}
+
+	if moduleRoot != "" {
+		return moduleRoot
+	}
+
+	return dir
+}
+
+// ignored reports whether path is excluded by an ignore file, either itself or
+// through one of its parent directories.
+func (im *ignoreMatcher) ignored(path string, isDir bool) bool {
+	rel, err := filepath.Rel(im.top, path)
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return false
+	}
+
+	parts := strings.Split(filepath.ToSlash(rel), "/")
+	dir := im.top
+
+	for i := range parts {
+		current := filepath.Join(dir, parts[i])
+		if im.matches(current, isDir || i < len(parts)-1) {
+			return true
+		}
+
+		dir = current
+	}
+
+	return false
+}
+
+// matches applies the rules of every ignore file above path; the last matching
+// rule wins, so "!pattern" can re-include a path.
+func (im *ignoreMatcher) matches(path string, isDir bool) bool {
+	for dir := filepath.Dir(path); ; dir = filepath.Dir(dir) {
+		rules := im.rules(dir)
+
+		for i := len(rules) - 1; i >= 0; i-- {
+			rule := rules[i]
+			if rule.dirOnly && !isDir {
+				continue
+			}
+
+			rel, err := filepath.Rel(rule.base, path)
+			if err != nil {
+				continue
+			}
+
+			if rule.pattern.MatchString(filepath.ToSlash(rel)) {
+				return !rule.negate
+			}
+		}
+
+		if dir == im.top || dir == filepath.Dir(dir) {
+			return false
+		}
+	}
+}
+
+func (im *ignoreMatcher) rules(dir string) []ignoreRule {
+	if rules, ok := im.loaded[dir]; ok {
+		return rules
+	}
+
+	var rules []ignoreRule
+
+	for _, name := range ignoreFileNames {
+		content, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			continue
+		}
+
+		rules = append(rules, parseIgnoreFile(dir, content)...)
+	}
+
+	im.loaded[dir] = rules
+
+	return rules
+}
+
+// parseIgnoreFile parses gitignore syntax: blank lines and # comments are
+// skipped, ! negates, a trailing / matches directories only, and a pattern
+// with a / elsewhere is relative to the ignore file's directory.
+func parseIgnoreFile(base string, content []byte) []ignoreRule {
+	var rules []ignoreRule
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		rule := ignoreRule{base: base}
+
+		if strings.HasPrefix(line, "!") {
+			rule.negate = true
+			line = line[1:]
+		} else if strings.HasPrefix(line, `\`) {
+			line = line[1:]
+		}
+
+		if strings.HasSuffix(line, "/") {
+			rule.dirOnly = true
+			line = strings.TrimRight(line, "/")
+		}
+
+		if line == "" {
+			continue
+		}
+
+		anchored := strings.Contains(line, "/")
+		line = strings.TrimPrefix(line, "/")
+
+		expr := globToRegexp(line)
+		if !anchored {
+			expr = "(?:.*/)?" + expr
+		}
+
+		pattern, err := regexp.Compile("^" + expr + "$")
+		if err != nil {
+			continue
+		}
+
+		rule.pattern = pattern
+		rules = append(rules, rule)
+	}
+
+	return rules
+}
+
+// globToRegexp translates a gitignore glob, including ** segments, into a
+// regular expression over slash-separated paths.
+func globToRegexp(glob string) string {
+	var expr strings.Builder
+
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; c {
+		case '*':
+			if strings.HasPrefix(glob[i:], "**/") {
+				expr.WriteString("(?:.*/)?")
+				i += 2
+			} else if strings.HasPrefix(glob[i:], "**") {
+				expr.WriteString(".*")
+				i++
+			} else {
+				expr.WriteString("[^/]*")
+			}
+		case '?':
+			expr.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(glob[i:], ']')
+			if end < 0 {
+				expr.WriteString(`\[`)
+				continue
+			}
+
+			class := glob[i+1 : i+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+
+			expr.WriteString("[" + class + "]")
+			i += end
+		case '\\':
+			if i+1 < len(glob) {
+				i++
+				expr.WriteString(regexp.QuoteMeta(string(glob[i])))
+			}
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	return expr.String()
+}
@@ -0,0 +1,67 @@
+package adapter
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIgnoreMatcher_Ignored(t *testing.T) {
+	top := t.TempDir()
+	mustMkdir(t, filepath.Join(top, "sub"))
+	writeTestFile(t, filepath.Join(top, ".gitignore"), "# comment\n\n*.gen.go\n/root_only.go\ndist/\ndocs/**/*.go\n!keep.gen.go\n")
+	writeTestFile(t, filepath.Join(top, "sub", ".goozeignore"), "local.go\n!again.gen.go\n")
+
+	matcher := newIgnoreMatcher(top)
+	path := func(parts ...string) string { return filepath.Join(append([]string{top}, parts...)...) }
+
+	tests := []struct {
+		name  string
+		path  string
+		isDir bool
+		want  bool
+	}{
+		{"glob in any directory", path("sub", "a.gen.go"), false, true},
+		{"negated pattern", path("keep.gen.go"), false, false},
+		{"nested negation wins", path("sub", "again.gen.go"), false, false},
+		{"anchored pattern at top", path("root_only.go"), false, true},
+		{"anchored pattern not nested", path("sub", "root_only.go"), false, false},
+		{"directory pattern", path("dist"), true, true},
+		{"file below ignored directory", path("dist", "x.go"), false, true},
+		{"directory pattern skips files", path("sub", "dist"), false, false},
+		{"double star", path("docs", "a", "b", "c.go"), false, true},
+		{"nested ignore file", path("sub", "local.go"), false, true},
+		{"nested rules stay local", path("local.go"), false, false},
+		{"unmatched", path("main.go"), false, false},
+		{"outside the tree", filepath.Dir(top), true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, matcher.ignored(tt.path, tt.isDir))
+		})
+	}
+}
+
+func TestIgnoreTop(t *testing.T) {
+	repo := t.TempDir()
+	mustMkdir(t, filepath.Join(repo, ".git"))
+	mustMkdir(t, filepath.Join(repo, "mod"))
+	mustMkdir(t, filepath.Join(repo, "mod", "pkg"))
+	writeTestFile(t, filepath.Join(repo, "mod", "go.mod"), "module example.com/mod\n")
+
+	assert.Equal(t, repo, ignoreTop(filepath.Join(repo, "mod", "pkg")))
+
+	module := t.TempDir()
+	mustMkdir(t, filepath.Join(module, "pkg"))
+	writeTestFile(t, filepath.Join(module, "go.mod"), "module example.com/mod\n")
+
+	assert.Equal(t, module, ignoreTop(filepath.Join(module, "pkg")))
+}
+
+func TestGlobToRegexp(t *testing.T) {
+	assert.Equal(t, `[^/]*\.go`, globToRegexp("*.go"))
+	assert.Equal(t, `(?:.*/)?a/.*`, globToRegexp("**/a/**"))
+	assert.Equal(t, `[^/][^a]\[`, globToRegexp(`?[!a]\[`))
+}
@@ -0,0 +1,12 @@
+//go:build !unix
+
+package adapter
+
+import "os"
+
+// lockFile opens path. File locks are not supported on this platform, so
+// workspace slots are only exclusive within a gooze process.
+func lockFile(path string) (*os.File, error) {
+	// #nosec G304 -- path is a lock file under gooze's workspace directory
+	return os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0o600)
+}
@@ -0,0 +1,32 @@
+//go:build unix
+
+package adapter
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// lockFile opens path and takes an exclusive lock on it, failing with
+// errLocked when another open file holds it. The lock is released when the
+// file is closed or the process exits.
+func lockFile(path string) (*os.File, error) {
+	// #nosec G304 -- path is a lock file under gooze's workspace directory
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		_ = file.Close()
+
+		if errors.Is(err, syscall.EWOULDBLOCK) {
+			return nil, errLocked
+		}
+
+		return nil, err
+	}
+
+	return file, nil
+}
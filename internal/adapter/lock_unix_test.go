@@ -0,0 +1,29 @@
+//go:build unix
+
+package adapter
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLockFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "0.lock")
+
+	lock, err := lockFile(path)
+	require.NoError(t, err)
+
+	// Another holder, like another gooze process, cannot take the lock...
+	_, err = lockFile(path)
+	assert.ErrorIs(t, err, errLocked)
+
+	// ...until it is released.
+	require.NoError(t, lock.Close())
+
+	lock, err = lockFile(path)
+	require.NoError(t, err)
+	require.NoError(t, lock.Close())
+}
@@ -5,9 +5,12 @@ package mocks
 import (
 	context "context"
 	ast "go/ast"
-	token "go/token"
+
+	adapter "gooze.dev/pkg/gooze/internal/adapter"
 
 	mock "github.com/stretchr/testify/mock"
+
+	token "go/token"
 )
 
 // MockGoFileAdapter is an autogenerated mock type for the GoFileAdapter type
@@ -23,6 +26,66 @@ func (_m *MockGoFileAdapter) EXPECT() *MockGoFileAdapter_Expecter {
 	return &MockGoFileAdapter_Expecter{mock: &_m.Mock}
 }
 
+// LoadPackage provides a mock function with given fields: ctx, filename, src
+func (_m *MockGoFileAdapter) LoadPackage(ctx context.Context, filename string, src []byte) (*adapter.TypedPackage, error) {
+	ret := _m.Called(ctx, filename, src)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoadPackage")
+	}
+
+	var r0 *adapter.TypedPackage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []byte) (*adapter.TypedPackage, error)); ok {
+		return rf(ctx, filename, src)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []byte) *adapter.TypedPackage); ok {
+		r0 = rf(ctx, filename, src)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*adapter.TypedPackage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []byte) error); ok {
+		r1 = rf(ctx, filename, src)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockGoFileAdapter_LoadPackage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LoadPackage'
+type MockGoFileAdapter_LoadPackage_Call struct {
+	*mock.Call
+}
+
+// LoadPackage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filename string
+//   - src []byte
+func (_e *MockGoFileAdapter_Expecter) LoadPackage(ctx interface{}, filename interface{}, src interface{}) *MockGoFileAdapter_LoadPackage_Call {
+	return &MockGoFileAdapter_LoadPackage_Call{Call: _e.mock.On("LoadPackage", ctx, filename, src)}
+}
+
+func (_c *MockGoFileAdapter_LoadPackage_Call) Run(run func(ctx context.Context, filename string, src []byte)) *MockGoFileAdapter_LoadPackage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]byte))
+	})
+	return _c
+}
+
+func (_c *MockGoFileAdapter_LoadPackage_Call) Return(_a0 *adapter.TypedPackage, _a1 error) *MockGoFileAdapter_LoadPackage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockGoFileAdapter_LoadPackage_Call) RunAndReturn(run func(context.Context, string, []byte) (*adapter.TypedPackage, error)) *MockGoFileAdapter_LoadPackage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Parse provides a mock function with given fields: ctx, fileSet, filename, src
 func (_m *MockGoFileAdapter) Parse(ctx context.Context, fileSet *token.FileSet, filename string, src []byte) (*ast.File, error) {
 	ret := _m.Called(ctx, fileSet, filename, src)
@@ -84,6 +147,54 @@ func (_c *MockGoFileAdapter_Parse_Call) RunAndReturn(run func(context.Context, *
 	return _c
 }
 
+// TypeCheck provides a mock function with given fields: ctx, pkg, src
+func (_m *MockGoFileAdapter) TypeCheck(ctx context.Context, pkg *adapter.TypedPackage, src []byte) error {
+	ret := _m.Called(ctx, pkg, src)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TypeCheck")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *adapter.TypedPackage, []byte) error); ok {
+		r0 = rf(ctx, pkg, src)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockGoFileAdapter_TypeCheck_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TypeCheck'
+type MockGoFileAdapter_TypeCheck_Call struct {
+	*mock.Call
+}
+
+// TypeCheck is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pkg *adapter.TypedPackage
+//   - src []byte
+func (_e *MockGoFileAdapter_Expecter) TypeCheck(ctx interface{}, pkg interface{}, src interface{}) *MockGoFileAdapter_TypeCheck_Call {
+	return &MockGoFileAdapter_TypeCheck_Call{Call: _e.mock.On("TypeCheck", ctx, pkg, src)}
+}
+
+func (_c *MockGoFileAdapter_TypeCheck_Call) Run(run func(ctx context.Context, pkg *adapter.TypedPackage, src []byte)) *MockGoFileAdapter_TypeCheck_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*adapter.TypedPackage), args[2].([]byte))
+	})
+	return _c
+}
+
+func (_c *MockGoFileAdapter_TypeCheck_Call) Return(_a0 error) *MockGoFileAdapter_TypeCheck_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockGoFileAdapter_TypeCheck_Call) RunAndReturn(run func(context.Context, *adapter.TypedPackage, []byte) error) *MockGoFileAdapter_TypeCheck_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockGoFileAdapter creates a new instance of MockGoFileAdapter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockGoFileAdapter(t interface {
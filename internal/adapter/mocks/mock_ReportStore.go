@@ -23,9 +23,9 @@ func (_m *MockReportStore) EXPECT() *MockReportStore_Expecter {
 	return &MockReportStore_Expecter{mock: &_m.Mock}
 }
 
-// CheckUpdates provides a mock function with given fields: ctx, path, sources
-func (_m *MockReportStore) CheckUpdates(ctx context.Context, path model.Path, sources []model.Source) ([]model.Source, error) {
-	ret := _m.Called(ctx, path, sources)
+// CheckUpdates provides a mock function with given fields: ctx, path, sources, scope
+func (_m *MockReportStore) CheckUpdates(ctx context.Context, path model.Path, sources []model.Source, scope model.RunScope) ([]model.Source, error) {
+	ret := _m.Called(ctx, path, sources, scope)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CheckUpdates")
@@ -33,19 +33,19 @@ func (_m *MockReportStore) CheckUpdates(ctx context.Context, path model.Path, so
 
 	var r0 []model.Source
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, model.Path, []model.Source) ([]model.Source, error)); ok {
-		return rf(ctx, path, sources)
+	if rf, ok := ret.Get(0).(func(context.Context, model.Path, []model.Source, model.RunScope) ([]model.Source, error)); ok {
+		return rf(ctx, path, sources, scope)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, model.Path, []model.Source) []model.Source); ok {
-		r0 = rf(ctx, path, sources)
+	if rf, ok := ret.Get(0).(func(context.Context, model.Path, []model.Source, model.RunScope) []model.Source); ok {
+		r0 = rf(ctx, path, sources, scope)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]model.Source)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, model.Path, []model.Source) error); ok {
-		r1 = rf(ctx, path, sources)
+	if rf, ok := ret.Get(1).(func(context.Context, model.Path, []model.Source, model.RunScope) error); ok {
+		r1 = rf(ctx, path, sources, scope)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -62,13 +62,14 @@ type MockReportStore_CheckUpdates_Call struct {
 //   - ctx context.Context
 //   - path model.Path
 //   - sources []model.Source
-func (_e *MockReportStore_Expecter) CheckUpdates(ctx interface{}, path interface{}, sources interface{}) *MockReportStore_CheckUpdates_Call {
-	return &MockReportStore_CheckUpdates_Call{Call: _e.mock.On("CheckUpdates", ctx, path, sources)}
+//   - scope model.RunScope
+func (_e *MockReportStore_Expecter) CheckUpdates(ctx interface{}, path interface{}, sources interface{}, scope interface{}) *MockReportStore_CheckUpdates_Call {
+	return &MockReportStore_CheckUpdates_Call{Call: _e.mock.On("CheckUpdates", ctx, path, sources, scope)}
 }
 
-func (_c *MockReportStore_CheckUpdates_Call) Run(run func(ctx context.Context, path model.Path, sources []model.Source)) *MockReportStore_CheckUpdates_Call {
+func (_c *MockReportStore_CheckUpdates_Call) Run(run func(ctx context.Context, path model.Path, sources []model.Source, scope model.RunScope)) *MockReportStore_CheckUpdates_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(model.Path), args[2].([]model.Source))
+		run(args[0].(context.Context), args[1].(model.Path), args[2].([]model.Source), args[3].(model.RunScope))
 	})
 	return _c
 }
@@ -78,7 +79,7 @@ func (_c *MockReportStore_CheckUpdates_Call) Return(_a0 []model.Source, _a1 erro
 	return _c
 }
 
-func (_c *MockReportStore_CheckUpdates_Call) RunAndReturn(run func(context.Context, model.Path, []model.Source) ([]model.Source, error)) *MockReportStore_CheckUpdates_Call {
+func (_c *MockReportStore_CheckUpdates_Call) RunAndReturn(run func(context.Context, model.Path, []model.Source, model.RunScope) ([]model.Source, error)) *MockReportStore_CheckUpdates_Call {
 	_c.Call.Return(run)
 	return _c
 }
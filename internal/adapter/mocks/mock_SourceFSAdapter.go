@@ -72,6 +72,64 @@ func (_c *MockSourceFSAdapter_CopyDir_Call) RunAndReturn(run func(context.Contex
 	return _c
 }
 
+// CopyModule provides a mock function with given fields: ctx, root, dst
+func (_m *MockSourceFSAdapter) CopyModule(ctx context.Context, root model.Path, dst model.Path) (model.Path, error) {
+	ret := _m.Called(ctx, root, dst)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CopyModule")
+	}
+
+	var r0 model.Path
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.Path, model.Path) (model.Path, error)); ok {
+		return rf(ctx, root, dst)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.Path, model.Path) model.Path); ok {
+		r0 = rf(ctx, root, dst)
+	} else {
+		r0 = ret.Get(0).(model.Path)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.Path, model.Path) error); ok {
+		r1 = rf(ctx, root, dst)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSourceFSAdapter_CopyModule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CopyModule'
+type MockSourceFSAdapter_CopyModule_Call struct {
+	*mock.Call
+}
+
+// CopyModule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - root model.Path
+//   - dst model.Path
+func (_e *MockSourceFSAdapter_Expecter) CopyModule(ctx interface{}, root interface{}, dst interface{}) *MockSourceFSAdapter_CopyModule_Call {
+	return &MockSourceFSAdapter_CopyModule_Call{Call: _e.mock.On("CopyModule", ctx, root, dst)}
+}
+
+func (_c *MockSourceFSAdapter_CopyModule_Call) Run(run func(ctx context.Context, root model.Path, dst model.Path)) *MockSourceFSAdapter_CopyModule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(model.Path), args[2].(model.Path))
+	})
+	return _c
+}
+
+func (_c *MockSourceFSAdapter_CopyModule_Call) Return(_a0 model.Path, _a1 error) *MockSourceFSAdapter_CopyModule_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSourceFSAdapter_CopyModule_Call) RunAndReturn(run func(context.Context, model.Path, model.Path) (model.Path, error)) *MockSourceFSAdapter_CopyModule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateTempDir provides a mock function with given fields: ctx, pattern
 func (_m *MockSourceFSAdapter) CreateTempDir(ctx context.Context, pattern string) (model.Path, error) {
 	ret := _m.Called(ctx, pattern)
@@ -129,23 +187,82 @@ func (_c *MockSourceFSAdapter_CreateTempDir_Call) RunAndReturn(run func(context.
 	return _c
 }
 
-// DetectTestFile provides a mock function with given fields: ctx, sourcePath
-func (_m *MockSourceFSAdapter) DetectTestFile(ctx context.Context, sourcePath model.Path) (model.Path, error) {
-	ret := _m.Called(ctx, sourcePath)
+// CreateWorkspaceDir provides a mock function with given fields: ctx, key
+func (_m *MockSourceFSAdapter) CreateWorkspaceDir(ctx context.Context, key string) (model.Path, error) {
+	ret := _m.Called(ctx, key)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DetectTestFile")
+		panic("no return value specified for CreateWorkspaceDir")
 	}
 
 	var r0 model.Path
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, model.Path) (model.Path, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) (model.Path, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) model.Path); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Get(0).(model.Path)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSourceFSAdapter_CreateWorkspaceDir_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWorkspaceDir'
+type MockSourceFSAdapter_CreateWorkspaceDir_Call struct {
+	*mock.Call
+}
+
+// CreateWorkspaceDir is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockSourceFSAdapter_Expecter) CreateWorkspaceDir(ctx interface{}, key interface{}) *MockSourceFSAdapter_CreateWorkspaceDir_Call {
+	return &MockSourceFSAdapter_CreateWorkspaceDir_Call{Call: _e.mock.On("CreateWorkspaceDir", ctx, key)}
+}
+
+func (_c *MockSourceFSAdapter_CreateWorkspaceDir_Call) Run(run func(ctx context.Context, key string)) *MockSourceFSAdapter_CreateWorkspaceDir_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockSourceFSAdapter_CreateWorkspaceDir_Call) Return(_a0 model.Path, _a1 error) *MockSourceFSAdapter_CreateWorkspaceDir_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSourceFSAdapter_CreateWorkspaceDir_Call) RunAndReturn(run func(context.Context, string) (model.Path, error)) *MockSourceFSAdapter_CreateWorkspaceDir_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DetectTestFiles provides a mock function with given fields: ctx, sourcePath
+func (_m *MockSourceFSAdapter) DetectTestFiles(ctx context.Context, sourcePath model.Path) ([]model.Path, error) {
+	ret := _m.Called(ctx, sourcePath)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DetectTestFiles")
+	}
+
+	var r0 []model.Path
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.Path) ([]model.Path, error)); ok {
 		return rf(ctx, sourcePath)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, model.Path) model.Path); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, model.Path) []model.Path); ok {
 		r0 = rf(ctx, sourcePath)
 	} else {
-		r0 = ret.Get(0).(model.Path)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Path)
+		}
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, model.Path) error); ok {
@@ -157,31 +274,31 @@ func (_m *MockSourceFSAdapter) DetectTestFile(ctx context.Context, sourcePath mo
 	return r0, r1
 }
 
-// MockSourceFSAdapter_DetectTestFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DetectTestFile'
-type MockSourceFSAdapter_DetectTestFile_Call struct {
+// MockSourceFSAdapter_DetectTestFiles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DetectTestFiles'
+type MockSourceFSAdapter_DetectTestFiles_Call struct {
 	*mock.Call
 }
 
-// DetectTestFile is a helper method to define mock.On call
+// DetectTestFiles is a helper method to define mock.On call
 //   - ctx context.Context
 //   - sourcePath model.Path
-func (_e *MockSourceFSAdapter_Expecter) DetectTestFile(ctx interface{}, sourcePath interface{}) *MockSourceFSAdapter_DetectTestFile_Call {
-	return &MockSourceFSAdapter_DetectTestFile_Call{Call: _e.mock.On("DetectTestFile", ctx, sourcePath)}
+func (_e *MockSourceFSAdapter_Expecter) DetectTestFiles(ctx interface{}, sourcePath interface{}) *MockSourceFSAdapter_DetectTestFiles_Call {
+	return &MockSourceFSAdapter_DetectTestFiles_Call{Call: _e.mock.On("DetectTestFiles", ctx, sourcePath)}
 }
 
-func (_c *MockSourceFSAdapter_DetectTestFile_Call) Run(run func(ctx context.Context, sourcePath model.Path)) *MockSourceFSAdapter_DetectTestFile_Call {
+func (_c *MockSourceFSAdapter_DetectTestFiles_Call) Run(run func(ctx context.Context, sourcePath model.Path)) *MockSourceFSAdapter_DetectTestFiles_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context), args[1].(model.Path))
 	})
 	return _c
 }
 
-func (_c *MockSourceFSAdapter_DetectTestFile_Call) Return(_a0 model.Path, _a1 error) *MockSourceFSAdapter_DetectTestFile_Call {
+func (_c *MockSourceFSAdapter_DetectTestFiles_Call) Return(_a0 []model.Path, _a1 error) *MockSourceFSAdapter_DetectTestFiles_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockSourceFSAdapter_DetectTestFile_Call) RunAndReturn(run func(context.Context, model.Path) (model.Path, error)) *MockSourceFSAdapter_DetectTestFile_Call {
+func (_c *MockSourceFSAdapter_DetectTestFiles_Call) RunAndReturn(run func(context.Context, model.Path) ([]model.Path, error)) *MockSourceFSAdapter_DetectTestFiles_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -658,6 +775,101 @@ func (_c *MockSourceFSAdapter_RemoveAll_Call) RunAndReturn(run func(context.Cont
 	return _c
 }
 
+// RemoveWorkspaceDir provides a mock function with given fields: ctx, dir
+func (_m *MockSourceFSAdapter) RemoveWorkspaceDir(ctx context.Context, dir model.Path) error {
+	ret := _m.Called(ctx, dir)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveWorkspaceDir")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.Path) error); ok {
+		r0 = rf(ctx, dir)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSourceFSAdapter_RemoveWorkspaceDir_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveWorkspaceDir'
+type MockSourceFSAdapter_RemoveWorkspaceDir_Call struct {
+	*mock.Call
+}
+
+// RemoveWorkspaceDir is a helper method to define mock.On call
+//   - ctx context.Context
+//   - dir model.Path
+func (_e *MockSourceFSAdapter_Expecter) RemoveWorkspaceDir(ctx interface{}, dir interface{}) *MockSourceFSAdapter_RemoveWorkspaceDir_Call {
+	return &MockSourceFSAdapter_RemoveWorkspaceDir_Call{Call: _e.mock.On("RemoveWorkspaceDir", ctx, dir)}
+}
+
+func (_c *MockSourceFSAdapter_RemoveWorkspaceDir_Call) Run(run func(ctx context.Context, dir model.Path)) *MockSourceFSAdapter_RemoveWorkspaceDir_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(model.Path))
+	})
+	return _c
+}
+
+func (_c *MockSourceFSAdapter_RemoveWorkspaceDir_Call) Return(_a0 error) *MockSourceFSAdapter_RemoveWorkspaceDir_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSourceFSAdapter_RemoveWorkspaceDir_Call) RunAndReturn(run func(context.Context, model.Path) error) *MockSourceFSAdapter_RemoveWorkspaceDir_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SkippedFiles provides a mock function with given fields: ctx
+func (_m *MockSourceFSAdapter) SkippedFiles(ctx context.Context) map[string]int {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SkippedFiles")
+	}
+
+	var r0 map[string]int
+	if rf, ok := ret.Get(0).(func(context.Context) map[string]int); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]int)
+		}
+	}
+
+	return r0
+}
+
+// MockSourceFSAdapter_SkippedFiles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SkippedFiles'
+type MockSourceFSAdapter_SkippedFiles_Call struct {
+	*mock.Call
+}
+
+// SkippedFiles is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockSourceFSAdapter_Expecter) SkippedFiles(ctx interface{}) *MockSourceFSAdapter_SkippedFiles_Call {
+	return &MockSourceFSAdapter_SkippedFiles_Call{Call: _e.mock.On("SkippedFiles", ctx)}
+}
+
+func (_c *MockSourceFSAdapter_SkippedFiles_Call) Run(run func(ctx context.Context)) *MockSourceFSAdapter_SkippedFiles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockSourceFSAdapter_SkippedFiles_Call) Return(_a0 map[string]int) *MockSourceFSAdapter_SkippedFiles_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSourceFSAdapter_SkippedFiles_Call) RunAndReturn(run func(context.Context) map[string]int) *MockSourceFSAdapter_SkippedFiles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Stream provides a mock function with given fields: ctx, roots, ignore
 func (_m *MockSourceFSAdapter) Stream(ctx context.Context, roots []model.Path, ignore ...string) (<-chan model.Source, <-chan error) {
 	_va := make([]interface{}, len(ignore))
@@ -21,27 +21,281 @@ func (_m *MockTestRunnerAdapter) EXPECT() *MockTestRunnerAdapter_Expecter {
 	return &MockTestRunnerAdapter_Expecter{mock: &_m.Mock}
 }
 
-// RunGoTest provides a mock function with given fields: ctx, workDir, testFile
-func (_m *MockTestRunnerAdapter) RunGoTest(ctx context.Context, workDir string, testFile string) (string, error) {
-	ret := _m.Called(ctx, workDir, testFile)
+// CompileHash provides a mock function with given fields: ctx, pkgDir, overlay
+func (_m *MockTestRunnerAdapter) CompileHash(ctx context.Context, pkgDir string, overlay string) (string, error) {
+	ret := _m.Called(ctx, pkgDir, overlay)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RunGoTest")
+		panic("no return value specified for CompileHash")
 	}
 
 	var r0 string
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
-		return rf(ctx, workDir, testFile)
+		return rf(ctx, pkgDir, overlay)
 	}
 	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
-		r0 = rf(ctx, workDir, testFile)
+		r0 = rf(ctx, pkgDir, overlay)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, workDir, testFile)
+		r1 = rf(ctx, pkgDir, overlay)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTestRunnerAdapter_CompileHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CompileHash'
+type MockTestRunnerAdapter_CompileHash_Call struct {
+	*mock.Call
+}
+
+// CompileHash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pkgDir string
+//   - overlay string
+func (_e *MockTestRunnerAdapter_Expecter) CompileHash(ctx interface{}, pkgDir interface{}, overlay interface{}) *MockTestRunnerAdapter_CompileHash_Call {
+	return &MockTestRunnerAdapter_CompileHash_Call{Call: _e.mock.On("CompileHash", ctx, pkgDir, overlay)}
+}
+
+func (_c *MockTestRunnerAdapter_CompileHash_Call) Run(run func(ctx context.Context, pkgDir string, overlay string)) *MockTestRunnerAdapter_CompileHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockTestRunnerAdapter_CompileHash_Call) Return(_a0 string, _a1 error) *MockTestRunnerAdapter_CompileHash_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTestRunnerAdapter_CompileHash_Call) RunAndReturn(run func(context.Context, string, string) (string, error)) *MockTestRunnerAdapter_CompileHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CoverTest provides a mock function with given fields: ctx, workDir, target, test
+func (_m *MockTestRunnerAdapter) CoverTest(ctx context.Context, workDir string, target string, test string) ([]byte, error) {
+	ret := _m.Called(ctx, workDir, target, test)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CoverTest")
+	}
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) ([]byte, error)); ok {
+		return rf(ctx, workDir, target, test)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) []byte); ok {
+		r0 = rf(ctx, workDir, target, test)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, workDir, target, test)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTestRunnerAdapter_CoverTest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CoverTest'
+type MockTestRunnerAdapter_CoverTest_Call struct {
+	*mock.Call
+}
+
+// CoverTest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - workDir string
+//   - target string
+//   - test string
+func (_e *MockTestRunnerAdapter_Expecter) CoverTest(ctx interface{}, workDir interface{}, target interface{}, test interface{}) *MockTestRunnerAdapter_CoverTest_Call {
+	return &MockTestRunnerAdapter_CoverTest_Call{Call: _e.mock.On("CoverTest", ctx, workDir, target, test)}
+}
+
+func (_c *MockTestRunnerAdapter_CoverTest_Call) Run(run func(ctx context.Context, workDir string, target string, test string)) *MockTestRunnerAdapter_CoverTest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockTestRunnerAdapter_CoverTest_Call) Return(profile []byte, err error) *MockTestRunnerAdapter_CoverTest_Call {
+	_c.Call.Return(profile, err)
+	return _c
+}
+
+func (_c *MockTestRunnerAdapter_CoverTest_Call) RunAndReturn(run func(context.Context, string, string, string) ([]byte, error)) *MockTestRunnerAdapter_CoverTest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTests provides a mock function with given fields: ctx, workDir, target
+func (_m *MockTestRunnerAdapter) ListTests(ctx context.Context, workDir string, target string) ([]string, error) {
+	ret := _m.Called(ctx, workDir, target)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTests")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]string, error)); ok {
+		return rf(ctx, workDir, target)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []string); ok {
+		r0 = rf(ctx, workDir, target)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, workDir, target)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTestRunnerAdapter_ListTests_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTests'
+type MockTestRunnerAdapter_ListTests_Call struct {
+	*mock.Call
+}
+
+// ListTests is a helper method to define mock.On call
+//   - ctx context.Context
+//   - workDir string
+//   - target string
+func (_e *MockTestRunnerAdapter_Expecter) ListTests(ctx interface{}, workDir interface{}, target interface{}) *MockTestRunnerAdapter_ListTests_Call {
+	return &MockTestRunnerAdapter_ListTests_Call{Call: _e.mock.On("ListTests", ctx, workDir, target)}
+}
+
+func (_c *MockTestRunnerAdapter_ListTests_Call) Run(run func(ctx context.Context, workDir string, target string)) *MockTestRunnerAdapter_ListTests_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockTestRunnerAdapter_ListTests_Call) Return(tests []string, err error) *MockTestRunnerAdapter_ListTests_Call {
+	_c.Call.Return(tests, err)
+	return _c
+}
+
+func (_c *MockTestRunnerAdapter_ListTests_Call) RunAndReturn(run func(context.Context, string, string) ([]string, error)) *MockTestRunnerAdapter_ListTests_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RunBaseline provides a mock function with given fields: ctx, workDir, target, cover
+func (_m *MockTestRunnerAdapter) RunBaseline(ctx context.Context, workDir string, target string, cover bool) (string, []byte, error) {
+	ret := _m.Called(ctx, workDir, target, cover)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RunBaseline")
+	}
+
+	var r0 string
+	var r1 []byte
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool) (string, []byte, error)); ok {
+		return rf(ctx, workDir, target, cover)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool) string); ok {
+		r0 = rf(ctx, workDir, target, cover)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, bool) []byte); ok {
+		r1 = rf(ctx, workDir, target, cover)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, bool) error); ok {
+		r2 = rf(ctx, workDir, target, cover)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockTestRunnerAdapter_RunBaseline_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RunBaseline'
+type MockTestRunnerAdapter_RunBaseline_Call struct {
+	*mock.Call
+}
+
+// RunBaseline is a helper method to define mock.On call
+//   - ctx context.Context
+//   - workDir string
+//   - target string
+//   - cover bool
+func (_e *MockTestRunnerAdapter_Expecter) RunBaseline(ctx interface{}, workDir interface{}, target interface{}, cover interface{}) *MockTestRunnerAdapter_RunBaseline_Call {
+	return &MockTestRunnerAdapter_RunBaseline_Call{Call: _e.mock.On("RunBaseline", ctx, workDir, target, cover)}
+}
+
+func (_c *MockTestRunnerAdapter_RunBaseline_Call) Run(run func(ctx context.Context, workDir string, target string, cover bool)) *MockTestRunnerAdapter_RunBaseline_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(bool))
+	})
+	return _c
+}
+
+func (_c *MockTestRunnerAdapter_RunBaseline_Call) Return(output string, profile []byte, err error) *MockTestRunnerAdapter_RunBaseline_Call {
+	_c.Call.Return(output, profile, err)
+	return _c
+}
+
+func (_c *MockTestRunnerAdapter_RunBaseline_Call) RunAndReturn(run func(context.Context, string, string, bool) (string, []byte, error)) *MockTestRunnerAdapter_RunBaseline_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RunGoTest provides a mock function with given fields: ctx, workDir, target, run, overlay, env
+func (_m *MockTestRunnerAdapter) RunGoTest(ctx context.Context, workDir string, target string, run string, overlay string, env ...string) (string, error) {
+	_va := make([]interface{}, len(env))
+	for _i := range env {
+		_va[_i] = env[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, workDir, target, run, overlay)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RunGoTest")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, ...string) (string, error)); ok {
+		return rf(ctx, workDir, target, run, overlay, env...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, ...string) string); ok {
+		r0 = rf(ctx, workDir, target, run, overlay, env...)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string, ...string) error); ok {
+		r1 = rf(ctx, workDir, target, run, overlay, env...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -57,14 +311,24 @@ type MockTestRunnerAdapter_RunGoTest_Call struct {
 // RunGoTest is a helper method to define mock.On call
 //   - ctx context.Context
 //   - workDir string
-//   - testFile string
-func (_e *MockTestRunnerAdapter_Expecter) RunGoTest(ctx interface{}, workDir interface{}, testFile interface{}) *MockTestRunnerAdapter_RunGoTest_Call {
-	return &MockTestRunnerAdapter_RunGoTest_Call{Call: _e.mock.On("RunGoTest", ctx, workDir, testFile)}
+//   - target string
+//   - run string
+//   - overlay string
+//   - env ...string
+func (_e *MockTestRunnerAdapter_Expecter) RunGoTest(ctx interface{}, workDir interface{}, target interface{}, run interface{}, overlay interface{}, env ...interface{}) *MockTestRunnerAdapter_RunGoTest_Call {
+	return &MockTestRunnerAdapter_RunGoTest_Call{Call: _e.mock.On("RunGoTest",
+		append([]interface{}{ctx, workDir, target, run, overlay}, env...)...)}
 }
 
-func (_c *MockTestRunnerAdapter_RunGoTest_Call) Run(run func(ctx context.Context, workDir string, testFile string)) *MockTestRunnerAdapter_RunGoTest_Call {
+func (_c *MockTestRunnerAdapter_RunGoTest_Call) Run(run func(ctx context.Context, workDir string, target string, run string, overlay string, env ...string)) *MockTestRunnerAdapter_RunGoTest_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string))
+		variadicArgs := make([]string, len(args)-5)
+		for i, a := range args[5:] {
+			if a != nil {
+				variadicArgs[i] = a.(string)
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(string), variadicArgs...)
 	})
 	return _c
 }
@@ -74,7 +338,7 @@ func (_c *MockTestRunnerAdapter_RunGoTest_Call) Return(output string, err error)
 	return _c
 }
 
-func (_c *MockTestRunnerAdapter_RunGoTest_Call) RunAndReturn(run func(context.Context, string, string) (string, error)) *MockTestRunnerAdapter_RunGoTest_Call {
+func (_c *MockTestRunnerAdapter_RunGoTest_Call) RunAndReturn(run func(context.Context, string, string, string, string, ...string) (string, error)) *MockTestRunnerAdapter_RunGoTest_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,101 @@
+//go:build linux
+
+package adapter
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTestCommand_CancelKillsProcessGroup(t *testing.T) {
+	pidFile := filepath.Join(t.TempDir(), "pid")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	// The shell stands in for the go command, the background sleep for a test
+	// binary it started.
+	cmd := testCommand(ctx, ResourceLimits{}, "sh", "-c", `sleep 60 & echo $! > "$0"; wait`, pidFile)
+
+	start := time.Now()
+	err := runTestCommand(cmd)
+
+	require.Error(t, err)
+	assert.Less(t, time.Since(start), 10*time.Second)
+
+	content, err := os.ReadFile(pidFile)
+	require.NoError(t, err)
+
+	pid, err := strconv.Atoi(strings.TrimSpace(string(content)))
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool { return !processRunning(pid) }, 5*time.Second, 50*time.Millisecond)
+}
+
+func TestLocalTestRunnerAdapter_RunGoTest_ResourceLimits(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "go.mod"), "module limits\n\ngo 1.21\n")
+	writeTestFile(t, filepath.Join(dir, "limits_test.go"), `package limits
+
+import "testing"
+
+var sink [][]byte
+
+func TestAllocate(t *testing.T) {
+	for {
+		sink = append(sink, make([]byte, 1<<20))
+		sink[len(sink)-1][0] = 1
+	}
+}
+
+func TestSpin(t *testing.T) {
+	for n := 0; ; n++ {
+	}
+}
+`)
+
+	t.Run("memory", func(t *testing.T) {
+		adapter := NewLocalTestRunnerAdapter(WithResourceLimits(ResourceLimits{MemoryMB: 256}))
+
+		_, err := adapter.RunGoTest(context.Background(), dir, ".", "^TestAllocate$", "")
+		require.ErrorIs(t, err, ErrResourceLimit)
+	})
+
+	t.Run("cpu", func(t *testing.T) {
+		adapter := NewLocalTestRunnerAdapter(WithResourceLimits(ResourceLimits{CPUSeconds: 2}))
+
+		_, err := adapter.RunGoTest(context.Background(), dir, ".", "^TestSpin$", "")
+		require.ErrorIs(t, err, ErrCPULimit)
+	})
+
+	t.Run("killed before the cpu limit", func(t *testing.T) {
+		limits := ResourceLimits{CPUSeconds: 60}
+
+		// The shell stands in for a test binary the kernel killed for memory.
+		cmd := testCommand(context.Background(), limits, "sh", "-c", `echo "signal: killed"; kill -9 $$`)
+
+		output, err := cmd.Output()
+		require.Error(t, err)
+		assert.NotErrorIs(t, limits.classify(string(output), err), ErrCPULimit)
+	})
+}
+
+// processRunning reports whether pid is alive and not a zombie.
+func processRunning(pid int) bool {
+	stat, err := os.ReadFile(filepath.Join("/proc", strconv.Itoa(pid), "stat"))
+	if err != nil {
+		return false
+	}
+
+	_, state, _ := strings.Cut(string(stat), ") ")
+
+	return !strings.HasPrefix(state, "Z")
+}
@@ -0,0 +1,23 @@
+//go:build !unix
+
+package adapter
+
+import (
+	"context"
+	"os/exec"
+)
+
+// testCommand returns a command running name. Process groups and resource
+// limits are not supported on this platform, so only the command itself is
+// killed when ctx is canceled.
+func testCommand(ctx context.Context, _ ResourceLimits, name string, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.WaitDelay = processWaitDelay
+
+	return cmd
+}
+
+// runTestCommand runs a command built by testCommand.
+func runTestCommand(cmd *exec.Cmd) error {
+	return cmd.Run()
+}
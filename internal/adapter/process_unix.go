@@ -0,0 +1,107 @@
+//go:build unix
+
+package adapter
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+// testCommand returns a command running name under the limits, in a process
+// group of its own: canceling ctx kills the whole group, so test binaries
+// stuck in a loop do not outlive the go command that started them.
+func testCommand(ctx context.Context, limits ResourceLimits, name string, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, name, args...)
+	if script := limits.ulimitScript(); script != "" {
+		cmd = exec.CommandContext(ctx, "sh", append([]string{"-c", script + `exec "$@"`, "sh", name}, args...)...)
+	}
+
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return killProcessGroup(cmd.Process.Pid)
+	}
+	cmd.WaitDelay = processWaitDelay
+
+	return cmd
+}
+
+// runTestCommand runs a command built by testCommand and then kills whatever
+// it left running in its process group.
+func runTestCommand(cmd *exec.Cmd) error {
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	pid := cmd.Process.Pid
+	processGroups.add(pid)
+
+	err := cmd.Wait()
+
+	_ = killProcessGroup(pid)
+	processGroups.remove(pid)
+
+	return err
+}
+
+func killProcessGroup(pid int) error {
+	err := syscall.Kill(-pid, syscall.SIGKILL)
+	if errors.Is(err, syscall.ESRCH) {
+		return os.ErrProcessDone
+	}
+
+	return err
+}
+
+// processGroups tracks the process groups of the running test commands. Being
+// in groups of their own, they no longer receive the signals sent to gooze's
+// group, such as Ctrl-C, so they are killed when gooze is.
+var processGroups = &groupRegistry{pids: map[int]struct{}{}}
+
+type groupRegistry struct {
+	mu    sync.Mutex
+	pids  map[int]struct{}
+	watch sync.Once
+}
+
+func (r *groupRegistry) add(pid int) {
+	r.watch.Do(func() {
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+
+		go r.forward(signals)
+	})
+
+	r.mu.Lock()
+	r.pids[pid] = struct{}{}
+	r.mu.Unlock()
+}
+
+func (r *groupRegistry) remove(pid int) {
+	r.mu.Lock()
+	delete(r.pids, pid)
+	r.mu.Unlock()
+}
+
+// forward kills every tracked group on the first signal, then stops listening
+// and raises the signal again, so it reaches the other handlers or, when there
+// are none, terminates gooze as it would have.
+func (r *groupRegistry) forward(signals chan os.Signal) {
+	sig := <-signals
+
+	r.mu.Lock()
+	for pid := range r.pids {
+		_ = killProcessGroup(pid)
+	}
+	r.mu.Unlock()
+
+	signal.Stop(signals)
+
+	if s, ok := sig.(syscall.Signal); ok {
+		_ = syscall.Kill(os.Getpid(), s)
+	}
+}
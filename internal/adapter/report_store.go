@@ -9,6 +9,7 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 
@@ -27,25 +28,52 @@ type ReportStore interface {
 	RegenerateIndex(ctx context.Context, path m.Path) error
 	LoadReports(ctx context.Context, path m.Path) ([]m.Report, error)
 	LoadSpillReports(ctx context.Context, path m.Path) (pkg.FileSpill[m.Report], error)
-	CheckUpdates(ctx context.Context, path m.Path, sources []m.Source) ([]m.Source, error)
+	CheckUpdates(ctx context.Context, path m.Path, sources []m.Source, scope m.RunScope) ([]m.Source, error)
 	CleanReports(ctx context.Context, path m.Path, sources []m.Source) error
 }
 
 // LocalReportStore is the concrete implementation that will back the
 // ReportStore interface. It currently returns nil for LoadReports so tests
 // can drive the actual logic.
-type LocalReportStore struct{}
+type LocalReportStore struct {
+	mutationTypes []m.MutationType
+}
+
+// ReportStoreOption configures a LocalReportStore.
+type ReportStoreOption func(*LocalReportStore)
+
+// WithMutationTypes sets the mutation types (and versions) cached reports are
+// validated against. Use it when mutagens run with non-default parameters so
+// results produced under other parameters are re-run.
+func WithMutationTypes(types ...m.MutationType) ReportStoreOption {
+	return func(rs *LocalReportStore) {
+		rs.mutationTypes = types
+	}
+}
 
 // NewReportStore constructs a LocalReportStore instance ready to
 // be wired into the workflow.
-func NewReportStore() ReportStore {
-	return &LocalReportStore{}
+func NewReportStore(opts ...ReportStoreOption) ReportStore {
+	rs := &LocalReportStore{}
+	for _, opt := range opts {
+		opt(rs)
+	}
+
+	return rs
 }
 
 type reportYAML struct {
-	Source m.Source          `yaml:"source"`
-	Result []resultEntryYAML `yaml:"result"`
-	Diff   *[]byte           `yaml:"diff"`
+	Source       m.Source          `yaml:"source"`
+	Result       []resultEntryYAML `yaml:"result"`
+	Diff         *[]byte           `yaml:"diff"`
+	Scope        string            `yaml:"scope,omitempty"`
+	DuplicateOf  string            `yaml:"duplicate_of,omitempty"`
+	Constituents []string          `yaml:"constituents,omitempty"`
+	Line         int               `yaml:"line,omitempty"`
+	FlakyTests   []string          `yaml:"flaky_tests,omitempty"`
+	Selection    []m.LineRange     `yaml:"selection,omitempty"`
+	Func         string            `yaml:"func,omitempty"`
+	Funcs        m.FuncSelection   `yaml:"funcs,omitempty"`
 }
 
 type resultEntryYAML struct {
@@ -71,13 +99,33 @@ type resultEntry struct {
 }
 
 type indexEntry struct {
-	TotalMutations      int           `yaml:"total_mutations"`
-	KilledMutations     int           `yaml:"killed_mutations"`
-	SurvivedMutations   int           `yaml:"survived_mutations"`
-	FailedMutations     int           `yaml:"failed_mutations"`
-	IgnoredMutations    int           `yaml:"ignored_mutations"`
-	NotCoveredMutations int           `yaml:"not_covered_mutations"`
-	Result              []resultEntry `yaml:"result"`
+	TotalMutations      int                `yaml:"total_mutations"`
+	KilledMutations     int                `yaml:"killed_mutations"`
+	SurvivedMutations   int                `yaml:"survived_mutations"`
+	FailedMutations     int                `yaml:"failed_mutations"`
+	IgnoredMutations    int                `yaml:"ignored_mutations"`
+	NotCoveredMutations int                `yaml:"not_covered_mutations"`
+	EquivalentMutations int                `yaml:"equivalent_mutations"`
+	FlakyMutations      int                `yaml:"flaky_mutations"`
+	DuplicateMutations  int                `yaml:"duplicate_mutations"`
+	ScoreInterval       *scoreIntervalYAML `yaml:"score_interval,omitempty"`
+	Result              []resultEntry      `yaml:"result"`
+	// Modules breaks the index down per Go module when the reports span
+	// several, as in a go.work workspace.
+	Modules []moduleEntry `yaml:"modules,omitempty"`
+}
+
+// moduleEntry is the index of the reports of one module.
+type moduleEntry struct {
+	Module     string `yaml:"module"`
+	indexEntry `yaml:",inline"`
+}
+
+// scoreIntervalYAML is the 95% confidence interval of the score of all mutants,
+// written when some were skipped and the score is estimated from the rest.
+type scoreIntervalYAML struct {
+	Low  float64 `yaml:"low"`
+	High float64 `yaml:"high"`
 }
 
 // SaveReports writes one YAML file per report into the provided directory.
@@ -317,6 +365,14 @@ func (rs *LocalReportStore) LoadSpillReports(ctx context.Context, path m.Path) (
 type storedSourceState struct {
 	source  m.Source
 	mutator map[string]int
+	// skipped is set when a sampled run left mutants of the source untested.
+	skipped bool
+	// full is set when some report was produced without a line selection;
+	// selected otherwise holds the line ranges the reports were limited to.
+	full     bool
+	selected []m.LineRange
+	// funcs holds the function filters the reports were produced under.
+	funcs []m.FuncSelection
 }
 
 // CleanReports deletes stored report files that belong to the provided sources.
@@ -431,8 +487,11 @@ func (rs *LocalReportStore) shouldCleanReport(source m.Source, toCleanPaths map[
 // CheckUpdates returns sources that should be re-tested because:
 // - the source file is deleted (present in stored reports but not in current `sources`)
 // - source/test content hash changed
-// - the current mutator set or versions differ from what was used to generate stored reports.
-func (rs *LocalReportStore) CheckUpdates(ctx context.Context, path m.Path, sources []m.Source) ([]m.Source, error) {
+// - the current mutator set or versions differ from what was used to generate stored reports
+// - a sampled run left some of its mutants untested
+// - the stored reports were limited to lines the current scope goes beyond
+// - the stored reports were produced under another function filter.
+func (rs *LocalReportStore) CheckUpdates(ctx context.Context, path m.Path, sources []m.Source, scope m.RunScope) ([]m.Source, error) {
 	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
@@ -459,7 +518,7 @@ func (rs *LocalReportStore) CheckUpdates(ctx context.Context, path m.Path, sourc
 
 	stored := rs.buildStoredSourceState(reports)
 	currentByPath := rs.buildCurrentSourceMap(sources)
-	changed := rs.findChangedSources(stored, currentByPath)
+	changed := rs.findChangedSources(stored, currentByPath, scope)
 
 	return changed, nil
 }
@@ -478,13 +537,13 @@ func (rs *LocalReportStore) buildCurrentSourceMap(sources []m.Source) map[string
 	return currentByPath
 }
 
-func (rs *LocalReportStore) findChangedSources(stored map[string]storedSourceState, currentByPath map[string]m.Source) []m.Source {
+func (rs *LocalReportStore) findChangedSources(stored map[string]storedSourceState, currentByPath map[string]m.Source, scope m.RunScope) []m.Source {
 	changed := make([]m.Source, 0)
 	visited := make(map[string]bool, len(currentByPath))
 
 	for pathStr, current := range currentByPath {
 		visited[pathStr] = true
-		if rs.isSourceChanged(stored, pathStr, current) {
+		if rs.isSourceChanged(stored, pathStr, current, scope) {
 			changed = append(changed, current)
 		}
 	}
@@ -499,13 +558,13 @@ func (rs *LocalReportStore) findChangedSources(stored map[string]storedSourceSta
 	return changed
 }
 
-func (rs *LocalReportStore) isSourceChanged(stored map[string]storedSourceState, pathStr string, current m.Source) bool {
+func (rs *LocalReportStore) isSourceChanged(stored map[string]storedSourceState, pathStr string, current m.Source, scope m.RunScope) bool {
 	st, ok := stored[pathStr]
 	if !ok {
 		return true
 	}
 
-	if rs.sourceHashChanged(st.source, current) {
+	if rs.sourceHashChanged(st.source, current) || st.skipped || !st.coversLines(scope.Lines, m.Path(pathStr)) || !st.coversFuncs(scope.Funcs) {
 		return true
 	}
 
@@ -530,7 +589,21 @@ func (rs *LocalReportStore) buildStoredSourceState(reports []m.Report) map[strin
 		// Keep the most recently seen Source metadata (hashes), but they should be consistent.
 		st.source = report.Source
 
-		for mt := range report.Result {
+		if len(report.Selection) == 0 {
+			st.full = true
+		} else {
+			st.selected = append(st.selected, report.Selection...)
+		}
+
+		if !slices.ContainsFunc(st.funcs, report.Funcs.Equal) {
+			st.funcs = append(st.funcs, report.Funcs)
+		}
+
+		for mt, entries := range report.Result {
+			for _, entry := range entries {
+				st.skipped = st.skipped || entry.Status == m.Skipped
+			}
+
 			if existing, ok := st.mutator[mt.Name]; ok && existing != mt.Version {
 				// Version mismatch across reports - mark as needing update
 				// Use -1 as a sentinel to indicate inconsistency
@@ -546,6 +619,59 @@ func (rs *LocalReportStore) buildStoredSourceState(reports []m.Report) map[strin
 	return state
 }
 
+// coversLines reports whether the stored reports of the file at path cover the
+// lines the current run selects in it: every line when lines is nil.
+func (st storedSourceState) coversLines(lines map[m.Path][]m.LineRange, path m.Path) bool {
+	if st.full {
+		return true
+	}
+
+	if lines == nil {
+		return false
+	}
+
+	for _, r := range lines[path] {
+		if !rangeCovered(st.selected, r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// coversFuncs reports whether the stored reports were produced under funcs, or
+// under no function filter at all.
+func (st storedSourceState) coversFuncs(funcs m.FuncSelection) bool {
+	for _, stored := range st.funcs {
+		if !stored.Enabled() || stored.Equal(funcs) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// rangeCovered reports whether every line of r lies in one of ranges.
+func rangeCovered(ranges []m.LineRange, r m.LineRange) bool {
+	for line := r.Start; line <= r.End; {
+		end := line - 1
+
+		for _, candidate := range ranges {
+			if candidate.Contains(line) && candidate.End > end {
+				end = candidate.End
+			}
+		}
+
+		if end < line {
+			return false
+		}
+
+		line = end + 1
+	}
+
+	return true
+}
+
 func (rs *LocalReportStore) sourceHashChanged(stored m.Source, current m.Source) bool {
 	storedOriginHash := ""
 	currentOriginHash := ""
@@ -562,26 +688,11 @@ func (rs *LocalReportStore) sourceHashChanged(stored m.Source, current m.Source)
 		return true
 	}
 
-	storedTestHash := ""
-	currentTestHash := ""
-
-	if stored.Test != nil {
-		storedTestHash = stored.Test.Hash
-	}
-
-	if current.Test != nil {
-		currentTestHash = current.Test.Hash
-	}
-
-	if storedTestHash != currentTestHash {
-		return true
-	}
-
-	return false
+	return stored.TestHash() != current.TestHash()
 }
 
 func (rs *LocalReportStore) mutatorsChanged(stored map[string]int) bool {
-	current := currentMutationVersions()
+	current := currentMutationVersions(rs.mutationTypes)
 
 	// Check if any mutator versions changed for mutators that were stored
 	for name, storedVersion := range stored {
@@ -603,15 +714,18 @@ func (rs *LocalReportStore) mutatorsChanged(stored map[string]int) bool {
 	return false
 }
 
-func currentMutationVersions() map[string]int {
-	// Keep in sync with supported mutation types.
-	mutations := []m.MutationType{
-		m.MutationArithmetic,
-		m.MutationBoolean,
-		m.MutationNumbers,
-		m.MutationComparison,
-		m.MutationLogical,
-		m.MutationUnary,
+func currentMutationVersions(mutations []m.MutationType) map[string]int {
+	if len(mutations) == 0 {
+		// Keep in sync with supported mutation types.
+		mutations = []m.MutationType{
+			m.MutationArithmetic,
+			m.MutationBoolean,
+			m.MutationNumbers,
+			m.MutationComparison,
+			m.MutationLogical,
+			m.MutationUnary,
+			m.MutationCollection,
+		}
 	}
 
 	out := make(map[string]int, len(mutations))
@@ -624,9 +738,17 @@ func currentMutationVersions() map[string]int {
 
 func (rs *LocalReportStore) marshalReport(report m.Report) ([]byte, error) {
 	encoded := reportYAML{
-		Source: report.Source,
-		Result: encodeResult(report.Result),
-		Diff:   report.Diff,
+		Source:       report.Source,
+		Result:       encodeResult(report.Result),
+		Diff:         report.Diff,
+		Scope:        report.Scope,
+		DuplicateOf:  report.DuplicateOf,
+		Constituents: report.Constituents,
+		Line:         report.Line,
+		FlakyTests:   report.FlakyTests,
+		Selection:    report.Selection,
+		Func:         report.Func,
+		Funcs:        report.Funcs,
 	}
 
 	return yaml.Marshal(encoded)
@@ -639,9 +761,17 @@ func (rs *LocalReportStore) unmarshalReport(data []byte) (m.Report, error) {
 	}
 
 	return m.Report{
-		Source: decoded.Source,
-		Result: decodeResult(decoded.Result),
-		Diff:   decoded.Diff,
+		Source:       decoded.Source,
+		Result:       decodeResult(decoded.Result),
+		Diff:         decoded.Diff,
+		Scope:        decoded.Scope,
+		DuplicateOf:  decoded.DuplicateOf,
+		Constituents: decoded.Constituents,
+		Line:         decoded.Line,
+		FlakyTests:   decoded.FlakyTests,
+		Selection:    decoded.Selection,
+		Func:         decoded.Func,
+		Funcs:        decoded.Funcs,
 	}, nil
 }
 
@@ -723,13 +853,48 @@ func decodeResult(entries []resultEntryYAML) m.Result {
 }
 
 func (rs *LocalReportStore) buildIndexFromReports(reports []m.Report) indexEntry {
+	index := rs.buildModuleIndex(reports)
+	index.Modules = rs.buildModuleEntries(reports)
+
+	return index
+}
+
+func (rs *LocalReportStore) buildModuleIndex(reports []m.Report) indexEntry {
 	index := indexEntry{Result: make([]resultEntry, 0)}
 	state := rs.collectIndexState(reports, &index)
 	index.Result = rs.buildIndexResults(state)
+	index.ScoreInterval = scoreInterval(index)
 
 	return index
 }
 
+// buildModuleEntries indexes the reports of each module separately, in module
+// path order. It returns nil unless the reports span several modules.
+func (rs *LocalReportStore) buildModuleEntries(reports []m.Report) []moduleEntry {
+	byModule := map[string][]m.Report{}
+	for _, report := range reports {
+		byModule[report.Source.Module] = append(byModule[report.Source.Module], report)
+	}
+
+	if len(byModule) < 2 {
+		return nil
+	}
+
+	modules := make([]string, 0, len(byModule))
+	for module := range byModule {
+		modules = append(modules, module)
+	}
+
+	sort.Strings(modules)
+
+	entries := make([]moduleEntry, 0, len(modules))
+	for _, module := range modules {
+		entries = append(entries, moduleEntry{Module: module, indexEntry: rs.buildModuleIndex(byModule[module])})
+	}
+
+	return entries
+}
+
 type indexState struct {
 	globalMutationMap map[string]*mutationEntry
 	sourceToMutations map[string]map[string]bool
@@ -756,6 +921,13 @@ func (rs *LocalReportStore) collectIndexState(reports []m.Report, index *indexEn
 
 		for mutationType, results := range report.Result {
 			for _, result := range results {
+				// Duplicates share the status of the mutation they duplicate,
+				// which is already counted.
+				if report.DuplicateOf != "" {
+					index.DuplicateMutations++
+					continue
+				}
+
 				index.TotalMutations++
 				rs.incrementStatusCount(index, result.Status)
 			}
@@ -834,6 +1006,20 @@ func (rs *LocalReportStore) sourceHex(source m.Source) string {
 	return source.Origin.Hash
 }
 
+// scoreInterval estimates the score of all mutants from the tested ones. Like
+// the score, it leaves out equivalent and flaky mutants; duplicates are not in
+// the totals.
+func scoreInterval(index indexEntry) *scoreIntervalYAML {
+	if index.IgnoredMutations == 0 {
+		return nil
+	}
+
+	scored := index.TotalMutations - index.EquivalentMutations - index.FlakyMutations - index.IgnoredMutations
+	low, high := pkg.ScoreInterval(index.KilledMutations, scored, scored+index.IgnoredMutations)
+
+	return &scoreIntervalYAML{Low: low, High: high}
+}
+
 func (rs *LocalReportStore) incrementStatusCount(index *indexEntry, status m.TestStatus) {
 	switch status {
 	case m.Killed:
@@ -846,6 +1032,10 @@ func (rs *LocalReportStore) incrementStatusCount(index *indexEntry, status m.Tes
 		index.IgnoredMutations++
 	case m.NotCovered:
 		index.NotCoveredMutations++
+	case m.Equivalent:
+		index.EquivalentMutations++
+	case m.Flaky:
+		index.FlakyMutations++
 	}
 }
 
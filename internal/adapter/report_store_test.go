@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"reflect"
 	"regexp"
+	"slices"
 	"strings"
 	"testing"
 
@@ -26,7 +27,7 @@ func TestLocalReportStore_SaveReports_WritesHashedYAMLPerReport(t *testing.T) {
 	report := m.Report{
 		Source: m.Source{
 			Origin: &m.File{FullPath: m.Path("/abs/path/file.go"), Hash: "abc123"},
-			Test:   &m.File{FullPath: m.Path("/abs/path/file_test.go"), Hash: "def456"},
+			Tests:  []*m.File{{FullPath: m.Path("/abs/path/file_test.go"), Hash: "def456"}},
 		},
 		Result: m.Result{
 			m.MutationBoolean: {
@@ -79,8 +80,8 @@ func TestLocalReportStore_SaveReports_WritesHashedYAMLPerReport(t *testing.T) {
 		t.Fatalf("unmarshal YAML: %v", err)
 	}
 
-	if decoded.Source.Origin == nil || decoded.Source.Test == nil {
-		t.Fatalf("expected source origin and test to be present")
+	if decoded.Source.Origin == nil || len(decoded.Source.Tests) != 1 {
+		t.Fatalf("expected source origin and tests to be present")
 	}
 	if decoded.Source.Origin.Hash != "abc123" {
 		t.Fatalf("unexpected origin hash: %s", decoded.Source.Origin.Hash)
@@ -159,11 +160,22 @@ func TestLocalReportStore_SaveReports_WritesIndexYAML(t *testing.T) {
 		Result: m.Result{
 			m.MutationArithmetic: {
 				{MutationID: "a1", Status: m.Error, Err: errors.New("nope")},
+				{MutationID: "a2", Status: m.Equivalent, Err: nil},
 			},
 		},
 	}
 
-	if err := rs.SaveReports(context.Background(), m.Path(dir), []m.Report{report1, report2}); err != nil {
+	report3 := m.Report{
+		Source: m.Source{Origin: &m.File{FullPath: m.Path("/abs/c.go"), Hash: "sourceC"}},
+		Result: m.Result{
+			m.MutationComparison: {
+				{MutationID: "c1", Status: m.Flaky, Err: nil},
+			},
+		},
+		FlakyTests: []string{"TestRace"},
+	}
+
+	if err := rs.SaveReports(context.Background(), m.Path(dir), []m.Report{report1, report2, report3}); err != nil {
 		t.Fatalf("SaveReports returned error: %v", err)
 	}
 
@@ -185,8 +197,8 @@ func TestLocalReportStore_SaveReports_WritesIndexYAML(t *testing.T) {
 		t.Fatalf("unmarshal _index.yaml: %v", err)
 	}
 
-	if idx.TotalMutations != 3 {
-		t.Fatalf("expected total_mutations=3, got %d", idx.TotalMutations)
+	if idx.TotalMutations != 5 {
+		t.Fatalf("expected total_mutations=5, got %d", idx.TotalMutations)
 	}
 	if idx.KilledMutations != 1 {
 		t.Fatalf("expected killed_mutations=1, got %d", idx.KilledMutations)
@@ -200,9 +212,18 @@ func TestLocalReportStore_SaveReports_WritesIndexYAML(t *testing.T) {
 	if idx.SurvivedMutations != 0 {
 		t.Fatalf("expected survived_mutations=0, got %d", idx.SurvivedMutations)
 	}
+	if idx.EquivalentMutations != 1 {
+		t.Fatalf("expected equivalent_mutations=1, got %d", idx.EquivalentMutations)
+	}
+	if idx.FlakyMutations != 1 {
+		t.Fatalf("expected flaky_mutations=1, got %d", idx.FlakyMutations)
+	}
+	if idx.ScoreInterval == nil || idx.ScoreInterval.Low > 0.5 || idx.ScoreInterval.High < 0.5 {
+		t.Fatalf("expected a score_interval around 50%%, got %+v", idx.ScoreInterval)
+	}
 
-	if len(idx.Result) != 2 {
-		t.Fatalf("expected 2 result entries, got %d", len(idx.Result))
+	if len(idx.Result) != 3 {
+		t.Fatalf("expected 3 result entries, got %d", len(idx.Result))
 	}
 
 	hash1 := rs.computeReportHash(report1.Result)
@@ -247,6 +268,220 @@ func TestLocalReportStore_SaveReports_WritesIndexYAML(t *testing.T) {
 	}
 }
 
+func TestLocalReportStore_RegenerateIndex_GroupsModules(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	rs := &LocalReportStore{}
+
+	reports := []m.Report{
+		{
+			Source: m.Source{Origin: &m.File{FullPath: m.Path("/work/api/a.go"), Hash: "sourceA"}, Module: "example.com/api"},
+			Result: m.Result{m.MutationBoolean: {{MutationID: "b1", Status: m.Killed}}},
+		},
+		{
+			Source: m.Source{Origin: &m.File{FullPath: m.Path("/work/shared/b.go"), Hash: "sourceB"}, Module: "example.com/shared"},
+			Result: m.Result{m.MutationArithmetic: {{MutationID: "a1", Status: m.Survived}, {MutationID: "a2", Status: m.Killed}}},
+		},
+	}
+	if err := rs.SaveReports(context.Background(), m.Path(dir), reports); err != nil {
+		t.Fatalf("SaveReports returned error: %v", err)
+	}
+
+	if err := rs.RegenerateIndex(context.Background(), m.Path(dir)); err != nil {
+		t.Fatalf("RegenerateIndex returned error: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "_index.yaml"))
+	if err != nil {
+		t.Fatalf("expected _index.yaml to exist: %v", err)
+	}
+
+	var idx indexEntry
+	if err := yaml.Unmarshal(data, &idx); err != nil {
+		t.Fatalf("unmarshal _index.yaml: %v", err)
+	}
+
+	if idx.TotalMutations != 3 || len(idx.Result) != 2 {
+		t.Fatalf("expected the workspace totals, got total=%d results=%d", idx.TotalMutations, len(idx.Result))
+	}
+
+	if len(idx.Modules) != 2 {
+		t.Fatalf("expected 2 modules, got %d", len(idx.Modules))
+	}
+
+	api, shared := idx.Modules[0], idx.Modules[1]
+	if api.Module != "example.com/api" || api.TotalMutations != 1 || api.KilledMutations != 1 || len(api.Result) != 1 {
+		t.Fatalf("unexpected api module entry: %+v", api)
+	}
+
+	if shared.Module != "example.com/shared" || shared.TotalMutations != 2 || shared.SurvivedMutations != 1 || len(shared.Result) != 1 {
+		t.Fatalf("unexpected shared module entry: %+v", shared)
+	}
+
+	if len(api.Modules) != 0 {
+		t.Fatalf("expected module entries not to nest modules, got %+v", api.Modules)
+	}
+
+	if err := rs.CleanReports(context.Background(), m.Path(dir), []m.Source{reports[1].Source}); err != nil {
+		t.Fatalf("CleanReports returned error: %v", err)
+	}
+
+	data, err = os.ReadFile(filepath.Join(dir, "_index.yaml"))
+	if err != nil {
+		t.Fatalf("expected _index.yaml to exist: %v", err)
+	}
+
+	if strings.Contains(string(data), "modules:") {
+		t.Fatalf("expected no module breakdown for a single module, got:\n%s", data)
+	}
+}
+
+func TestLocalReportStore_RegenerateIndex_CountsDuplicatesSeparately(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	rs := &LocalReportStore{}
+
+	source := m.Source{Origin: &m.File{FullPath: m.Path("/abs/a.go"), Hash: "sourceA"}}
+	original := m.Report{
+		Source: source,
+		Result: m.Result{m.MutationBoolean: {{MutationID: "b1", Status: m.Survived}}},
+	}
+	duplicate := m.Report{
+		Source:      source,
+		Result:      m.Result{m.MutationBranch: {{MutationID: "r1", Status: m.Survived}}},
+		DuplicateOf: "b1",
+	}
+
+	if err := rs.SaveReports(context.Background(), m.Path(dir), []m.Report{original, duplicate}); err != nil {
+		t.Fatalf("SaveReports returned error: %v", err)
+	}
+
+	if err := rs.RegenerateIndex(context.Background(), m.Path(dir)); err != nil {
+		t.Fatalf("RegenerateIndex returned error: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "_index.yaml"))
+	if err != nil {
+		t.Fatalf("expected _index.yaml to exist: %v", err)
+	}
+
+	var idx indexEntry
+	if err := yaml.Unmarshal(data, &idx); err != nil {
+		t.Fatalf("unmarshal _index.yaml: %v", err)
+	}
+
+	if idx.TotalMutations != 1 || idx.SurvivedMutations != 1 || idx.DuplicateMutations != 1 {
+		t.Fatalf("expected 1 total, 1 survived and 1 duplicate mutation, got %+v", idx)
+	}
+
+	loaded, err := rs.LoadReports(context.Background(), m.Path(dir))
+	if err != nil {
+		t.Fatalf("LoadReports returned error: %v", err)
+	}
+
+	duplicates := 0
+	for _, report := range loaded {
+		if report.DuplicateOf == "b1" {
+			duplicates++
+		}
+	}
+
+	if duplicates != 1 {
+		t.Fatalf("expected the duplicate_of pointer to round-trip, got %d duplicates", duplicates)
+	}
+}
+
+func TestLocalReportStore_RoundTripsHigherOrderConstituents(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	rs := &LocalReportStore{}
+
+	diff := []byte("--- original\n+++ mutated\n")
+	report := m.Report{
+		Source:       m.Source{Origin: &m.File{FullPath: m.Path("/abs/a.go"), Hash: "sourceA"}},
+		Result:       m.Result{m.MutationHigherOrder: {{MutationID: "h1", Status: m.Survived}}},
+		Diff:         &diff,
+		Constituents: []string{"a1", "c1"},
+	}
+
+	if err := rs.SaveReports(context.Background(), m.Path(dir), []m.Report{report}); err != nil {
+		t.Fatalf("SaveReports returned error: %v", err)
+	}
+
+	loaded, err := rs.LoadReports(context.Background(), m.Path(dir))
+	if err != nil {
+		t.Fatalf("LoadReports returned error: %v", err)
+	}
+
+	if len(loaded) != 1 || !slices.Equal(loaded[0].Constituents, []string{"a1", "c1"}) {
+		t.Fatalf("expected constituents to round-trip, got %+v", loaded)
+	}
+}
+
+func TestLocalReportStore_RoundTripsFlakyTests(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	rs := &LocalReportStore{}
+
+	diff := []byte("--- original\n+++ mutated\n")
+	report := m.Report{
+		Source:     m.Source{Origin: &m.File{FullPath: m.Path("/abs/a.go"), Hash: "sourceA"}},
+		Result:     m.Result{m.MutationArithmetic: {{MutationID: "a1", Status: m.Flaky}}},
+		Diff:       &diff,
+		FlakyTests: []string{"TestRace", "TestSlow"},
+	}
+
+	if err := rs.SaveReports(context.Background(), m.Path(dir), []m.Report{report}); err != nil {
+		t.Fatalf("SaveReports returned error: %v", err)
+	}
+
+	loaded, err := rs.LoadReports(context.Background(), m.Path(dir))
+	if err != nil {
+		t.Fatalf("LoadReports returned error: %v", err)
+	}
+
+	if len(loaded) != 1 || !slices.Equal(loaded[0].FlakyTests, []string{"TestRace", "TestSlow"}) {
+		t.Fatalf("expected flaky tests to round-trip, got %+v", loaded)
+	}
+}
+
+func TestLocalReportStore_RoundTripsLineAndSelection(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	rs := &LocalReportStore{}
+
+	report := m.Report{
+		Source:    m.Source{Origin: &m.File{FullPath: m.Path("/abs/a.go"), Hash: "sourceA"}},
+		Result:    m.Result{m.MutationArithmetic: {{MutationID: "a1", Status: m.Killed}}},
+		Line:      42,
+		Selection: []m.LineRange{{Start: 40, End: 80}},
+		Func:      "Config.Parse",
+		Funcs:     m.FuncSelection{Match: []string{"Parse"}, Exclude: []string{"String$"}},
+	}
+
+	if err := rs.SaveReports(context.Background(), m.Path(dir), []m.Report{report}); err != nil {
+		t.Fatalf("SaveReports returned error: %v", err)
+	}
+
+	loaded, err := rs.LoadReports(context.Background(), m.Path(dir))
+	if err != nil {
+		t.Fatalf("LoadReports returned error: %v", err)
+	}
+
+	if len(loaded) != 1 || loaded[0].Line != 42 || !slices.Equal(loaded[0].Selection, report.Selection) {
+		t.Fatalf("expected line and selection to round-trip, got %+v", loaded)
+	}
+
+	if loaded[0].Func != report.Func || !loaded[0].Funcs.Equal(report.Funcs) {
+		t.Fatalf("expected the function and its filter to round-trip, got %+v", loaded[0])
+	}
+}
+
 func TestLocalReportStore_SaveSpillReports_WritesHashedYAMLPerReport(t *testing.T) {
 	t.Parallel()
 
@@ -263,7 +498,7 @@ func TestLocalReportStore_SaveSpillReports_WritesHashedYAMLPerReport(t *testing.
 	report := m.Report{
 		Source: m.Source{
 			Origin: &m.File{FullPath: m.Path("/abs/path/file.go"), Hash: "abc123"},
-			Test:   &m.File{FullPath: m.Path("/abs/path/file_test.go"), Hash: "def456"},
+			Tests:  []*m.File{{FullPath: m.Path("/abs/path/file_test.go"), Hash: "def456"}},
 		},
 		Result: m.Result{
 			m.MutationBoolean: {
@@ -301,18 +536,19 @@ func TestLocalReportStore_LoadSpillReports_LoadsReportsIntoSpill(t *testing.T) {
 	report1 := m.Report{
 		Source: m.Source{
 			Origin: &m.File{FullPath: m.Path("/abs/path/file1.go"), Hash: "abc123"},
-			Test:   &m.File{FullPath: m.Path("/abs/path/file1_test.go"), Hash: "def456"},
+			Tests:  []*m.File{{FullPath: m.Path("/abs/path/file1_test.go"), Hash: "def456"}},
 		},
 		Result: m.Result{
 			m.MutationBoolean: {
 				{MutationID: "m1", Status: m.Killed, Err: nil},
 			},
 		},
+		Scope: "scope1",
 	}
 	report2 := m.Report{
 		Source: m.Source{
 			Origin: &m.File{FullPath: m.Path("/abs/path/file2.go"), Hash: "ghi789"},
-			Test:   &m.File{FullPath: m.Path("/abs/path/file2_test.go"), Hash: "jkl012"},
+			Tests:  []*m.File{{FullPath: m.Path("/abs/path/file2_test.go"), Hash: "jkl012"}},
 		},
 		Result: m.Result{
 			m.MutationArithmetic: {
@@ -348,12 +584,12 @@ func TestLocalReportStore_LoadSpillReports_LoadsReportsIntoSpill(t *testing.T) {
 	}
 
 	// For simplicity, check that the sources are present (order may vary)
-	sources := make(map[string]bool)
+	sources := make(map[string]string)
 	for _, r := range loadedReports {
-		sources[string(r.Source.Origin.FullPath)] = true
+		sources[string(r.Source.Origin.FullPath)] = r.Scope
 	}
-	if !sources["/abs/path/file1.go"] || !sources["/abs/path/file2.go"] {
-		t.Fatalf("loaded reports do not match expected sources")
+	if _, ok := sources["/abs/path/file2.go"]; !ok || sources["/abs/path/file1.go"] != "scope1" {
+		t.Fatalf("loaded reports do not match expected sources and scopes: %v", sources)
 	}
 }
 
@@ -368,7 +604,7 @@ func TestLocalReportStore_CheckUpdates_NoReportsDir_ReturnsAllSources(t *testing
 		{Origin: &m.File{FullPath: m.Path("/abs/b.go"), Hash: "hash-b"}},
 	}
 
-	changed, err := rs.CheckUpdates(context.Background(), m.Path(dir), sources)
+	changed, err := rs.CheckUpdates(context.Background(), m.Path(dir), sources, m.RunScope{})
 	if err != nil {
 		t.Fatalf("CheckUpdates returned error: %v", err)
 	}
@@ -381,7 +617,7 @@ func TestLocalReportStore_CheckUpdates_EmptyPath_ReturnsError(t *testing.T) {
 	t.Parallel()
 
 	rs := &LocalReportStore{}
-	_, err := rs.CheckUpdates(context.Background(), "", nil)
+	_, err := rs.CheckUpdates(context.Background(), "", nil, m.RunScope{})
 	if err == nil {
 		t.Fatalf("expected error")
 	}
@@ -400,7 +636,7 @@ func TestLocalReportStore_CheckUpdates_PathIsFile_ReturnsError(t *testing.T) {
 	}
 
 	rs := &LocalReportStore{}
-	_, err := rs.CheckUpdates(context.Background(), m.Path(filePath), nil)
+	_, err := rs.CheckUpdates(context.Background(), m.Path(filePath), nil, m.RunScope{})
 	if err == nil {
 		t.Fatalf("expected error")
 	}
@@ -416,7 +652,7 @@ func TestLocalReportStore_CheckUpdates_EmptyReportsDir_ReturnsAllSources(t *test
 	rs := &LocalReportStore{}
 
 	sources := []m.Source{{Origin: &m.File{FullPath: m.Path("/abs/a.go"), Hash: "hash-a"}}}
-	changed, err := rs.CheckUpdates(context.Background(), m.Path(dir), sources)
+	changed, err := rs.CheckUpdates(context.Background(), m.Path(dir), sources, m.RunScope{})
 	if err != nil {
 		t.Fatalf("CheckUpdates returned error: %v", err)
 	}
@@ -440,7 +676,7 @@ func TestLocalReportStore_CheckUpdates_SourceDeleted_ReturnsMissingSource(t *tes
 		t.Fatalf("SaveReports returned error: %v", err)
 	}
 
-	changed, err := rs.CheckUpdates(context.Background(), m.Path(dir), nil)
+	changed, err := rs.CheckUpdates(context.Background(), m.Path(dir), nil, m.RunScope{})
 	if err != nil {
 		t.Fatalf("CheckUpdates returned error: %v", err)
 	}
@@ -460,7 +696,7 @@ func TestLocalReportStore_CheckUpdates_CodeOrTestChanged_ReturnsSource(t *testin
 
 	old := m.Source{
 		Origin: &m.File{FullPath: m.Path("/abs/a.go"), Hash: "old-code"},
-		Test:   &m.File{FullPath: m.Path("/abs/a_test.go"), Hash: "old-test"},
+		Tests:  []*m.File{{FullPath: m.Path("/abs/a_test.go"), Hash: "old-test"}},
 	}
 	report := m.Report{
 		Source: old,
@@ -472,10 +708,10 @@ func TestLocalReportStore_CheckUpdates_CodeOrTestChanged_ReturnsSource(t *testin
 
 	current := []m.Source{{
 		Origin: &m.File{FullPath: m.Path("/abs/a.go"), Hash: "new-code"},
-		Test:   &m.File{FullPath: m.Path("/abs/a_test.go"), Hash: "old-test"},
+		Tests:  []*m.File{{FullPath: m.Path("/abs/a_test.go"), Hash: "old-test"}},
 	}}
 
-	changed, err := rs.CheckUpdates(context.Background(), m.Path(dir), current)
+	changed, err := rs.CheckUpdates(context.Background(), m.Path(dir), current, m.RunScope{})
 	if err != nil {
 		t.Fatalf("CheckUpdates returned error: %v", err)
 	}
@@ -487,6 +723,70 @@ func TestLocalReportStore_CheckUpdates_CodeOrTestChanged_ReturnsSource(t *testin
 	}
 }
 
+func TestLocalReportStore_CheckUpdates_TestConfigChanged_ReturnsSource(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	rs := &LocalReportStore{}
+
+	source := func(testConfig string) m.Source {
+		return m.Source{
+			Origin:     &m.File{FullPath: m.Path("/abs/a.go"), Hash: "same"},
+			Tests:      []*m.File{{FullPath: m.Path("/abs/a_test.go"), Hash: "test-hash"}},
+			TestConfig: testConfig,
+		}
+	}
+
+	report := m.Report{
+		Source: source("short"),
+		Result: m.Result{m.MutationBoolean: {{MutationID: "m1", Status: m.Killed, Err: nil}}},
+	}
+	if err := rs.SaveReports(context.Background(), m.Path(dir), []m.Report{report}); err != nil {
+		t.Fatalf("SaveReports returned error: %v", err)
+	}
+
+	changed, err := rs.CheckUpdates(context.Background(), m.Path(dir), []m.Source{source("short")}, m.RunScope{})
+	if err != nil {
+		t.Fatalf("CheckUpdates returned error: %v", err)
+	}
+	if len(changed) != 0 {
+		t.Fatalf("expected no changed source with the same test settings, got %d", len(changed))
+	}
+
+	changed, err = rs.CheckUpdates(context.Background(), m.Path(dir), []m.Source{source("race")}, m.RunScope{})
+	if err != nil {
+		t.Fatalf("CheckUpdates returned error: %v", err)
+	}
+	if len(changed) != 1 {
+		t.Fatalf("expected 1 changed source with other test settings, got %d", len(changed))
+	}
+}
+
+func TestLocalReportStore_CheckUpdates_TestConfigChangedWithoutTests_ReturnsSource(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	rs := &LocalReportStore{}
+
+	report := m.Report{
+		Source: m.Source{Origin: &m.File{FullPath: m.Path("/abs/a.go"), Hash: "same"}},
+		Result: m.Result{m.MutationBoolean: {{MutationID: "m1", Status: m.Survived, Err: nil}}},
+	}
+	if err := rs.SaveReports(context.Background(), m.Path(dir), []m.Report{report}); err != nil {
+		t.Fatalf("SaveReports returned error: %v", err)
+	}
+
+	current := []m.Source{{Origin: &m.File{FullPath: m.Path("/abs/a.go"), Hash: "same"}, TestConfig: "race"}}
+
+	changed, err := rs.CheckUpdates(context.Background(), m.Path(dir), current, m.RunScope{})
+	if err != nil {
+		t.Fatalf("CheckUpdates returned error: %v", err)
+	}
+	if len(changed) != 1 {
+		t.Fatalf("expected 1 changed test-less source with other test settings, got %d", len(changed))
+	}
+}
+
 func TestLocalReportStore_CheckUpdates_TestFileAddedOrRemoved_ReturnsSource(t *testing.T) {
 	t.Parallel()
 
@@ -496,7 +796,7 @@ func TestLocalReportStore_CheckUpdates_TestFileAddedOrRemoved_ReturnsSource(t *t
 	// Stored run had a test file.
 	stored := m.Source{
 		Origin: &m.File{FullPath: m.Path("/abs/a.go"), Hash: "same"},
-		Test:   &m.File{FullPath: m.Path("/abs/a_test.go"), Hash: "test-hash"},
+		Tests:  []*m.File{{FullPath: m.Path("/abs/a_test.go"), Hash: "test-hash"}},
 	}
 	report := m.Report{
 		Source: stored,
@@ -508,7 +808,7 @@ func TestLocalReportStore_CheckUpdates_TestFileAddedOrRemoved_ReturnsSource(t *t
 
 	// Current run has no test file associated.
 	current := []m.Source{{Origin: &m.File{FullPath: m.Path("/abs/a.go"), Hash: "same"}}}
-	changed, err := rs.CheckUpdates(context.Background(), m.Path(dir), current)
+	changed, err := rs.CheckUpdates(context.Background(), m.Path(dir), current, m.RunScope{})
 	if err != nil {
 		t.Fatalf("CheckUpdates returned error: %v", err)
 	}
@@ -517,6 +817,143 @@ func TestLocalReportStore_CheckUpdates_TestFileAddedOrRemoved_ReturnsSource(t *t
 	}
 }
 
+func TestLocalReportStore_CheckUpdates_SkippedMutants_ReturnsSource(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	rs := &LocalReportStore{}
+
+	source := m.Source{Origin: &m.File{FullPath: m.Path("/abs/a.go"), Hash: "same"}}
+
+	// A sampled run tested one mutant and skipped the other.
+	reports := []m.Report{
+		{Source: source, Result: m.Result{m.MutationArithmetic: {{MutationID: "m1", Status: m.Killed}}}},
+		{Source: source, Result: m.Result{m.MutationArithmetic: {{MutationID: "m2", Status: m.Skipped}}}},
+	}
+	if err := rs.SaveReports(context.Background(), m.Path(dir), reports); err != nil {
+		t.Fatalf("SaveReports returned error: %v", err)
+	}
+
+	changed, err := rs.CheckUpdates(context.Background(), m.Path(dir), []m.Source{source}, m.RunScope{})
+	if err != nil {
+		t.Fatalf("CheckUpdates returned error: %v", err)
+	}
+	if len(changed) != 1 {
+		t.Fatalf("expected the source with skipped mutants to be re-tested, got %d changed sources", len(changed))
+	}
+}
+
+func TestLocalReportStore_CheckUpdates_LineSelectedReports(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	rs := &LocalReportStore{}
+
+	source := m.Source{Origin: &m.File{FullPath: m.Path("/abs/a.go"), Hash: "same"}}
+
+	// Two runs limited to lines of a.go, and no run over the whole file.
+	reports := []m.Report{
+		{Source: source, Line: 12, Selection: []m.LineRange{{Start: 10, End: 20}}, Result: m.Result{m.MutationArithmetic: {{MutationID: "m1", Status: m.Killed}}}},
+		{Source: source, Line: 25, Selection: []m.LineRange{{Start: 21, End: 30}}, Result: m.Result{m.MutationArithmetic: {{MutationID: "m2", Status: m.Killed}}}},
+	}
+	if err := rs.SaveReports(context.Background(), m.Path(dir), reports); err != nil {
+		t.Fatalf("SaveReports returned error: %v", err)
+	}
+
+	lines := func(ranges ...m.LineRange) m.RunScope {
+		return m.RunScope{Lines: map[m.Path][]m.LineRange{"/abs/a.go": ranges}}
+	}
+
+	tests := []struct {
+		name    string
+		scope   m.RunScope
+		changed bool
+	}{
+		{name: "whole file", scope: m.RunScope{}, changed: true},
+		{name: "within one selection", scope: lines(m.LineRange{Start: 12, End: 18}), changed: false},
+		{name: "across both selections", scope: lines(m.LineRange{Start: 15, End: 30}), changed: false},
+		{name: "beyond the selections", scope: lines(m.LineRange{Start: 25, End: 35}), changed: true},
+	}
+
+	for _, tt := range tests {
+		changed, err := rs.CheckUpdates(context.Background(), m.Path(dir), []m.Source{source}, tt.scope)
+		if err != nil {
+			t.Fatalf("%s: CheckUpdates returned error: %v", tt.name, err)
+		}
+
+		if got := len(changed) == 1; got != tt.changed {
+			t.Errorf("%s: changed = %v, want %v", tt.name, got, tt.changed)
+		}
+	}
+
+	// Once the whole file was tested, any selection is covered.
+	full := m.Report{Source: source, Line: 40, Result: m.Result{m.MutationArithmetic: {{MutationID: "m3", Status: m.Killed}}}}
+	if err := rs.SaveReports(context.Background(), m.Path(dir), []m.Report{full}); err != nil {
+		t.Fatalf("SaveReports returned error: %v", err)
+	}
+
+	changed, err := rs.CheckUpdates(context.Background(), m.Path(dir), []m.Source{source}, lines(m.LineRange{Start: 1, End: 100}))
+	if err != nil {
+		t.Fatalf("CheckUpdates returned error: %v", err)
+	}
+	if len(changed) != 0 {
+		t.Fatalf("expected no changed sources after a whole-file run, got %d", len(changed))
+	}
+}
+
+func TestLocalReportStore_CheckUpdates_FuncFilteredReports(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	rs := &LocalReportStore{}
+
+	source := m.Source{Origin: &m.File{FullPath: m.Path("/abs/a.go"), Hash: "same"}}
+	parse := m.FuncSelection{Match: []string{"Parse"}}
+
+	// A run limited to Parse, and no run over every function.
+	reports := []m.Report{
+		{Source: source, Func: "Parse", Funcs: parse, Result: m.Result{m.MutationArithmetic: {{MutationID: "m1", Status: m.Killed}}}},
+	}
+	if err := rs.SaveReports(context.Background(), m.Path(dir), reports); err != nil {
+		t.Fatalf("SaveReports returned error: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		funcs   m.FuncSelection
+		changed bool
+	}{
+		{name: "same filter", funcs: m.FuncSelection{Match: []string{"Parse"}}, changed: false},
+		{name: "no filter", funcs: m.FuncSelection{}, changed: true},
+		{name: "other filter", funcs: m.FuncSelection{Match: []string{"Parse"}, Exclude: []string{"String"}}, changed: true},
+	}
+
+	for _, tt := range tests {
+		changed, err := rs.CheckUpdates(context.Background(), m.Path(dir), []m.Source{source}, m.RunScope{Funcs: tt.funcs})
+		if err != nil {
+			t.Fatalf("%s: CheckUpdates returned error: %v", tt.name, err)
+		}
+
+		if got := len(changed) == 1; got != tt.changed {
+			t.Errorf("%s: changed = %v, want %v", tt.name, got, tt.changed)
+		}
+	}
+
+	// Once every function was tested, any filter is covered.
+	full := m.Report{Source: source, Func: "String", Result: m.Result{m.MutationArithmetic: {{MutationID: "m2", Status: m.Killed}}}}
+	if err := rs.SaveReports(context.Background(), m.Path(dir), []m.Report{full}); err != nil {
+		t.Fatalf("SaveReports returned error: %v", err)
+	}
+
+	changed, err := rs.CheckUpdates(context.Background(), m.Path(dir), []m.Source{source}, m.RunScope{Funcs: m.FuncSelection{Exclude: []string{"Parse"}}})
+	if err != nil {
+		t.Fatalf("CheckUpdates returned error: %v", err)
+	}
+	if len(changed) != 0 {
+		t.Fatalf("expected no changed sources after an unfiltered run, got %d", len(changed))
+	}
+}
+
 func TestLocalReportStore_CheckUpdates_NewMutator_ReturnsSource(t *testing.T) {
 	t.Parallel()
 
@@ -535,7 +972,7 @@ func TestLocalReportStore_CheckUpdates_NewMutator_ReturnsSource(t *testing.T) {
 	}
 
 	current := []m.Source{{Origin: &m.File{FullPath: m.Path("/abs/a.go"), Hash: "same"}}}
-	changed, err := rs.CheckUpdates(context.Background(), m.Path(dir), current)
+	changed, err := rs.CheckUpdates(context.Background(), m.Path(dir), current, m.RunScope{})
 	if err != nil {
 		t.Fatalf("CheckUpdates returned error: %v", err)
 	}
@@ -561,7 +998,7 @@ func TestLocalReportStore_CheckUpdates_StoredHasUnknownMutator_ReturnsSource(t *
 	}
 
 	current := []m.Source{{Origin: &m.File{FullPath: m.Path("/abs/a.go"), Hash: "same"}}}
-	changed, err := rs.CheckUpdates(context.Background(), m.Path(dir), current)
+	changed, err := rs.CheckUpdates(context.Background(), m.Path(dir), current, m.RunScope{})
 	if err != nil {
 		t.Fatalf("CheckUpdates returned error: %v", err)
 	}
@@ -577,7 +1014,7 @@ func TestLocalReportStore_CheckUpdates_IgnoresSourcesWithNilOrigin(t *testing.T)
 	rs := &LocalReportStore{}
 
 	sources := []m.Source{{Origin: nil}}
-	changed, err := rs.CheckUpdates(context.Background(), m.Path(dir), sources)
+	changed, err := rs.CheckUpdates(context.Background(), m.Path(dir), sources, m.RunScope{})
 	if err != nil {
 		t.Fatalf("CheckUpdates returned error: %v", err)
 	}
@@ -725,7 +1162,7 @@ func TestLocalReportStore_CheckUpdates_MutatorVersionDiff_ReturnsSource(t *testi
 	}
 
 	current := []m.Source{{Origin: &m.File{FullPath: m.Path("/abs/a.go"), Hash: "same"}}}
-	changed, err := rs.CheckUpdates(context.Background(), m.Path(dir), current)
+	changed, err := rs.CheckUpdates(context.Background(), m.Path(dir), current, m.RunScope{})
 	if err != nil {
 		t.Fatalf("CheckUpdates returned error: %v", err)
 	}
@@ -733,3 +1170,51 @@ func TestLocalReportStore_CheckUpdates_MutatorVersionDiff_ReturnsSource(t *testi
 		t.Fatalf("expected 1 changed source due to mutator version diff, got %d", len(changed))
 	}
 }
+
+func TestLocalReportStore_CheckUpdates_WithMutationTypes_UsesConfiguredVersions(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	tuned := m.MutationType{Name: m.MutationNumbers.Name, Version: 1_000_042}
+
+	src := m.Source{Origin: &m.File{FullPath: m.Path("/abs/a.go"), Hash: "same"}}
+	report := m.Report{
+		Source: src,
+		Result: m.Result{m.MutationNumbers: {{MutationID: "m1", Status: m.Killed, Err: nil}}},
+	}
+
+	if err := (&LocalReportStore{}).SaveReports(context.Background(), m.Path(dir), []m.Report{report}); err != nil {
+		t.Fatalf("SaveReports returned error: %v", err)
+	}
+
+	current := []m.Source{{Origin: &m.File{FullPath: m.Path("/abs/a.go"), Hash: "same"}}}
+
+	changed, err := NewReportStore().CheckUpdates(context.Background(), m.Path(dir), current, m.RunScope{})
+	if err != nil {
+		t.Fatalf("CheckUpdates returned error: %v", err)
+	}
+	if len(changed) != 0 {
+		t.Fatalf("expected no changed sources with default versions, got %d", len(changed))
+	}
+
+	changed, err = NewReportStore(WithMutationTypes(tuned)).CheckUpdates(context.Background(), m.Path(dir), current, m.RunScope{})
+	if err != nil {
+		t.Fatalf("CheckUpdates returned error: %v", err)
+	}
+	if len(changed) != 1 {
+		t.Fatalf("expected 1 changed source after operator parameters changed, got %d", len(changed))
+	}
+}
+
+func TestScoreInterval_OnlyWhenMutantsWereSkipped(t *testing.T) {
+	t.Parallel()
+
+	if got := scoreInterval(indexEntry{TotalMutations: 2, KilledMutations: 1}); got != nil {
+		t.Fatalf("expected no score_interval without skipped mutants, got %+v", got)
+	}
+
+	got := scoreInterval(indexEntry{TotalMutations: 100, KilledMutations: 8, IgnoredMutations: 90})
+	if got == nil || got.Low >= 0.8 || got.High <= 0.8 {
+		t.Fatalf("expected a score_interval around 80%%, got %+v", got)
+	}
+}
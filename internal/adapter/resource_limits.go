@@ -0,0 +1,95 @@
+package adapter
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// ErrCPULimit reports a test run stopped by its CPU time limit.
+var ErrCPULimit = errors.New("test run exceeded its CPU time limit")
+
+// ErrResourceLimit reports a test run that ran out of memory or file
+// descriptors under its limits.
+var ErrResourceLimit = errors.New("test run exceeded its memory or open file limit")
+
+// processWaitDelay bounds how long a canceled test run may keep its output
+// pipes open after its process group was killed.
+const processWaitDelay = 5 * time.Second
+
+// ResourceLimits caps the resources of every process of a mutant's test run:
+// the go command, the compiler and the test binary. Zero means no limit.
+// Limits are only enforced on Unix systems.
+type ResourceLimits struct {
+	// MemoryMB is the data segment size of each process, in MiB, which bounds
+	// the Go heap.
+	MemoryMB int
+	// CPUSeconds is the CPU time of each process, in seconds.
+	CPUSeconds int
+	// OpenFiles is the number of file descriptors each process may hold.
+	OpenFiles int
+}
+
+// TestRunnerOption configures a LocalTestRunnerAdapter.
+type TestRunnerOption func(*LocalTestRunnerAdapter)
+
+// WithResourceLimits sets the limits mutant test runs execute under.
+func WithResourceLimits(limits ResourceLimits) TestRunnerOption {
+	return func(a *LocalTestRunnerAdapter) {
+		a.limits = limits
+	}
+}
+
+// ulimitScript returns the shell commands applying the limits, or "" when
+// there are none.
+func (l ResourceLimits) ulimitScript() string {
+	var script strings.Builder
+
+	if l.MemoryMB > 0 {
+		fmt.Fprintf(&script, "ulimit -d %d || exit 125; ", l.MemoryMB*1024)
+	}
+
+	if l.CPUSeconds > 0 {
+		fmt.Fprintf(&script, "ulimit -t %d || exit 125; ", l.CPUSeconds)
+	}
+
+	if l.OpenFiles > 0 {
+		fmt.Fprintf(&script, "ulimit -n %d || exit 125; ", l.OpenFiles)
+	}
+
+	return script.String()
+}
+
+// classify wraps the error of a failed test run in ErrCPULimit or
+// ErrResourceLimit when its output shows it hit one of the limits.
+func (l ResourceLimits) classify(output string, err error) error {
+	switch {
+	case l.CPUSeconds > 0 && (strings.Contains(output, "CPU time limit exceeded") || strings.Contains(output, "SIGXCPU")):
+		return fmt.Errorf("%w: %w", ErrCPULimit, err)
+	case l.CPUSeconds > 0 && strings.Contains(output, "signal: killed") && l.usedCPUTime(err):
+		return fmt.Errorf("%w: %w", ErrCPULimit, err)
+	case l.MemoryMB > 0 && (strings.Contains(output, "runtime: out of memory") || strings.Contains(output, "cannot allocate memory")):
+		return fmt.Errorf("%w: %w", ErrResourceLimit, err)
+	case l.OpenFiles > 0 && strings.Contains(output, "too many open files"):
+		return fmt.Errorf("%w: %w", ErrResourceLimit, err)
+	default:
+		return err
+	}
+}
+
+// usedCPUTime reports whether the run that failed with err spent its CPU time
+// limit. A process that reaches it is killed like one the kernel ran out of
+// memory for, so only its CPU time tells them apart. It counts the go command
+// and the processes it waited for, which bounds the test binary's.
+func (l ResourceLimits) usedCPUTime(err error) bool {
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) || exitErr.ProcessState == nil {
+		return false
+	}
+
+	used := exitErr.ProcessState.UserTime() + exitErr.ProcessState.SystemTime()
+
+	return used >= time.Duration(l.CPUSeconds)*time.Second
+}
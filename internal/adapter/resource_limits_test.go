@@ -0,0 +1,44 @@
+package adapter
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceLimits_UlimitScript(t *testing.T) {
+	assert.Empty(t, ResourceLimits{}.ulimitScript())
+	assert.Equal(t,
+		"ulimit -d 2097152 || exit 125; ulimit -t 60 || exit 125; ulimit -n 256 || exit 125; ",
+		ResourceLimits{MemoryMB: 2048, CPUSeconds: 60, OpenFiles: 256}.ulimitScript())
+	assert.Equal(t, "ulimit -n 64 || exit 125; ", ResourceLimits{OpenFiles: 64}.ulimitScript())
+}
+
+func TestResourceLimits_Classify(t *testing.T) {
+	exit := errors.New("exit status 1")
+	limits := ResourceLimits{MemoryMB: 512, CPUSeconds: 10, OpenFiles: 64}
+
+	tests := []struct {
+		name   string
+		limits ResourceLimits
+		output string
+		want   error
+	}{
+		{name: "cpu", limits: limits, output: "signal: CPU time limit exceeded\nFAIL\tpkg\t10.1s\n", want: ErrCPULimit},
+		{name: "killed without cpu time", limits: limits, output: "signal: killed\nFAIL\tpkg\t0.5s\n", want: exit},
+		{name: "memory", limits: limits, output: "fatal error: runtime: out of memory\n", want: ErrResourceLimit},
+		{name: "files", limits: limits, output: "open x: too many open files\n", want: ErrResourceLimit},
+		{name: "test failure", limits: limits, output: "--- FAIL: TestA (0.00s)\n", want: exit},
+		{name: "no limits", output: "fatal error: runtime: out of memory\n", want: exit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.limits.classify(tt.output, exit)
+
+			assert.ErrorIs(t, err, tt.want)
+			assert.ErrorIs(t, err, exit)
+		})
+	}
+}
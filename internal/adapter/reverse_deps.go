@@ -0,0 +1,171 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	m "gooze.dev/pkg/gooze/internal/model"
+)
+
+// WithReverseDependencyTests makes sources whose package has no tests of its
+// own covered by the tests of the in-module packages importing it, directly or
+// through up to depth levels of importers. At most budget test packages are
+// added, nearest importers first; a budget of 0 means no limit. A depth of 0
+// disables the lookup.
+func WithReverseDependencyTests(depth, budget int) SourceFSOption {
+	return func(a *LocalSourceFSAdapter) {
+		a.reverseDepth = depth
+		a.reverseBudget = budget
+	}
+}
+
+// reverseDependencyTests returns the test files of the packages importing the
+// source's package, at most reverseBudget packages of them.
+func (a *LocalSourceFSAdapter) reverseDependencyTests(ctx context.Context, sourcePath m.Path) ([]string, error) {
+	dirs, err := a.reverseDependencyDirs(ctx, sourcePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+
+	packages := 0
+
+	for _, dir := range dirs {
+		if a.reverseBudget > 0 && packages >= a.reverseBudget {
+			break
+		}
+
+		tests, err := a.testFilesIn(dir, false)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(tests) > 0 {
+			packages++
+			files = append(files, tests...)
+		}
+	}
+
+	return files, nil
+}
+
+// importGraph is the in-module import graph of one module, resolved once with
+// `go list -deps` and shared by every source of the module.
+type importGraph struct {
+	// packages maps a package directory to its import path.
+	packages map[string]string
+	// dirs maps an import path to its package directory.
+	dirs map[string]string
+	// importers maps an import path to the in-module packages importing it,
+	// from their code or from their tests.
+	importers map[string][]string
+}
+
+// reverseDependencyDirs returns the directories of the packages importing the
+// source's package, breadth first up to the configured depth, nearest first.
+func (a *LocalSourceFSAdapter) reverseDependencyDirs(ctx context.Context, sourcePath m.Path) ([]string, error) {
+	if a.reverseDepth <= 0 {
+		return nil, nil
+	}
+
+	root, err := a.FindProjectRoot(ctx, sourcePath)
+	if err != nil {
+		return nil, nil
+	}
+
+	graph, err := a.importGraph(ctx, string(root))
+	if err != nil {
+		return nil, err
+	}
+
+	start, ok := graph.packages[filepath.Dir(string(sourcePath))]
+	if !ok {
+		return nil, nil
+	}
+
+	var dirs []string
+
+	visited := map[string]bool{start: true}
+	layer := []string{start}
+
+	for depth := 0; depth < a.reverseDepth && len(layer) > 0; depth++ {
+		var next []string
+
+		for _, pkg := range layer {
+			for _, importer := range graph.importers[pkg] {
+				if !visited[importer] {
+					visited[importer] = true
+					next = append(next, importer)
+				}
+			}
+		}
+
+		sort.Strings(next)
+
+		for _, pkg := range next {
+			dirs = append(dirs, graph.dirs[pkg])
+		}
+
+		layer = next
+	}
+
+	return dirs, nil
+}
+
+// importGraph returns the import graph of the module rooted at root, listing it
+// on first use.
+func (a *LocalSourceFSAdapter) importGraph(ctx context.Context, root string) (*importGraph, error) {
+	a.mu.Lock()
+	graph, ok := a.graphs[root]
+	a.mu.Unlock()
+
+	if ok {
+		return graph, nil
+	}
+
+	packages, err := a.runGoList(ctx, root, []string{"-deps", "-json=" + importFields}, "./...")
+	if err != nil {
+		return nil, fmt.Errorf("resolve reverse dependencies: %w", err)
+	}
+
+	graph = &importGraph{packages: map[string]string{}, dirs: map[string]string{}, importers: map[string][]string{}}
+
+	for _, pkg := range packages {
+		if !pkg.inMainModule() {
+			continue
+		}
+
+		graph.packages[pkg.Dir] = pkg.ImportPath
+		graph.dirs[pkg.ImportPath] = pkg.Dir
+	}
+
+	for _, pkg := range packages {
+		if !pkg.inMainModule() {
+			continue
+		}
+
+		seen := map[string]bool{}
+
+		for _, imports := range [][]string{pkg.Imports, pkg.TestImports, pkg.XTestImports} {
+			for _, imported := range imports {
+				if _, ok := graph.dirs[imported]; ok && imported != pkg.ImportPath && !seen[imported] {
+					seen[imported] = true
+					graph.importers[imported] = append(graph.importers[imported], pkg.ImportPath)
+				}
+			}
+		}
+	}
+
+	a.mu.Lock()
+	if a.graphs == nil {
+		a.graphs = map[string]*importGraph{}
+	}
+
+	a.graphs[root] = graph
+	a.mu.Unlock()
+
+	return graph, nil
+}
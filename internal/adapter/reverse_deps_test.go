@@ -0,0 +1,69 @@
+package adapter
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	m "gooze.dev/pkg/gooze/internal/model"
+)
+
+func TestLocalSourceFSAdapter_DetectTestFiles_ReverseDependencies(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, filepath.Join(root, "go.mod"), "module example.com/project\n\ngo 1.21\n")
+
+	for _, dir := range []string{"helper", "mid", "top", "direct", "tested"} {
+		mustMkdir(t, filepath.Join(root, dir))
+	}
+
+	// helper <- mid <- top (tested), helper <- direct (tested), and tested has
+	// tests of its own.
+	helperSrc := filepath.Join(root, "helper", "helper.go")
+	writeTestFile(t, helperSrc, "package helper\n\nfunc Double(n int) int { return n * 2 }\n")
+	writeTestFile(t, filepath.Join(root, "mid", "mid.go"), "package mid\n\nimport \"example.com/project/helper\"\n\nfunc Quad(n int) int { return helper.Double(helper.Double(n)) }\n")
+	writeTestFile(t, filepath.Join(root, "top", "top.go"), "package top\n")
+	topTest := filepath.Join(root, "top", "top_test.go")
+	writeTestFile(t, topTest, "package top\n\nimport (\n\t\"testing\"\n\n\t\"example.com/project/mid\"\n)\n\nfunc TestQuad(t *testing.T) { _ = mid.Quad(1) }\n")
+	writeTestFile(t, filepath.Join(root, "direct", "direct.go"), "package direct\n")
+	directTest := filepath.Join(root, "direct", "direct_test.go")
+	writeTestFile(t, directTest, "package direct_test\n\nimport (\n\t\"testing\"\n\n\t\"example.com/project/helper\"\n)\n\nfunc TestDouble(t *testing.T) { _ = helper.Double(1) }\n")
+	testedSrc := filepath.Join(root, "tested", "tested.go")
+	testedTest := filepath.Join(root, "tested", "tested_test.go")
+	writeTestFile(t, testedSrc, "package tested\n\nimport \"example.com/project/helper\"\n\nvar _ = helper.Double\n")
+	writeTestFile(t, testedTest, "package tested\n")
+
+	detect := func(t *testing.T, adapter *LocalSourceFSAdapter, source string) []m.Path {
+		t.Helper()
+
+		got, err := adapter.DetectTestFiles(context.Background(), m.Path(source))
+		require.NoError(t, err)
+
+		return got
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		assert.Empty(t, detect(t, NewLocalSourceFSAdapter(), helperSrc))
+	})
+
+	t.Run("direct importers", func(t *testing.T) {
+		got := detect(t, NewLocalSourceFSAdapter(WithReverseDependencyTests(1, 0)), helperSrc)
+		assert.Equal(t, []m.Path{m.Path(directTest), m.Path(testedTest)}, got)
+	})
+
+	t.Run("transitive importers up to the depth", func(t *testing.T) {
+		got := detect(t, NewLocalSourceFSAdapter(WithReverseDependencyTests(2, 0)), helperSrc)
+		assert.Equal(t, []m.Path{m.Path(directTest), m.Path(testedTest), m.Path(topTest)}, got)
+	})
+
+	t.Run("budget keeps the nearest tested packages", func(t *testing.T) {
+		got := detect(t, NewLocalSourceFSAdapter(WithReverseDependencyTests(2, 1)), helperSrc)
+		assert.Equal(t, []m.Path{m.Path(directTest)}, got)
+	})
+
+	t.Run("packages with tests keep their own", func(t *testing.T) {
+		got := detect(t, NewLocalSourceFSAdapter(WithReverseDependencyTests(2, 0)), testedSrc)
+		assert.Equal(t, []m.Path{m.Path(testedTest)}, got)
+	})
+}
@@ -0,0 +1,108 @@
+package adapter
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Names of the rules that skip files during source discovery, as reported by
+// SkippedFiles.
+const (
+	SkipGenerated = "generated"
+	SkipVendor    = "vendor"
+	SkipTestdata  = "testdata"
+	SkipCgo       = "cgo"
+	SkipIgnored   = "ignored"
+)
+
+// SkipRules toggles the files source discovery skips automatically.
+type SkipRules struct {
+	// Generated skips files with a "// Code generated ... DO NOT EDIT." header.
+	Generated bool
+	// Vendor skips vendor/ directories.
+	Vendor bool
+	// Testdata skips testdata/ directories.
+	Testdata bool
+	// Cgo skips files that import "C".
+	Cgo bool
+	// Ignored skips paths matched by .gitignore or .goozeignore files.
+	Ignored bool
+}
+
+// DefaultSkipRules enables every skip rule.
+func DefaultSkipRules() SkipRules {
+	return SkipRules{Generated: true, Vendor: true, Testdata: true, Cgo: true, Ignored: true}
+}
+
+// WithSkipRules sets the files source discovery skips automatically.
+func WithSkipRules(rules SkipRules) SourceFSOption {
+	return func(a *LocalSourceFSAdapter) {
+		a.skipRules = rules
+	}
+}
+
+// skipDir returns the rule that skips the directory, if any.
+func (r SkipRules) skipDir(name string) string {
+	switch {
+	case r.Vendor && name == "vendor":
+		return SkipVendor
+	case r.Testdata && name == "testdata":
+		return SkipTestdata
+	default:
+		return ""
+	}
+}
+
+// skipPath returns the rule that skips a file because of a directory on its
+// path, if any. Only directories below root are considered.
+func (r SkipRules) skipPath(root, path string) string {
+	rel, err := filepath.Rel(root, filepath.Dir(path))
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return ""
+	}
+
+	for _, name := range strings.Split(filepath.ToSlash(rel), "/") {
+		if rule := r.skipDir(name); rule != "" {
+			return rule
+		}
+	}
+
+	return ""
+}
+
+// skipContent returns the rule that skips a file because of its content, if
+// any. Only the header up to the imports is parsed.
+func (r SkipRules) skipContent(path string, src []byte) string {
+	if !r.Generated && !r.Cgo {
+		return ""
+	}
+
+	file, err := parser.ParseFile(token.NewFileSet(), path, src, parser.ImportsOnly|parser.ParseComments)
+	if err != nil {
+		return ""
+	}
+
+	if r.Generated && ast.IsGenerated(file) {
+		return SkipGenerated
+	}
+
+	if r.Cgo && importsC(file) {
+		return SkipCgo
+	}
+
+	return ""
+}
+
+func importsC(file *ast.File) bool {
+	for _, spec := range file.Imports {
+		if path, err := strconv.Unquote(spec.Path.Value); err == nil && path == "C" {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,120 @@
+package adapter
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	m "gooze.dev/pkg/gooze/internal/model"
+)
+
+func TestSkipRules_SkipContent(t *testing.T) {
+	rules := DefaultSkipRules()
+
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{"generated header", "// Code generated by stringer. DO NOT EDIT.\n\npackage p\n", SkipGenerated},
+		{"cgo import", "package p\n\n// #include <stdio.h>\nimport \"C\"\n", SkipCgo},
+		{"plain file", "package p\n\nimport \"fmt\"\n\nvar _ = fmt.Sprint\n", ""},
+		{"generated comment after package clause", "package p\n\n// Code generated by hand. DO NOT EDIT.\n", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, rules.skipContent("p.go", []byte(tt.src)))
+		})
+	}
+
+	assert.Empty(t, SkipRules{}.skipContent("p.go", []byte(tests[0].src)), "disabled rules skip nothing")
+}
+
+func TestSkipRules_SkipPath(t *testing.T) {
+	rules := DefaultSkipRules()
+	root := filepath.FromSlash("/repo")
+
+	assert.Equal(t, SkipVendor, rules.skipPath(root, filepath.FromSlash("/repo/vendor/x/x.go")))
+	assert.Equal(t, SkipTestdata, rules.skipPath(root, filepath.FromSlash("/repo/pkg/testdata/x.go")))
+	assert.Empty(t, rules.skipPath(root, filepath.FromSlash("/repo/pkg/x.go")))
+	assert.Empty(t, rules.skipPath(filepath.FromSlash("/repo/vendor/x"), filepath.FromSlash("/repo/vendor/x/x.go")), "directories above the root are not checked")
+	assert.Empty(t, SkipRules{}.skipPath(root, filepath.FromSlash("/repo/vendor/x/x.go")))
+}
+
+func TestLocalSourceFSAdapter_Get_SkipRules(t *testing.T) {
+	newModule := func(t *testing.T) (root string) {
+		t.Helper()
+
+		root = t.TempDir()
+		writeTestFile(t, filepath.Join(root, "go.mod"), "module example.com/project\n\ngo 1.21\n")
+		writeTestFile(t, filepath.Join(root, "main.go"), "package main\n\nfunc main() {}\n")
+		writeTestFile(t, filepath.Join(root, "zz_generated.go"), "// Code generated by gen. DO NOT EDIT.\n\npackage main\n\nfunc gen() {}\n")
+
+		mustMkdir(t, filepath.Join(root, "vendor"))
+		mustMkdir(t, filepath.Join(root, "vendor", "dep"))
+		writeTestFile(t, filepath.Join(root, "vendor", "dep", "dep.go"), "package dep\n\nfunc Dep() {}\n")
+
+		mustMkdir(t, filepath.Join(root, "pkg"))
+		mustMkdir(t, filepath.Join(root, "pkg", "testdata"))
+		writeTestFile(t, filepath.Join(root, "pkg", "pkg.go"), "package pkg\n\nfunc Pkg() {}\n")
+		writeTestFile(t, filepath.Join(root, "pkg", "testdata", "fixture.go"), "package fixture\n\nfunc Fixture() {}\n")
+
+		mustMkdir(t, filepath.Join(root, "build"))
+		writeTestFile(t, filepath.Join(root, "build", "out.go"), "package build\n\nfunc Out() {}\n")
+		writeTestFile(t, filepath.Join(root, "pkg", "keep_tmp.go"), "package pkg\n\nfunc Keep() {}\n")
+		writeTestFile(t, filepath.Join(root, "pkg", "drop_tmp.go"), "package pkg\n\nfunc Drop() {}\n")
+		writeTestFile(t, filepath.Join(root, ".gitignore"), "build/\n*_tmp.go\n")
+		writeTestFile(t, filepath.Join(root, "pkg", ".goozeignore"), "!keep_tmp.go\n")
+
+		t.Chdir(root)
+
+		return root
+	}
+
+	t.Run("default rules skip and count files", func(t *testing.T) {
+		root := newModule(t)
+		fsAdapter := NewLocalSourceFSAdapter()
+
+		sources, err := fsAdapter.Get(context.Background(), []m.Path{"./..."})
+		require.NoError(t, err)
+
+		require.Len(t, sources, 3)
+		assert.NotNil(t, findSourceV2ByOrigin(sources, filepath.Join(root, "main.go")))
+		assert.NotNil(t, findSourceV2ByOrigin(sources, filepath.Join(root, "pkg", "pkg.go")))
+		assert.NotNil(t, findSourceV2ByOrigin(sources, filepath.Join(root, "pkg", "keep_tmp.go")), "negated patterns re-include files")
+
+		assert.Equal(t, map[string]int{
+			SkipGenerated: 1,
+			SkipVendor:    1,
+			SkipTestdata:  1,
+			SkipIgnored:   2,
+		}, fsAdapter.SkippedFiles(context.Background()))
+	})
+
+	t.Run("disabled rules keep files", func(t *testing.T) {
+		root := newModule(t)
+		fsAdapter := NewLocalSourceFSAdapter(WithSkipRules(SkipRules{Vendor: true, Testdata: true}))
+
+		sources, err := fsAdapter.Get(context.Background(), []m.Path{"./..."})
+		require.NoError(t, err)
+
+		assert.NotNil(t, findSourceV2ByOrigin(sources, filepath.Join(root, "zz_generated.go")))
+		assert.NotNil(t, findSourceV2ByOrigin(sources, filepath.Join(root, "build", "out.go")))
+		assert.Nil(t, findSourceV2ByOrigin(sources, filepath.Join(root, "vendor", "dep", "dep.go")))
+		assert.Equal(t, map[string]int{SkipVendor: 1, SkipTestdata: 1}, fsAdapter.SkippedFiles(context.Background()))
+	})
+
+	t.Run("file roots are checked too", func(t *testing.T) {
+		root := newModule(t)
+		fsAdapter := NewLocalSourceFSAdapter()
+
+		sources, err := fsAdapter.Get(context.Background(), []m.Path{m.Path(filepath.Join(root, "zz_generated.go"))})
+		require.NoError(t, err)
+
+		assert.Empty(t, sources)
+		assert.Equal(t, map[string]int{SkipGenerated: 1}, fsAdapter.SkippedFiles(context.Background()))
+	})
+}
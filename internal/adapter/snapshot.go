@@ -0,0 +1,217 @@
+package adapter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// SnapshotSettings limits the workspace copies CopyModule makes to the files
+// the module's builds and tests read, instead of its whole directory tree.
+type SnapshotSettings struct {
+	// Include lists extra files the tests read at runtime, as glob patterns
+	// relative to the module root. A matched directory is included whole.
+	Include []string
+	// Hardlinks links the files gooze never writes, such as testdata and
+	// embedded files, into the snapshot instead of copying them, when the
+	// filesystem allows it. Go sources and module files are always copied.
+	Hardlinks bool
+}
+
+// snapshotFields selects the fields of `go list -deps -test` that name the
+// files of a package's build and tests.
+const snapshotFields = "Dir,GoFiles,CgoFiles,CFiles,CXXFiles,MFiles,HFiles,FFiles,SFiles,SwigFiles,SwigCXXFiles,SysoFiles," +
+	"EmbedFiles,TestGoFiles,XTestGoFiles,TestEmbedFiles,XTestEmbedFiles"
+
+// moduleFiles are the files at a module root the go command reads, or may
+// rewrite, besides the packages' own.
+var moduleFiles = []string{"go.mod", "go.sum", filepath.Join("vendor", "modules.txt")}
+
+// WithSnapshots makes CopyModule copy only the files the module's builds and
+// tests need, as reported by `go list -deps -test`, plus the testdata
+// directories of its packages and the settings' extra files.
+func WithSnapshots(settings SnapshotSettings) SourceFSOption {
+	return func(a *LocalSourceFSAdapter) {
+		a.snapshot = &settings
+	}
+}
+
+// snapshotModule copies the files of the modules in dirs that the build and
+// tests of the module in root need into dst, keeping their paths relative to
+// base. When the files cannot be listed, it falls back to copying the modules
+// whole.
+func (a *LocalSourceFSAdapter) snapshotModule(ctx context.Context, root string, dirs []string, base, dst string) error {
+	files, err := a.snapshotFiles(ctx, root, dirs)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		slog.Warn("Failed to list the module's files, copying it whole", "root", root, "error", err)
+
+		return a.copyModuleDirs(ctx, dirs, base, dst)
+	}
+
+	for _, file := range files {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(base, file)
+		if err != nil {
+			return err
+		}
+
+		if err := a.snapshotFile(file, filepath.Join(dst, rel)); err != nil {
+			return fmt.Errorf("snapshot %s: %w", file, err)
+		}
+	}
+
+	return nil
+}
+
+// snapshotFiles returns, in lexical order, the files of the modules in dirs
+// that the build and tests of the module in root read.
+func (a *LocalSourceFSAdapter) snapshotFiles(ctx context.Context, root string, dirs []string) ([]string, error) {
+	packages, err := a.runGoList(ctx, root, []string{"-deps", "-test", "-json=" + snapshotFields}, "./...")
+	if err != nil {
+		return nil, err
+	}
+
+	inModules := func(path string) bool {
+		for _, dir := range dirs {
+			if containsDir(dir, path) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	files := map[string]bool{}
+
+	add := func(path string) {
+		if inModules(path) {
+			files[path] = true
+		}
+	}
+
+	for _, pkg := range packages {
+		if pkg.Dir == "" || !inModules(pkg.Dir) {
+			continue
+		}
+
+		for _, names := range pkg.fileLists() {
+			for _, name := range names {
+				if !filepath.IsAbs(name) {
+					name = filepath.Join(pkg.Dir, name)
+				}
+
+				add(name)
+			}
+		}
+
+		if err := walkFiles(filepath.Join(pkg.Dir, "testdata"), add); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, dir := range dirs {
+		for _, name := range moduleFiles {
+			if info, err := os.Stat(filepath.Join(dir, name)); err == nil && info.Mode().IsRegular() {
+				add(filepath.Join(dir, name))
+			}
+		}
+	}
+
+	for _, pattern := range a.snapshot.Include {
+		matches, err := filepath.Glob(filepath.Join(root, filepath.FromSlash(pattern)))
+		if err != nil {
+			return nil, fmt.Errorf("snapshot include %q: %w", pattern, err)
+		}
+
+		for _, match := range matches {
+			if err := walkFiles(match, add); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	sorted := make([]string, 0, len(files))
+	for file := range files {
+		sorted = append(sorted, file)
+	}
+
+	sort.Strings(sorted)
+
+	return sorted, nil
+}
+
+// fileLists returns every file list of the package.
+func (p listedPackage) fileLists() [][]string {
+	return [][]string{
+		p.GoFiles, p.CgoFiles, p.CFiles, p.CXXFiles, p.MFiles, p.HFiles, p.FFiles, p.SFiles,
+		p.SwigFiles, p.SwigCXXFiles, p.SysoFiles, p.EmbedFiles,
+		p.TestGoFiles, p.XTestGoFiles, p.TestEmbedFiles, p.XTestEmbedFiles,
+	}
+}
+
+// snapshotFile puts src at dst, hard-linking it when allowed and gooze or the
+// go command never write to it, and copying it otherwise.
+func (a *LocalSourceFSAdapter) snapshotFile(src, dst string) error {
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+
+	if a.snapshot.Hardlinks && !writableInSnapshot(src) {
+		if err := os.MkdirAll(filepath.Dir(dst), 0o750); err != nil {
+			return err
+		}
+
+		if err := os.Link(src, dst); err == nil {
+			return nil
+		}
+	}
+
+	return a.copyFile(src, dst, info.Mode())
+}
+
+// writableInSnapshot reports whether a file of the snapshot may be written to:
+// Go sources, which mutants are written to, and the module files the go
+// command may update.
+func writableInSnapshot(path string) bool {
+	switch filepath.Base(path) {
+	case "go.mod", "go.sum", "go.work", "go.work.sum", "modules.txt":
+		return true
+	}
+
+	return strings.HasSuffix(path, ".go")
+}
+
+// walkFiles calls add with each regular file at or below path. A missing path
+// has no files.
+func walkFiles(path string, add func(string)) error {
+	err := filepath.WalkDir(path, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.Type().IsRegular() {
+			add(file)
+		}
+
+		return nil
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	return err
+}
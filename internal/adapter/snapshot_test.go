@@ -0,0 +1,108 @@
+package adapter
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	m "gooze.dev/pkg/gooze/internal/model"
+)
+
+func TestLocalSourceFSAdapter_CopyModule_Snapshot(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, filepath.Join(root, "go.mod"), "module example.com/project\n\ngo 1.21\n")
+
+	for _, dir := range []string{".git", "node_modules", "bin", "config", "app", "app/static", "app/testdata", "app/testdata/golden"} {
+		mustMkdir(t, filepath.Join(root, dir))
+	}
+
+	// Files no build or test reads.
+	writeTestFile(t, filepath.Join(root, ".git", "HEAD"), "ref: refs/heads/main\n")
+	writeTestFile(t, filepath.Join(root, "node_modules", "index.js"), "module.exports = {}\n")
+	writeTestFile(t, filepath.Join(root, "bin", "app"), "binary")
+	writeTestFile(t, filepath.Join(root, "config", "app.yaml"), "name: app\n")
+	writeTestFile(t, filepath.Join(root, "app", "other_windows.go"), "package app\n")
+
+	writeTestFile(t, filepath.Join(root, "app", "app.go"), "package app\n\nimport _ \"embed\"\n\n//go:embed static/name.txt\nvar Name string\n")
+	writeTestFile(t, filepath.Join(root, "app", "static", "name.txt"), "gooze")
+	writeTestFile(t, filepath.Join(root, "app", "testdata", "golden", "name.golden"), "gooze")
+	writeTestFile(t, filepath.Join(root, "app", "app_test.go"), "package app\n\nimport (\n\t\"os\"\n\t\"testing\"\n)\n\n"+
+		"func TestName(t *testing.T) {\n\twant, err := os.ReadFile(\"testdata/golden/name.golden\")\n\tif err != nil || string(want) != Name {\n\t\tt.Fatalf(\"got %q, want %q (%v)\", Name, want, err)\n\t}\n}\n")
+
+	snapshot := func(t *testing.T, settings SnapshotSettings) string {
+		t.Helper()
+
+		dst := t.TempDir()
+
+		moduleDir, err := NewLocalSourceFSAdapter(WithSnapshots(settings)).CopyModule(context.Background(), m.Path(root), m.Path(dst))
+		require.NoError(t, err)
+		require.Equal(t, m.Path(dst), moduleDir)
+
+		return dst
+	}
+
+	sameFile := func(t *testing.T, a, b string) bool {
+		t.Helper()
+
+		infoA, err := os.Stat(a)
+		require.NoError(t, err)
+
+		infoB, err := os.Stat(b)
+		require.NoError(t, err)
+
+		return os.SameFile(infoA, infoB)
+	}
+
+	t.Run("copies only what builds and tests need", func(t *testing.T) {
+		dst := snapshot(t, SnapshotSettings{Hardlinks: true})
+
+		for _, name := range []string{"go.mod", "app/app.go", "app/app_test.go", "app/static/name.txt", "app/testdata/golden/name.golden"} {
+			assert.FileExists(t, filepath.Join(dst, name))
+		}
+
+		for _, name := range []string{".git", "node_modules", "bin", "config"} {
+			assert.NoDirExists(t, filepath.Join(dst, name))
+		}
+
+		assert.NoFileExists(t, filepath.Join(dst, "app", "other_windows.go"))
+
+		output, err := NewLocalTestRunnerAdapter().RunGoTest(context.Background(), dst, "./...", "", "")
+		require.NoError(t, err, output)
+	})
+
+	t.Run("links unmodified files and copies sources", func(t *testing.T) {
+		dst := snapshot(t, SnapshotSettings{Hardlinks: true})
+
+		assert.True(t, sameFile(t, filepath.Join(root, "app", "testdata", "golden", "name.golden"), filepath.Join(dst, "app", "testdata", "golden", "name.golden")))
+		assert.True(t, sameFile(t, filepath.Join(root, "app", "static", "name.txt"), filepath.Join(dst, "app", "static", "name.txt")))
+		assert.False(t, sameFile(t, filepath.Join(root, "app", "app.go"), filepath.Join(dst, "app", "app.go")))
+		assert.False(t, sameFile(t, filepath.Join(root, "go.mod"), filepath.Join(dst, "go.mod")))
+	})
+
+	t.Run("copies every file without hardlinks", func(t *testing.T) {
+		dst := snapshot(t, SnapshotSettings{})
+
+		assert.False(t, sameFile(t, filepath.Join(root, "app", "testdata", "golden", "name.golden"), filepath.Join(dst, "app", "testdata", "golden", "name.golden")))
+	})
+
+	t.Run("includes extra runtime files", func(t *testing.T) {
+		dst := snapshot(t, SnapshotSettings{Include: []string{"config/*.yaml", "bin"}})
+
+		assert.FileExists(t, filepath.Join(dst, "config", "app.yaml"))
+		assert.FileExists(t, filepath.Join(dst, "bin", "app"))
+		assert.NoDirExists(t, filepath.Join(dst, ".git"))
+	})
+}
+
+func TestWritableInSnapshot(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, writableInSnapshot("/work/app/app.go"))
+	assert.True(t, writableInSnapshot("/work/go.sum"))
+	assert.True(t, writableInSnapshot("/work/vendor/modules.txt"))
+	assert.False(t, writableInSnapshot("/work/app/testdata/input.json"))
+	assert.False(t, writableInSnapshot("/work/app/static/go.txt"))
+}
@@ -10,10 +10,12 @@ import (
 	"go/parser"
 	"go/token"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
+	"sync"
 
 	m "gooze.dev/pkg/gooze/internal/model"
 )
@@ -43,9 +45,10 @@ type SourceFSAdapter interface {
 	// HashFile returns a stable fingerprint (e.g. SHA-256) for the file at path.
 	HashFile(ctx context.Context, path m.Path) (string, error)
 
-	// DetectTestFile attempts to find a Go test file that matches the provided
-	// source file. This allows the domain to auto-link source/test pairs.
-	DetectTestFile(ctx context.Context, sourcePath m.Path) (m.Path, error)
+	// DetectTestFiles finds the Go test files that cover the provided source
+	// file: those of its package and of any package mapped to it. This allows
+	// the domain to auto-link sources to their tests.
+	DetectTestFiles(ctx context.Context, sourcePath m.Path) ([]m.Path, error)
 
 	// FileInfo returns metadata for a path so the domain can check existence or
 	// distinguish between files and directories when necessary.
@@ -57,12 +60,26 @@ type SourceFSAdapter interface {
 	// CreateTempDir creates a temporary directory for mutation testing.
 	CreateTempDir(ctx context.Context, pattern string) (m.Path, error)
 
+	// CreateWorkspaceDir creates an empty directory for a workspace at a path
+	// that stays the same from one run to the next, in the lowest slot under
+	// key no other workspace holds.
+	CreateWorkspaceDir(ctx context.Context, key string) (m.Path, error)
+
 	// RemoveAll removes a directory and all its contents.
 	RemoveAll(ctx context.Context, path m.Path) error
 
+	// RemoveWorkspaceDir removes a directory CreateWorkspaceDir created and
+	// releases its slot.
+	RemoveWorkspaceDir(ctx context.Context, dir m.Path) error
+
 	// CopyDir recursively copies a directory tree.
 	CopyDir(ctx context.Context, src, dst m.Path) error
 
+	// CopyModule copies the module rooted at root into dst together with the
+	// other modules its build reads from disk (go.work modules and local
+	// replacements), and returns the module's directory in dst.
+	CopyModule(ctx context.Context, root, dst m.Path) (m.Path, error)
+
 	// WriteFile writes content to a file with the given permissions.
 	WriteFile(ctx context.Context, path m.Path, content []byte, perm os.FileMode) error
 	// RelPath returns the relative path from base to target.
@@ -70,6 +87,10 @@ type SourceFSAdapter interface {
 
 	// JoinPath joins path elements into a single path.
 	JoinPath(ctx context.Context, elem ...string) m.Path
+
+	// SkippedFiles returns, per skip rule, how many files the last scan left
+	// out (generated, vendored, ignored, ...).
+	SkippedFiles(ctx context.Context) map[string]int
 }
 
 // FilepathWalkFunc mirrors the callback shape used by filepath.WalkDir. It is
@@ -77,15 +98,51 @@ type SourceFSAdapter interface {
 // domain layer.
 type FilepathWalkFunc func(path string, info os.FileInfo, err error) error
 
-// LocalSourceFSAdapter is the concrete implementation that will back the
-// SourceFSAdapter interface. It currently returns ErrNotImplemented so tests
-// can drive the actual logic.
-type LocalSourceFSAdapter struct{}
+// LocalSourceFSAdapter is the concrete implementation that backs the
+// SourceFSAdapter interface on the local filesystem and go toolchain.
+type LocalSourceFSAdapter struct {
+	buildTags     []string
+	buildFlags    []string
+	buildEnv      []string
+	testConfig    string
+	skipRules     SkipRules
+	testMappings  []TestMapping
+	reverseDepth  int
+	reverseBudget int
+	// snapshot limits module copies to the files builds and tests need; nil
+	// copies modules whole.
+	snapshot *SnapshotSettings
+
+	mu      sync.Mutex
+	skipped map[string]int
+	ignores map[string]*ignoreMatcher
+	graphs  map[string]*importGraph
+	// modulePaths caches the module path of each module root.
+	modulePaths map[m.Path]string
+	// workspaceLocks holds the lock of each workspace directory created.
+	workspaceLocks map[string]*os.File
+}
+
+// SourceFSOption configures a LocalSourceFSAdapter.
+type SourceFSOption func(*LocalSourceFSAdapter)
+
+// WithBuildTags sets the build tags source discovery evaluates build
+// constraints with, like `go build -tags`.
+func WithBuildTags(tags ...string) SourceFSOption {
+	return func(a *LocalSourceFSAdapter) {
+		a.buildTags = tags
+	}
+}
 
 // NewLocalSourceFSAdapter constructs a LocalSourceFSAdapter instance ready to
 // be wired into the workflow.
-func NewLocalSourceFSAdapter() *LocalSourceFSAdapter {
-	return &LocalSourceFSAdapter{}
+func NewLocalSourceFSAdapter(opts ...SourceFSOption) *LocalSourceFSAdapter {
+	a := &LocalSourceFSAdapter{skipRules: DefaultSkipRules()}
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	return a
 }
 
 // Get collects Go source files for the provided roots and returns SourceV2 entries.
@@ -144,6 +201,8 @@ func (a *LocalSourceFSAdapter) eachSource(ctx context.Context, roots []m.Path, i
 		return err
 	}
 
+	a.resetScan()
+
 	emitUnique := dedupEmit(make(map[string]struct{}), emit)
 
 	for _, root := range roots {
@@ -155,6 +214,10 @@ func (a *LocalSourceFSAdapter) eachSource(ctx context.Context, roots []m.Path, i
 	return nil
 }
 
+// collectSourcesFromRoot emits the sources of one root: a file, a directory
+// (optionally with a "/..." suffix), or a package pattern such as an import
+// path or "all". Directories inside a module and package patterns are resolved
+// through go list, so only files that are part of the build are emitted.
 func (a *LocalSourceFSAdapter) collectSourcesFromRoot(ctx context.Context, root m.Path, ignoreRegexps []*regexp.Regexp, emit func(m.Source) error) error {
 	rootPath, recursive, err := normalizeRootPath(string(root))
 	if err != nil {
@@ -162,20 +225,74 @@ func (a *LocalSourceFSAdapter) collectSourcesFromRoot(ctx context.Context, root
 	}
 
 	info, err := a.FileInfo(ctx, m.Path(rootPath))
+	if err != nil {
+		if isPathRoot(string(root)) {
+			return fmt.Errorf("root path error: %w", err)
+		}
+
+		return a.collectSourcesFromPattern(ctx, string(root), ignoreRegexps, emit)
+	}
+
+	if !info.IsDir() {
+		if !a.matchesBuild(rootPath) {
+			return nil
+		}
+
+		return a.emitFileSource(ctx, rootPath, ignoreRegexps, emit)
+	}
+
+	return a.collectSourcesFromDir(ctx, rootPath, recursive, ignoreRegexps, emit)
+}
+
+// collectSourcesFromPattern resolves a package pattern from the working
+// directory. A pattern that names a package go list cannot load is an error.
+func (a *LocalSourceFSAdapter) collectSourcesFromPattern(ctx context.Context, pattern string, ignoreRegexps []*regexp.Regexp, emit func(m.Source) error) error {
+	packages, err := a.goList(ctx, ".", pattern)
 	if err != nil {
 		return fmt.Errorf("root path error: %w", err)
 	}
 
-	if info.IsDir() {
-		return a.collectSourcesFromDir(ctx, rootPath, recursive, ignoreRegexps, emit)
+	for _, pkg := range packages {
+		if pkg.Error != nil && len(pkg.GoFiles)+len(pkg.CgoFiles) == 0 {
+			return fmt.Errorf("root path error: package %s: %s", pkg.ImportPath, pkg.Error.Err)
+		}
+	}
+
+	return a.emitPackageSources(ctx, packages, ignoreRegexps, emit)
+}
+
+func (a *LocalSourceFSAdapter) emitPackageSources(ctx context.Context, packages []listedPackage, ignoreRegexps []*regexp.Regexp, emit func(m.Source) error) error {
+	for _, pkg := range packages {
+		for _, path := range pkg.buildFiles() {
+			if err := a.emitFileSource(ctx, path, ignoreRegexps, emit); err != nil {
+				return err
+			}
+		}
 	}
 
-	return a.emitFileSource(ctx, rootPath, ignoreRegexps, emit)
+	return nil
+}
+
+// isPathRoot reports whether a root can only be a filesystem path, as opposed
+// to a package pattern like "example.com/svc/..." or "all".
+func isPathRoot(root string) bool {
+	return filepath.IsAbs(root) || root == "." || root == ".." || root == "~" ||
+		strings.HasPrefix(root, "./") || strings.HasPrefix(root, "../") || strings.HasPrefix(root, "~/") ||
+		strings.HasPrefix(root, "."+string(filepath.Separator)) || strings.HasPrefix(root, ".."+string(filepath.Separator))
 }
 
 // emitFileSource emits the source for a single file path, skipping files that
-// are not valid mutation sources.
+// are not valid mutation sources or that a skip rule excludes.
 func (a *LocalSourceFSAdapter) emitFileSource(ctx context.Context, path string, ignoreRegexps []*regexp.Regexp, emit func(m.Source) error) error {
+	if !isCandidateSourcePath(path, ignoreRegexps) {
+		return nil
+	}
+
+	if rule := a.skipFile(ctx, path); rule != "" {
+		a.countSkipped(rule, 1)
+		return nil
+	}
+
 	source, ok, err := a.processFilePath(ctx, path, ignoreRegexps)
 	if err != nil {
 		if isInvalidSourceErr(err) {
@@ -245,35 +362,6 @@ func (a *LocalSourceFSAdapter) HashFile(ctx context.Context, path m.Path) (strin
 	return fmt.Sprintf("%x", h.Sum(nil)), nil
 }
 
-// DetectTestFile finds the companion *_test.go file for the provided source path.
-func (a *LocalSourceFSAdapter) DetectTestFile(ctx context.Context, sourcePath m.Path) (m.Path, error) {
-	if err := ctx.Err(); err != nil {
-		return "", err
-	}
-
-	source := string(sourcePath)
-	if filepath.Ext(source) != ".go" {
-		return "", nil
-	}
-
-	if strings.HasSuffix(source, "_test.go") {
-		return "", nil
-	}
-
-	base := strings.TrimSuffix(filepath.Base(source), ".go")
-	testFile := filepath.Join(filepath.Dir(source), base+"_test.go")
-
-	if _, err := os.Stat(testFile); err != nil {
-		if os.IsNotExist(err) {
-			return "", nil
-		}
-
-		return "", err
-	}
-
-	return m.Path(testFile), nil
-}
-
 // FileInfo returns os.FileInfo metadata for the given path.
 func (a *LocalSourceFSAdapter) FileInfo(ctx context.Context, path m.Path) (os.FileInfo, error) {
 	if err := ctx.Err(); err != nil {
@@ -441,31 +529,224 @@ func dedupEmit(seen map[string]struct{}, emit func(m.Source) error) func(m.Sourc
 	}
 }
 
+// collectSourcesFromDir emits the sources of the package directories under
+// rootPath. Inside a module the directories are resolved through go list, so
+// build constraints apply; outside one, each file's constraints are checked.
 func (a *LocalSourceFSAdapter) collectSourcesFromDir(ctx context.Context, rootPath string, recursive bool, ignoreRegexps []*regexp.Regexp, emit func(m.Source) error) error {
-	return a.Walk(ctx, m.Path(rootPath), recursive, func(path string, info os.FileInfo, err error) error {
+	dirs, err := a.sourceDirs(ctx, rootPath, recursive)
+	if err != nil {
+		return err
+	}
+
+	if len(dirs) == 0 {
+		return nil
+	}
+
+	if _, err := a.FindProjectRoot(ctx, m.Path(filepath.Join(rootPath, "go.mod"))); err != nil {
+		return a.emitDirFiles(ctx, dirs, ignoreRegexps, emit)
+	}
+
+	patterns := make([]string, 0, len(dirs))
+	for _, dir := range dirs {
+		rel, err := filepath.Rel(rootPath, dir)
 		if err != nil {
 			return err
 		}
 
-		if info.IsDir() {
+		patterns = append(patterns, "./"+filepath.ToSlash(rel))
+	}
+
+	packages, err := a.goList(ctx, rootPath, patterns...)
+	if err != nil {
+		return fmt.Errorf("root path error: %w", err)
+	}
+
+	// Package errors (files all excluded by constraints, ...) do not stop the
+	// scan of a directory: it simply has nothing to mutate.
+	return a.emitPackageSources(ctx, packages, ignoreRegexps, emit)
+}
+
+// sourceDirs walks rootPath (only its top level unless recursive) and returns
+// the directories holding Go files, in walk order. Like go list ./..., it does
+// not descend into nested modules or directories starting with "." or "_";
+// directories a skip rule excludes are counted and pruned.
+func (a *LocalSourceFSAdapter) sourceDirs(ctx context.Context, rootPath string, recursive bool) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var dirs []string
+
+	seen := map[string]bool{}
+
+	err := filepath.WalkDir(rootPath, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !entry.IsDir() {
+			dir := filepath.Dir(path)
+			if filepath.Ext(path) == ".go" && !seen[dir] {
+				seen[dir] = true
+				dirs = append(dirs, dir)
+			}
+
 			return nil
 		}
 
-		source, ok, err := a.processFilePath(ctx, path, ignoreRegexps)
+		if path == rootPath {
+			return nil
+		}
+
+		if !recursive || isHiddenDir(entry.Name()) || fileExists(filepath.Join(path, "go.mod")) {
+			return filepath.SkipDir
+		}
+
+		rule := a.skipRules.skipDir(entry.Name())
+		if rule == "" && a.skipRules.Ignored && a.ignoreMatcher(path).ignored(path, true) {
+			rule = SkipIgnored
+		}
+
+		if rule != "" {
+			a.countSkipped(rule, countSourceFiles(path))
+			return filepath.SkipDir
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dirs, nil
+}
+
+// emitDirFiles emits the Go files of each directory whose build constraints are
+// satisfied.
+func (a *LocalSourceFSAdapter) emitDirFiles(ctx context.Context, dirs []string, ignoreRegexps []*regexp.Regexp, emit func(m.Source) error) error {
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
 		if err != nil {
-			if isInvalidSourceErr(err) {
-				return nil
+			return err
+		}
+
+		for _, entry := range entries {
+			path := filepath.Join(dir, entry.Name())
+			if entry.IsDir() || !a.matchesBuild(path) {
+				continue
 			}
 
-			return err
+			if err := a.emitFileSource(ctx, path, ignoreRegexps, emit); err != nil {
+				return err
+			}
 		}
+	}
 
-		if !ok {
-			return nil
+	return nil
+}
+
+// skipFile returns the skip rule that excludes a Go file, if any.
+func (a *LocalSourceFSAdapter) skipFile(ctx context.Context, path string) string {
+	matcher := a.ignoreMatcher(filepath.Dir(path))
+
+	if rule := a.skipRules.skipPath(matcher.top, path); rule != "" {
+		return rule
+	}
+
+	if a.skipRules.Ignored && matcher.ignored(path, false) {
+		return SkipIgnored
+	}
+
+	src, err := a.ReadFile(ctx, m.Path(path))
+	if err != nil {
+		return ""
+	}
+
+	return a.skipRules.skipContent(path, src)
+}
+
+// SkippedFiles returns, per skip rule, how many files the last scan left out.
+func (a *LocalSourceFSAdapter) SkippedFiles(ctx context.Context) map[string]int {
+	if err := ctx.Err(); err != nil {
+		return nil
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	skipped := make(map[string]int, len(a.skipped))
+	for rule, count := range a.skipped {
+		skipped[rule] = count
+	}
+
+	return skipped
+}
+
+// resetScan clears the state one scan gathers, so the next scan sees the
+// current tree.
+func (a *LocalSourceFSAdapter) resetScan() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.skipped = map[string]int{}
+	a.ignores = map[string]*ignoreMatcher{}
+	a.graphs = map[string]*importGraph{}
+}
+
+func (a *LocalSourceFSAdapter) countSkipped(rule string, files int) {
+	if files == 0 {
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.skipped[rule] += files
+}
+
+// ignoreMatcher returns the matcher for the ignore files that apply to dir,
+// shared by every directory under the same top.
+func (a *LocalSourceFSAdapter) ignoreMatcher(dir string) *ignoreMatcher {
+	top := ignoreTop(dir)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.ignores == nil {
+		a.ignores = map[string]*ignoreMatcher{}
+	}
+
+	matcher, ok := a.ignores[top]
+	if !ok {
+		matcher = newIgnoreMatcher(top)
+		a.ignores[top] = matcher
+	}
+
+	return matcher
+}
+
+// countSourceFiles counts the non-test Go files under dir.
+func countSourceFiles(dir string) int {
+	count := 0
+
+	_ = filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err == nil && !entry.IsDir() && filepath.Ext(path) == ".go" && !strings.HasSuffix(path, "_test.go") {
+			count++
 		}
 
-		return emit(source)
+		return nil
 	})
+
+	return count
+}
+
+func isHiddenDir(name string) bool {
+	return strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
 }
 
 func normalizeRootPath(root string) (string, bool, error) {
@@ -540,15 +821,25 @@ func (a *LocalSourceFSAdapter) buildSourceFromPath(ctx context.Context, path str
 		return m.Source{}, false, err
 	}
 
-	testFile := a.detectTestFile(ctx, m.Path(absPath), projectRoot, ignoreRegexps)
+	tests, err := a.detectTestFiles(ctx, m.Path(absPath), projectRoot, ignoreRegexps)
+	if err != nil {
+		return m.Source{}, false, err
+	}
 
 	packageName := file.Name.Name
 
-	return m.Source{
-		Origin:  origin,
-		Test:    testFile,
-		Package: &packageName,
-	}, true, nil
+	source := m.Source{
+		Origin:     origin,
+		Tests:      tests,
+		Package:    &packageName,
+		TestConfig: a.testConfig,
+	}
+
+	if rootErr == nil {
+		source.Module = a.modulePath(projectRoot)
+	}
+
+	return source, true, nil
 }
 
 func (a *LocalSourceFSAdapter) readAndParseSource(ctx context.Context, absPath string) (*ast.File, error) {
@@ -587,31 +878,30 @@ func (a *LocalSourceFSAdapter) buildOriginFile(ctx context.Context, absPath stri
 	return origin, nil
 }
 
-func (a *LocalSourceFSAdapter) detectTestFile(ctx context.Context, sourcePath m.Path, projectRoot m.Path, ignoreRegexps []*regexp.Regexp) *m.File {
-	testPath := a.resolveTestPath(ctx, sourcePath, ignoreRegexps)
-	if testPath == "" {
-		return nil
-	}
-
-	file, err := a.buildTestFile(ctx, testPath, projectRoot)
+// detectTestFiles builds the test files of a source, leaving out ignored paths
+// and files that do not parse.
+func (a *LocalSourceFSAdapter) detectTestFiles(ctx context.Context, sourcePath m.Path, projectRoot m.Path, ignoreRegexps []*regexp.Regexp) ([]*m.File, error) {
+	testPaths, err := a.DetectTestFiles(ctx, sourcePath)
 	if err != nil {
-		return nil
+		return nil, err
 	}
 
-	return file
-}
+	var tests []*m.File
 
-func (a *LocalSourceFSAdapter) resolveTestPath(ctx context.Context, sourcePath m.Path, ignoreRegexps []*regexp.Regexp) m.Path {
-	testPath, err := a.DetectTestFile(ctx, sourcePath)
-	if err != nil || testPath == "" {
-		return ""
-	}
+	for _, testPath := range testPaths {
+		if shouldIgnorePath(string(testPath), ignoreRegexps) {
+			continue
+		}
 
-	if shouldIgnorePath(string(testPath), ignoreRegexps) {
-		return ""
+		file, err := a.buildTestFile(ctx, testPath, projectRoot)
+		if err != nil {
+			continue
+		}
+
+		tests = append(tests, file)
 	}
 
-	return testPath
+	return tests, nil
 }
 
 func (a *LocalSourceFSAdapter) buildTestFile(ctx context.Context, testPath m.Path, projectRoot m.Path) (*m.File, error) {
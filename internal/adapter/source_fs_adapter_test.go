@@ -96,31 +96,98 @@ func TestLocalSourceFSAdapter_HashFile(t *testing.T) {
 	assert.Equal(t, expected, hash)
 }
 
-func TestLocalSourceFSAdapter_DetectTestFile(t *testing.T) {
+func TestLocalSourceFSAdapter_DetectTestFiles(t *testing.T) {
 	adapter := NewLocalSourceFSAdapter()
 
 	root := t.TempDir()
 	source := filepath.Join(root, "calc.go")
 	testFile := filepath.Join(root, "calc_test.go")
+	externalTest := filepath.Join(root, "api_test.go")
 	writeTestFile(t, source, "package calc\n")
 	writeTestFile(t, testFile, "package calc\n")
+	writeTestFile(t, externalTest, "package calc_test\n")
+	writeTestFile(t, filepath.Join(root, "tagged_test.go"), "//go:build integration\n\npackage calc\n")
 
-	got, err := adapter.DetectTestFile(context.Background(), m.Path(source))
+	got, err := adapter.DetectTestFiles(context.Background(), m.Path(source))
 	require.NoError(t, err)
 
-	assert.Equal(t, m.Path(testFile), got)
+	assert.Equal(t, []m.Path{m.Path(externalTest), m.Path(testFile)}, got, "all test files of the package, excluding constrained ones")
 
-	t.Run("returns empty path when test file missing", func(t *testing.T) {
-		missingSrc := filepath.Join(root, "other.go")
+	t.Run("sources without a same-name test file get the package tests", func(t *testing.T) {
+		otherSrc := filepath.Join(root, "other.go")
+		writeTestFile(t, otherSrc, "package calc\n")
+
+		got, err := adapter.DetectTestFiles(context.Background(), m.Path(otherSrc))
+		require.NoError(t, err)
+
+		assert.Equal(t, []m.Path{m.Path(externalTest), m.Path(testFile)}, got)
+	})
+
+	t.Run("returns nothing when the package has no tests", func(t *testing.T) {
+		dir := t.TempDir()
+		missingSrc := filepath.Join(dir, "other.go")
 		writeTestFile(t, missingSrc, "package main\n")
 
-		got, err := adapter.DetectTestFile(context.Background(), m.Path(missingSrc))
+		got, err := adapter.DetectTestFiles(context.Background(), m.Path(missingSrc))
+		require.NoError(t, err)
+
+		assert.Empty(t, got)
+	})
+
+	t.Run("test files have no tests of their own", func(t *testing.T) {
+		got, err := adapter.DetectTestFiles(context.Background(), m.Path(testFile))
 		require.NoError(t, err)
 
 		assert.Empty(t, got)
 	})
 }
 
+func TestLocalSourceFSAdapter_DetectTestFiles_Mappings(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, filepath.Join(root, "go.mod"), "module example.com/project\n\ngo 1.21\n")
+
+	for _, dir := range []string{"internal", "internal/store", "internal/store/sql", "internal/other", "test", "test/integration", "test/integration/store", "test/integration/store/slow"} {
+		mustMkdir(t, filepath.Join(root, filepath.FromSlash(dir)))
+	}
+
+	storeSrc := filepath.Join(root, "internal", "store", "sql", "sql.go")
+	otherSrc := filepath.Join(root, "internal", "other", "other.go")
+	storeTest := filepath.Join(root, "test", "integration", "store", "store_test.go")
+	slowTest := filepath.Join(root, "test", "integration", "store", "slow", "slow_test.go")
+
+	writeTestFile(t, storeSrc, "package sql\n")
+	writeTestFile(t, otherSrc, "package other\n")
+	writeTestFile(t, storeTest, "package store_test\n")
+	writeTestFile(t, slowTest, "package slow_test\n")
+
+	t.Run("mapped package tests cover sources below the mapped directory", func(t *testing.T) {
+		adapter := NewLocalSourceFSAdapter(WithTestMappings(TestMapping{Sources: "internal/store", Tests: []string{"./test/integration/store"}}))
+
+		got, err := adapter.DetectTestFiles(context.Background(), m.Path(storeSrc))
+		require.NoError(t, err)
+		assert.Equal(t, []m.Path{m.Path(storeTest)}, got)
+
+		got, err = adapter.DetectTestFiles(context.Background(), m.Path(otherSrc))
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("recursive test patterns", func(t *testing.T) {
+		adapter := NewLocalSourceFSAdapter(WithTestMappings(TestMapping{Sources: "./internal/store/", Tests: []string{"test/integration/store/..."}}))
+
+		got, err := adapter.DetectTestFiles(context.Background(), m.Path(storeSrc))
+		require.NoError(t, err)
+		assert.Equal(t, []m.Path{m.Path(slowTest), m.Path(storeTest)}, got)
+	})
+
+	t.Run("missing mapped directory is an error", func(t *testing.T) {
+		adapter := NewLocalSourceFSAdapter(WithTestMappings(TestMapping{Sources: "internal", Tests: []string{"test/missing"}}))
+
+		_, err := adapter.DetectTestFiles(context.Background(), m.Path(storeSrc))
+		assert.Error(t, err)
+	})
+}
+
 func TestLocalSourceFSAdapter_FileInfo(t *testing.T) {
 	adapter := NewLocalSourceFSAdapter()
 
@@ -449,7 +516,88 @@ func TestLocalSourceFSAdapter_Get(t *testing.T) {
 		if assert.NotNil(t, sources[0].Origin) {
 			assert.Equal(t, m.Path(sourcePath), sources[0].Origin.FullPath)
 		}
-		assert.Nil(t, sources[0].Test)
+		assert.Empty(t, sources[0].Tests)
+	})
+}
+
+func TestLocalSourceFSAdapter_Get_PackagePatterns(t *testing.T) {
+	newModule := func(t *testing.T) (root, mainPath, childPath, taggedPath string) {
+		t.Helper()
+
+		root = t.TempDir()
+		writeTestFile(t, filepath.Join(root, "go.mod"), "module example.com/project\n\ngo 1.21\n")
+
+		mainPath = filepath.Join(root, "main.go")
+		writeTestFile(t, mainPath, "package main\n\nfunc main() {}\n")
+
+		taggedPath = filepath.Join(root, "integration.go")
+		writeTestFile(t, taggedPath, "//go:build integration\n\npackage main\n\nfunc extra() {}\n")
+
+		mustMkdir(t, filepath.Join(root, "nested"))
+		childPath = filepath.Join(root, "nested", "child.go")
+		writeTestFile(t, childPath, "package nested\n\nfunc Child() {}\n")
+
+		t.Chdir(root)
+
+		return root, mainPath, childPath, taggedPath
+	}
+
+	t.Run("import path pattern resolves through go list", func(t *testing.T) {
+		_, mainPath, childPath, taggedPath := newModule(t)
+
+		sources, err := NewLocalSourceFSAdapter().Get(context.Background(), []m.Path{"example.com/project/..."})
+		require.NoError(t, err)
+
+		assert.NotNil(t, findSourceV2ByOrigin(sources, mainPath))
+		assert.NotNil(t, findSourceV2ByOrigin(sources, childPath))
+		assert.Nil(t, findSourceV2ByOrigin(sources, taggedPath), "files excluded by build constraints are not sources")
+	})
+
+	t.Run("all selects the main module only", func(t *testing.T) {
+		_, mainPath, childPath, _ := newModule(t)
+
+		sources, err := NewLocalSourceFSAdapter().Get(context.Background(), []m.Path{"all"})
+		require.NoError(t, err)
+
+		require.Len(t, sources, 2)
+		assert.NotNil(t, findSourceV2ByOrigin(sources, mainPath))
+		assert.NotNil(t, findSourceV2ByOrigin(sources, childPath))
+	})
+
+	t.Run("build tags include constrained files", func(t *testing.T) {
+		_, _, _, taggedPath := newModule(t)
+
+		sources, err := NewLocalSourceFSAdapter(WithBuildTags("integration")).Get(context.Background(), []m.Path{"./..."})
+		require.NoError(t, err)
+
+		assert.NotNil(t, findSourceV2ByOrigin(sources, taggedPath))
+	})
+
+	t.Run("test settings include constrained files and fingerprint sources", func(t *testing.T) {
+		_, mainPath, _, taggedPath := newModule(t)
+
+		settings := TestSettings{Flags: []string{"-short", "-tags=integration"}}
+
+		sources, err := NewLocalSourceFSAdapter(WithBuildSettings(settings)).Get(context.Background(), []m.Path{"./..."})
+		require.NoError(t, err)
+
+		require.NotNil(t, findSourceV2ByOrigin(sources, taggedPath))
+		assert.Equal(t, settings.Fingerprint(), findSourceV2ByOrigin(sources, mainPath).TestConfig)
+	})
+
+	t.Run("constrained file roots are skipped", func(t *testing.T) {
+		_, _, _, taggedPath := newModule(t)
+
+		sources, err := NewLocalSourceFSAdapter().Get(context.Background(), []m.Path{m.Path(taggedPath)})
+		require.NoError(t, err)
+		assert.Empty(t, sources)
+	})
+
+	t.Run("unknown import path is an error", func(t *testing.T) {
+		newModule(t)
+
+		_, err := NewLocalSourceFSAdapter().Get(context.Background(), []m.Path{"example.com/project/missing"})
+		assert.Error(t, err)
 	})
 }
 
@@ -514,14 +662,14 @@ func assertSourceV2(t *testing.T, source *m.Source, originPath string, originSho
 	}
 
 	if testPath == "" {
-		assert.Nil(t, source.Test)
+		assert.Empty(t, source.Tests)
 		return
 	}
 
-	if assert.NotNil(t, source.Test) {
-		assert.Equal(t, m.Path(testPath), source.Test.FullPath)
-		assert.Equal(t, m.Path(testShort), source.Test.ShortPath)
-		assert.Equal(t, hashBytes(testContent), source.Test.Hash)
+	if assert.Len(t, source.Tests, 1) {
+		assert.Equal(t, m.Path(testPath), source.Tests[0].FullPath)
+		assert.Equal(t, m.Path(testShort), source.Tests[0].ShortPath)
+		assert.Equal(t, hashBytes(testContent), source.Tests[0].Hash)
 	}
 }
 
@@ -0,0 +1,160 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	m "gooze.dev/pkg/gooze/internal/model"
+)
+
+// TestMapping declares that the tests of other packages cover part of the tree,
+// for example integration tests kept apart from the code they exercise.
+type TestMapping struct {
+	// Sources is a directory relative to the module root; the source files in
+	// it and below it are matched.
+	Sources string
+	// Tests lists package directories, relative to the module root, whose test
+	// files cover the matched sources in addition to their own package's. A
+	// "/..." suffix includes the packages below the directory as well.
+	Tests []string
+}
+
+// WithTestMappings adds the tests of other packages to the sources they cover.
+func WithTestMappings(mappings ...TestMapping) SourceFSOption {
+	return func(a *LocalSourceFSAdapter) {
+		a.testMappings = mappings
+	}
+}
+
+// DetectTestFiles returns the test files of the source's package, external
+// _test packages included, followed by those of the packages mapped to it.
+// When the package has no tests, those of its importers stand in for them if
+// enabled. Test files excluded by build constraints are left out.
+func (a *LocalSourceFSAdapter) DetectTestFiles(ctx context.Context, sourcePath m.Path) ([]m.Path, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	source := string(sourcePath)
+	if filepath.Ext(source) != ".go" || strings.HasSuffix(source, "_test.go") {
+		return nil, nil
+	}
+
+	files, err := a.testFilesIn(filepath.Dir(source), false)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(files) == 0 {
+		if files, err = a.reverseDependencyTests(ctx, sourcePath); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, target := range a.mappedTestDirs(ctx, sourcePath) {
+		mapped, err := a.testFilesIn(target.dir, target.recursive)
+		if err != nil {
+			return nil, fmt.Errorf("test mapping for %s: %w", source, err)
+		}
+
+		files = append(files, mapped...)
+	}
+
+	seen := make(map[string]bool, len(files))
+	paths := make([]m.Path, 0, len(files))
+
+	for _, file := range files {
+		if !seen[file] {
+			seen[file] = true
+			paths = append(paths, m.Path(file))
+		}
+	}
+
+	return paths, nil
+}
+
+type testDir struct {
+	dir       string
+	recursive bool
+}
+
+// mappedTestDirs returns the test package directories the configured mappings
+// add to the source. Mappings are relative to the source's module root.
+func (a *LocalSourceFSAdapter) mappedTestDirs(ctx context.Context, sourcePath m.Path) []testDir {
+	if len(a.testMappings) == 0 {
+		return nil
+	}
+
+	root, err := a.FindProjectRoot(ctx, sourcePath)
+	if err != nil {
+		return nil
+	}
+
+	rel, err := filepath.Rel(string(root), filepath.Dir(string(sourcePath)))
+	if err != nil {
+		return nil
+	}
+
+	var dirs []testDir
+
+	for _, mapping := range a.testMappings {
+		if !underDir(rel, cleanMappingPath(mapping.Sources)) {
+			continue
+		}
+
+		for _, tests := range mapping.Tests {
+			dir, recursive := strings.CutSuffix(filepath.ToSlash(tests), "/...")
+			dirs = append(dirs, testDir{dir: filepath.Join(string(root), cleanMappingPath(dir)), recursive: recursive})
+		}
+	}
+
+	return dirs
+}
+
+// testFilesIn lists the *_test.go files of the package in dir, or of every
+// package below it when recursive, in lexical order.
+func (a *LocalSourceFSAdapter) testFilesIn(dir string, recursive bool) ([]string, error) {
+	var files []string
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if path != dir && (!recursive || isHiddenDir(d.Name()) || d.Name() == "testdata" || d.Name() == "vendor") {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if strings.HasSuffix(d.Name(), "_test.go") && a.matchesBuild(path) {
+			files = append(files, path)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(files)
+
+	return files, nil
+}
+
+// cleanMappingPath turns a slash-separated, module-relative mapping path such
+// as "./internal/store" into a clean OS path ("." for the module root).
+func cleanMappingPath(path string) string {
+	return filepath.Clean(filepath.FromSlash(strings.TrimPrefix(strings.TrimSpace(path), "/")))
+}
+
+// underDir reports whether the relative path rel is dir or lies below it.
+func underDir(rel, dir string) bool {
+	return dir == "." || rel == dir || strings.HasPrefix(rel, dir+string(filepath.Separator))
+}
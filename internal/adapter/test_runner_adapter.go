@@ -1,40 +1,293 @@
 package adapter
 
 import (
+	"bufio"
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os"
 	"os/exec"
+	"regexp"
+	"strings"
 )
 
 // TestRunnerAdapter abstracts test execution operations for mutation testing.
 type TestRunnerAdapter interface {
-	// RunGoTest runs 'go test' on a specific test file in the given directory.
-	// Returns the combined stdout/stderr output and any error.
-	RunGoTest(ctx context.Context, workDir, testFile string) (output string, err error)
+	// RunGoTest runs 'go test' on a test target (a package directory or
+	// pattern) from the given directory, limited to the tests matching run
+	// (a -run regular expression) unless it is empty. Returns the combined
+	// stdout/stderr output and any error. A run stopped by its resource limits
+	// fails with ErrCPULimit or ErrResourceLimit. A non-empty overlay names a
+	// `go build -overlay` file replacing source files for the build. env adds
+	// KEY=VALUE variables to the test's environment; such runs are never
+	// answered from the go command's test cache.
+	RunGoTest(ctx context.Context, workDir, target, run, overlay string, env ...string) (output string, err error)
+	// ListTests returns the names of the top-level tests, examples and fuzz
+	// targets of the test target, as printed by 'go test -list'.
+	ListTests(ctx context.Context, workDir, target string) (tests []string, err error)
+	// CoverTest runs a single test of the test target with a coverage profile
+	// spanning every package of the module in workDir, and returns the profile.
+	// A profile is returned even when the test fails, along with the error.
+	CoverTest(ctx context.Context, workDir, target, test string) (profile []byte, err error)
+	// RunBaseline runs the tests of the test target uncached, as the baseline
+	// against which mutants are measured. With cover, it also records a
+	// coverage profile spanning every package of the module in workDir.
+	RunBaseline(ctx context.Context, workDir, target string, cover bool) (output string, profile []byte, err error)
+	// CompileHash compiles the package in pkgDir and returns a hash of the code
+	// the compiler generated for it. Source positions are left out, so two
+	// versions of a package that compile to the same instructions hash equally.
+	// A non-empty overlay is passed to the build as in RunGoTest.
+	CompileHash(ctx context.Context, pkgDir, overlay string) (hash string, err error)
 }
 
 // LocalTestRunnerAdapter provides a concrete implementation using os/exec.
+// Test runs execute in a process group of their own, killed as a whole when
+// their context is canceled, with the configured test settings.
 type LocalTestRunnerAdapter struct {
+	limits   ResourceLimits
+	settings TestSettings
+	trimpath bool
 }
 
-// NewLocalTestRunnerAdapter constructs a LocalTestRunnerAdapter with default 30s timeout.
-func NewLocalTestRunnerAdapter() *LocalTestRunnerAdapter {
-	return &LocalTestRunnerAdapter{}
+// NewLocalTestRunnerAdapter constructs a LocalTestRunnerAdapter.
+func NewLocalTestRunnerAdapter(opts ...TestRunnerOption) *LocalTestRunnerAdapter {
+	a := &LocalTestRunnerAdapter{}
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	return a
+}
+
+// testArgs returns the flags of every go test run.
+func (a *LocalTestRunnerAdapter) testArgs() []string {
+	return a.withTrimpath(a.settings.args())
+}
+
+// compileArgs returns the flags of the builds hashing compiled packages.
+func (a *LocalTestRunnerAdapter) compileArgs() []string {
+	return a.withTrimpath(a.settings.compileArgs())
 }
 
-// RunGoTest runs 'go test' on a specific test file in the given directory.
-func (a *LocalTestRunnerAdapter) RunGoTest(ctx context.Context, workDir, testFile string) (string, error) {
-	cmd := exec.CommandContext(ctx, "go", "test", "-v", testFile)
+func (a *LocalTestRunnerAdapter) withTrimpath(args []string) []string {
+	if a.trimpath && !a.settings.HasFlag("trimpath") {
+		args = append(args, "-trimpath")
+	}
+
+	return args
+}
+
+// RunGoTest runs 'go test' on a test target from the given directory.
+func (a *LocalTestRunnerAdapter) RunGoTest(ctx context.Context, workDir, target, run, overlay string, env ...string) (string, error) {
+	args := append([]string{"test", "-v"}, a.testArgs()...)
+	// The test cache only keys results on the variables a test reads after
+	// it started, not during package initialization.
+	if len(env) > 0 && !a.settings.HasFlag("count") {
+		args = append(args, "-count=1")
+	}
+
+	if run != "" {
+		args = append(args, "-run", run)
+	}
+
+	if overlay != "" {
+		args = append(args, "-overlay="+overlay)
+	}
+
+	cmd := testCommand(ctx, a.limits, "go", append(args, target)...)
 	cmd.Dir = workDir
+	cmd.Env = a.settings.environ()
+
+	if len(env) > 0 {
+		if cmd.Env == nil {
+			cmd.Env = os.Environ()
+		}
+
+		cmd.Env = append(cmd.Env, env...)
+	}
 
 	var stdout, stderr bytes.Buffer
 
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 
-	err := cmd.Run()
+	err := runTestCommand(cmd)
 
 	output := stdout.String() + stderr.String()
+	if err != nil && ctx.Err() == nil {
+		err = a.limits.classify(output, err)
+	}
 
 	return output, err
 }
+
+// testName matches the test, example and fuzz target names 'go test -list'
+// prints, as opposed to its package summary lines.
+var testName = regexp.MustCompile(`^(Test|Example|Fuzz)\w*$`)
+
+// ListTests lists the tests of a test target with 'go test -list'.
+func (a *LocalTestRunnerAdapter) ListTests(ctx context.Context, workDir, target string) ([]string, error) {
+	args := append([]string{"test"}, a.testArgs()...)
+
+	cmd := testCommand(ctx, ResourceLimits{}, "go", append(args, "-list", ".", target)...)
+	cmd.Dir = workDir
+	cmd.Env = a.settings.environ()
+
+	var stdout, stderr bytes.Buffer
+
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := runTestCommand(cmd)
+
+	if err != nil {
+		return nil, fmt.Errorf("go test -list %s: %w: %s", target, err, strings.TrimSpace(stdout.String()+stderr.String()))
+	}
+
+	var tests []string
+
+	for _, line := range strings.Split(stdout.String(), "\n") {
+		if line = strings.TrimSpace(line); testName.MatchString(line) {
+			tests = append(tests, line)
+		}
+	}
+
+	return tests, nil
+}
+
+// CoverTest runs one test with -coverprofile and -coverpkg=./..., so lines of
+// other packages the test reaches are recorded too.
+func (a *LocalTestRunnerAdapter) CoverTest(ctx context.Context, workDir, target, test string) ([]byte, error) {
+	profile, err := os.CreateTemp("", "gooze-cover-*.out")
+	if err != nil {
+		return nil, fmt.Errorf("create coverage profile: %w", err)
+	}
+
+	profilePath := profile.Name()
+	_ = profile.Close()
+
+	defer func() {
+		_ = os.Remove(profilePath)
+	}()
+
+	args := append([]string{"test", "-count=1"}, a.testArgs()...)
+	args = append(args, "-run", "^"+regexp.QuoteMeta(test)+"$", "-coverpkg=./...", "-coverprofile="+profilePath, target)
+
+	cmd := testCommand(ctx, ResourceLimits{}, "go", args...)
+	cmd.Dir = workDir
+	cmd.Env = a.settings.environ()
+
+	var output bytes.Buffer
+
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	runErr := runTestCommand(cmd)
+
+	if runErr != nil {
+		runErr = fmt.Errorf("go test -run %s: %w: %s", test, runErr, strings.TrimSpace(output.String()))
+	}
+
+	content, err := os.ReadFile(profilePath)
+	if err != nil {
+		return nil, errors.Join(runErr, fmt.Errorf("read coverage profile: %w", err))
+	}
+
+	return content, runErr
+}
+
+// RunBaseline runs the tests of a test target with -count=1, so the go command
+// does not replay a cached result, optionally recording a coverage profile.
+func (a *LocalTestRunnerAdapter) RunBaseline(ctx context.Context, workDir, target string, cover bool) (string, []byte, error) {
+	args := append([]string{"test", "-count=1"}, a.testArgs()...)
+
+	var profilePath string
+
+	if cover {
+		profile, err := os.CreateTemp("", "gooze-baseline-*.out")
+		if err != nil {
+			return "", nil, fmt.Errorf("create coverage profile: %w", err)
+		}
+
+		profilePath = profile.Name()
+		_ = profile.Close()
+
+		defer func() {
+			_ = os.Remove(profilePath)
+		}()
+
+		args = append(args, "-coverpkg=./...", "-coverprofile="+profilePath)
+	}
+
+	cmd := testCommand(ctx, ResourceLimits{}, "go", append(args, target)...)
+	cmd.Dir = workDir
+	cmd.Env = a.settings.environ()
+
+	var output bytes.Buffer
+
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	err := runTestCommand(cmd)
+
+	if err != nil || !cover {
+		return output.String(), nil, err
+	}
+
+	content, err := os.ReadFile(profilePath)
+	if err != nil {
+		return output.String(), nil, fmt.Errorf("read coverage profile: %w", err)
+	}
+
+	return output.String(), content, nil
+}
+
+// sourcePosition matches the "(file.go:line)" annotations of compiler assembly output.
+var sourcePosition = regexp.MustCompile(`\([^()\s]+\.go:\d+\)`)
+
+// CompileHash builds the package in pkgDir with -gcflags=-S and hashes the
+// assembly listing the compiler prints. The go command replays that listing from
+// the build cache, so unchanged packages are not recompiled.
+func (a *LocalTestRunnerAdapter) CompileHash(ctx context.Context, pkgDir, overlay string) (string, error) {
+	args := append([]string{"build"}, a.compileArgs()...)
+	if overlay != "" {
+		args = append(args, "-overlay="+overlay)
+	}
+
+	cmd := exec.CommandContext(ctx, "go", append(args, "-gcflags=-S", "-o", os.DevNull, ".")...)
+	cmd.Dir = pkgDir
+	cmd.Env = a.settings.environ()
+
+	var stdout, stderr bytes.Buffer
+
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("go build: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	h := sha256.New()
+
+	scanner := bufio.NewScanner(&stderr)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		// "# importpath" headers name the package, not its code.
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		h.Write(sourcePosition.ReplaceAll([]byte(line), nil))
+		h.Write([]byte{'\n'})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("read compiler output: %w", err)
+	}
+
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
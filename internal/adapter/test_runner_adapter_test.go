@@ -1,7 +1,10 @@
 package adapter
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -17,7 +20,7 @@ func TestLocalTestRunnerAdapter_RunGoTest_Success(t *testing.T) {
 	workDir := filepath.Join("..", "..", "examples", "basic")
 	testTarget := "./..."
 
-	out, err := adapter.RunGoTest(context.Background(), workDir, testTarget)
+	out, err := adapter.RunGoTest(context.Background(), workDir, testTarget, "", "")
 	if err != nil {
 		t.Fatalf("RunGoTest() error = %v, output = %s", err, out)
 	}
@@ -28,6 +31,102 @@ func TestLocalTestRunnerAdapter_RunGoTest_Success(t *testing.T) {
 	}
 }
 
+func TestLocalTestRunnerAdapter_RunGoTest_TestSettings(t *testing.T) {
+	workDir := examplePath(t, "basic")
+
+	adapter := NewLocalTestRunnerAdapter(WithTestSettings(TestSettings{Flags: []string{"-count=2"}}))
+
+	out, err := adapter.RunGoTest(context.Background(), workDir, ".", "", "")
+	if err != nil {
+		t.Fatalf("RunGoTest() error = %v, output = %s", err, out)
+	}
+
+	if runs := strings.Count(out, "=== RUN   TestMain"); runs != 2 {
+		t.Fatalf("RunGoTest() with -count=2 ran TestMain %d times: %q", runs, out)
+	}
+
+	adapter = NewLocalTestRunnerAdapter(WithTestSettings(TestSettings{Env: []string{"GOARCH=gooze"}}))
+
+	if out, err := adapter.RunGoTest(context.Background(), workDir, ".", "", ""); err == nil {
+		t.Fatalf("RunGoTest() expected the environment to select an unknown GOARCH, output = %s", out)
+	}
+}
+
+func TestLocalTestRunnerAdapter_RunGoTest_Overlay(t *testing.T) {
+	workDir := examplePath(t, "basic")
+	mainPath := filepath.Join(workDir, "main.go")
+	original := readFileBytes(t, mainPath)
+
+	mutated := filepath.Join(t.TempDir(), "main.go")
+	writeTestBytes(t, mutated, bytes.Replace(original, []byte("3+5"), []byte("3-5"), 1))
+
+	overlay := filepath.Join(t.TempDir(), "overlay.json")
+	writeTestFile(t, overlay, fmt.Sprintf(`{"Replace":{%q:%q}}`, mainPath, mutated))
+
+	adapter := NewLocalTestRunnerAdapter()
+
+	out, err := adapter.RunGoTest(context.Background(), workDir, ".", "", overlay)
+	if err == nil || !strings.Contains(out, "--- FAIL: TestMain") {
+		t.Fatalf("RunGoTest() expected the overlaid source to fail TestMain, err = %v, output = %s", err, out)
+	}
+
+	if !bytes.Equal(readFileBytes(t, mainPath), original) {
+		t.Fatalf("RunGoTest() with an overlay modified the source tree")
+	}
+
+	base, err := adapter.CompileHash(context.Background(), workDir, "")
+	if err != nil {
+		t.Fatalf("CompileHash() error = %v", err)
+	}
+
+	overlaid, err := adapter.CompileHash(context.Background(), workDir, overlay)
+	if err != nil {
+		t.Fatalf("CompileHash() with overlay error = %v", err)
+	}
+
+	if overlaid == base {
+		t.Fatalf("CompileHash() ignored the overlay")
+	}
+}
+
+func TestLocalTestRunnerAdapter_RunGoTest_Trimpath(t *testing.T) {
+	workDir := t.TempDir()
+	writeTestFile(t, filepath.Join(workDir, "go.mod"), "module example.com/trimpath\n\ngo 1.21\n")
+	writeTestFile(t, filepath.Join(workDir, "path_test.go"), "package trimpath\n\nimport (\n\t\"runtime\"\n\t\"testing\"\n)\n\n"+
+		"func TestPath(t *testing.T) {\n\t_, file, _, _ := runtime.Caller(0)\n\tt.Log(\"file:\", file)\n}\n")
+
+	out, err := NewLocalTestRunnerAdapter().RunGoTest(context.Background(), workDir, ".", "", "")
+	if err != nil || !strings.Contains(out, "file: "+workDir) {
+		t.Fatalf("RunGoTest() expected the source's absolute path, err = %v, output = %s", err, out)
+	}
+
+	out, err = NewLocalTestRunnerAdapter(WithTrimpath(true)).RunGoTest(context.Background(), workDir, ".", "", "")
+	if err != nil || !strings.Contains(out, "file: example.com/trimpath/path_test.go") {
+		t.Fatalf("RunGoTest() with trimpath expected the source's module path, err = %v, output = %s", err, out)
+	}
+}
+
+func TestLocalTestRunnerAdapter_RunGoTest_Env(t *testing.T) {
+	workDir := t.TempDir()
+	writeTestFile(t, filepath.Join(workDir, "go.mod"), "module example.com/env\n\ngo 1.21\n")
+	// The variable is read during package initialization, which the test
+	// cache does not track.
+	writeTestFile(t, filepath.Join(workDir, "env_test.go"), "package env\n\nimport (\n\t\"os\"\n\t\"testing\"\n)\n\n"+
+		"var mode = os.Getenv(\"GOOZE_ENV_TEST\")\n\nfunc TestMode(t *testing.T) {\n\tif mode != \"on\" {\n\t\tt.Fatalf(\"mode = %q\", mode)\n\t}\n}\n")
+
+	adapter := NewLocalTestRunnerAdapter()
+
+	out, err := adapter.RunGoTest(context.Background(), workDir, ".", "", "", "GOOZE_ENV_TEST=on")
+	if err != nil {
+		t.Fatalf("RunGoTest() expected the environment to reach the test, err = %v, output = %s", err, out)
+	}
+
+	out, err = adapter.RunGoTest(context.Background(), workDir, ".", "", "", "GOOZE_ENV_TEST=off")
+	if err == nil || strings.Contains(out, "(cached)") {
+		t.Fatalf("RunGoTest() expected a fresh failing run for another environment, err = %v, output = %s", err, out)
+	}
+}
+
 func TestLocalTestRunnerAdapter_RunGoTest_Failure(t *testing.T) {
 	adapter := NewLocalTestRunnerAdapter()
 
@@ -35,7 +134,7 @@ func TestLocalTestRunnerAdapter_RunGoTest_Failure(t *testing.T) {
 	workDir := filepath.Join("..", "..", "examples", "basic")
 	testTarget := "./does_not_exist"
 
-	out, err := adapter.RunGoTest(context.Background(), workDir, testTarget)
+	out, err := adapter.RunGoTest(context.Background(), workDir, testTarget, "", "")
 	if err == nil {
 		t.Fatalf("RunGoTest() expected error for missing test target, got nil (output=%s)", out)
 	}
@@ -44,3 +143,113 @@ func TestLocalTestRunnerAdapter_RunGoTest_Failure(t *testing.T) {
 		t.Fatalf("RunGoTest() expected some diagnostic output for failure, got empty string")
 	}
 }
+
+func TestLocalTestRunnerAdapter_ListTests(t *testing.T) {
+	adapter := NewLocalTestRunnerAdapter()
+
+	tests, err := adapter.ListTests(context.Background(), examplePath(t, "basic"), ".")
+	if err != nil {
+		t.Fatalf("ListTests() error = %v", err)
+	}
+
+	if len(tests) != 1 || tests[0] != "TestMain" {
+		t.Fatalf("ListTests() = %v, want [TestMain]", tests)
+	}
+
+	if _, err := adapter.ListTests(context.Background(), examplePath(t, "basic"), "./does_not_exist"); err == nil {
+		t.Fatalf("ListTests() expected error for missing test target")
+	}
+}
+
+func TestLocalTestRunnerAdapter_CoverTest(t *testing.T) {
+	adapter := NewLocalTestRunnerAdapter()
+
+	profile, err := adapter.CoverTest(context.Background(), examplePath(t, "basic"), ".", "TestMain")
+	if err != nil {
+		t.Fatalf("CoverTest() error = %v", err)
+	}
+
+	if !bytes.HasPrefix(profile, []byte("mode: ")) || !bytes.Contains(profile, []byte("main.go:")) {
+		t.Fatalf("CoverTest() profile does not look like a coverage profile: %q", profile)
+	}
+}
+
+func TestLocalTestRunnerAdapter_RunBaseline(t *testing.T) {
+	adapter := NewLocalTestRunnerAdapter()
+
+	out, profile, err := adapter.RunBaseline(context.Background(), examplePath(t, "basic"), ".", false)
+	if err != nil {
+		t.Fatalf("RunBaseline() error = %v, output = %s", err, out)
+	}
+
+	if profile != nil || strings.Contains(out, "(cached)") {
+		t.Fatalf("RunBaseline() without cover: profile = %q, output = %q", profile, out)
+	}
+
+	_, profile, err = adapter.RunBaseline(context.Background(), examplePath(t, "basic"), ".", true)
+	if err != nil {
+		t.Fatalf("RunBaseline() with cover error = %v", err)
+	}
+
+	if !bytes.HasPrefix(profile, []byte("mode: ")) {
+		t.Fatalf("RunBaseline() profile does not look like a coverage profile: %q", profile)
+	}
+
+	if _, _, err := adapter.RunBaseline(context.Background(), examplePath(t, "basic"), "./does_not_exist", false); err == nil {
+		t.Fatalf("RunBaseline() expected error for missing test target")
+	}
+}
+
+func TestLocalTestRunnerAdapter_CompileHash(t *testing.T) {
+	adapter := NewLocalTestRunnerAdapter()
+
+	// Work on a copy of the basic example so its source can be mutated.
+	workDir := t.TempDir()
+	for _, name := range []string{"go.mod", "main.go", "type.go"} {
+		content := readFileBytes(t, filepath.Join(examplePath(t, "basic"), name))
+		if err := os.WriteFile(filepath.Join(workDir, name), content, 0o600); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+
+	mainPath := filepath.Join(workDir, "main.go")
+	original := readFileBytes(t, mainPath)
+
+	hashWith := func(content []byte) string {
+		t.Helper()
+
+		if err := os.WriteFile(mainPath, content, 0o600); err != nil {
+			t.Fatalf("write main.go: %v", err)
+		}
+
+		hash, err := adapter.CompileHash(context.Background(), workDir, "")
+		if err != nil {
+			t.Fatalf("CompileHash() error = %v", err)
+		}
+
+		return hash
+	}
+
+	base := hashWith(original)
+
+	// 5+3 folds to the same constant as 3+5; a shifted line changes no code.
+	if got := hashWith(bytes.Replace(original, []byte("3+5"), []byte("5+3"), 1)); got != base {
+		t.Fatalf("CompileHash() differs for an equivalent mutant")
+	}
+
+	if got := hashWith(append([]byte("// leading comment\n"), original...)); got != base {
+		t.Fatalf("CompileHash() differs when only source positions change")
+	}
+
+	if got := hashWith(bytes.Replace(original, []byte("3+5"), []byte("3*5"), 1)); got == base {
+		t.Fatalf("CompileHash() equal for a mutant that changes the generated code")
+	}
+}
+
+func TestLocalTestRunnerAdapter_CompileHash_BuildError(t *testing.T) {
+	adapter := NewLocalTestRunnerAdapter()
+
+	if _, err := adapter.CompileHash(context.Background(), t.TempDir(), ""); err == nil {
+		t.Fatalf("CompileHash() expected error outside a Go package")
+	}
+}
@@ -0,0 +1,224 @@
+package adapter
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"go/build"
+	"os"
+	"strings"
+)
+
+// TestSettings are the go test flags, build tags and environment every test
+// run executes with.
+type TestSettings struct {
+	// Flags are passed to `go test` as is, e.g. -short, -race or -mod=vendor.
+	// Values are given in the -name=value form.
+	Flags []string
+	// Tags are build tags, merged with any -tags of Flags.
+	Tags []string
+	// Env holds KEY=VALUE variables added to the environment, e.g.
+	// CGO_ENABLED=0.
+	Env []string
+}
+
+// reservedTestFlags are the go test flags gooze sets itself to run and read
+// the tests.
+var reservedTestFlags = map[string]bool{
+	"run":          true,
+	"list":         true,
+	"json":         true,
+	"coverprofile": true,
+}
+
+// buildFileFlags are the build flags, besides -tags, that change which files
+// are part of the build.
+var buildFileFlags = map[string]bool{
+	"mod":     true,
+	"modfile": true,
+	"overlay": true,
+}
+
+// toolBuildFlags are the build flags that also set the build tag of their
+// name.
+var toolBuildFlags = map[string]bool{
+	"race": true,
+	"msan": true,
+	"asan": true,
+}
+
+// WithTestSettings sets the flags, build tags and environment of every test
+// run.
+func WithTestSettings(settings TestSettings) TestRunnerOption {
+	return func(a *LocalTestRunnerAdapter) {
+		a.settings = settings
+	}
+}
+
+// WithTrimpath builds the tests with -trimpath, which leaves the location of
+// the sources out of the build, so copies of a package at different paths
+// share their build cache entries.
+func WithTrimpath(enabled bool) TestRunnerOption {
+	return func(a *LocalTestRunnerAdapter) {
+		a.trimpath = enabled
+	}
+}
+
+// WithBuildSettings makes source discovery evaluate build constraints and
+// resolve packages the way the tests run with the settings build them.
+// Sources record the settings' fingerprint, so results obtained with other
+// settings are not reused.
+func WithBuildSettings(settings TestSettings) SourceFSOption {
+	return func(a *LocalSourceFSAdapter) {
+		a.buildTags = settings.tags()
+		a.buildFlags = settings.buildFlags()
+		a.buildEnv = settings.Env
+		a.testConfig = settings.Fingerprint()
+	}
+}
+
+// Validate reports flags gooze sets itself and malformed variables.
+func (s TestSettings) Validate() error {
+	for _, flag := range s.Flags {
+		name, _ := flagName(flag)
+		if name == "" {
+			return fmt.Errorf("test flag %q: not a flag; give values as -name=value", flag)
+		}
+
+		if reservedTestFlags[name] {
+			return fmt.Errorf("test flag %q: set by gooze", flag)
+		}
+	}
+
+	for _, env := range s.Env {
+		if key, _, ok := strings.Cut(env, "="); !ok || key == "" {
+			return fmt.Errorf("test environment %q: want KEY=VALUE", env)
+		}
+	}
+
+	return nil
+}
+
+// HasFlag reports whether Flags set the named flag.
+func (s TestSettings) HasFlag(name string) bool {
+	for _, flag := range s.Flags {
+		if got, _ := flagName(flag); got == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Fingerprint identifies the settings; it is empty when there are none.
+func (s TestSettings) Fingerprint() string {
+	if len(s.Flags) == 0 && len(s.Tags) == 0 && len(s.Env) == 0 {
+		return ""
+	}
+
+	h := sha256.New()
+	for _, values := range [][]string{s.Flags, s.Tags, s.Env} {
+		for _, value := range values {
+			h.Write([]byte(value))
+			h.Write([]byte{0})
+		}
+
+		h.Write([]byte{1})
+	}
+
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+// args returns the flags to pass to the go command, with every build tag
+// merged into a single -tags flag.
+func (s TestSettings) args() []string {
+	var args []string
+
+	for _, flag := range s.Flags {
+		if name, _ := flagName(flag); name != "tags" {
+			args = append(args, flag)
+		}
+	}
+
+	if tags := s.tags(); len(tags) > 0 {
+		args = append(args, "-tags="+strings.Join(tags, ","))
+	}
+
+	return args
+}
+
+// buildFlags returns the flags that change which files are part of the build,
+// other than -tags, for `go list`.
+func (s TestSettings) buildFlags() []string {
+	var flags []string
+
+	for _, flag := range s.Flags {
+		if name, _ := flagName(flag); buildFileFlags[name] || toolBuildFlags[name] {
+			flags = append(flags, flag)
+		}
+	}
+
+	return flags
+}
+
+// compileArgs returns the flags of `go build` compiling a package the way its
+// tests build it: the build flags and tags, without the test flags.
+func (s TestSettings) compileArgs() []string {
+	args := s.buildFlags()
+	if tags := s.tags(); len(tags) > 0 {
+		args = append(args, "-tags="+strings.Join(tags, ","))
+	}
+
+	return args
+}
+
+// tags returns the build tags of Tags and of any -tags flag, in order.
+func (s TestSettings) tags() []string {
+	tags := append([]string(nil), s.Tags...)
+
+	for _, flag := range s.Flags {
+		if name, value := flagName(flag); name == "tags" {
+			tags = append(tags, strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ' ' })...)
+		}
+	}
+
+	return tags
+}
+
+// environ returns the environment of a go command run with the settings, or
+// nil to inherit gooze's own.
+func (s TestSettings) environ() []string {
+	if len(s.Env) == 0 {
+		return nil
+	}
+
+	return append(os.Environ(), s.Env...)
+}
+
+// flagName splits a "-name", "--name" or "-name=value" flag into its name and
+// value. The name is empty when flag is not a flag.
+func flagName(flag string) (name, value string) {
+	if !strings.HasPrefix(flag, "-") {
+		return "", ""
+	}
+
+	name, value, _ = strings.Cut(strings.TrimPrefix(strings.TrimPrefix(flag, "-"), "-"), "=")
+
+	return name, value
+}
+
+// applyBuildEnv sets the target platform and cgo setting of the build context
+// from the environment variables, as the go command would.
+func applyBuildEnv(ctxt *build.Context, env []string) {
+	for _, variable := range env {
+		key, value, _ := strings.Cut(variable, "=")
+
+		switch key {
+		case "GOOS":
+			ctxt.GOOS = value
+		case "GOARCH":
+			ctxt.GOARCH = value
+		case "CGO_ENABLED":
+			ctxt.CgoEnabled = value == "1"
+		}
+	}
+}
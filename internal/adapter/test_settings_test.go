@@ -0,0 +1,47 @@
+package adapter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTestSettings_Args(t *testing.T) {
+	settings := TestSettings{
+		Flags: []string{"-short", "-tags=integration,slow", "-mod=vendor", "-race", "-count=1"},
+		Tags:  []string{"e2e"},
+	}
+
+	assert.Equal(t, []string{"-short", "-mod=vendor", "-race", "-count=1", "-tags=e2e,integration,slow"}, settings.args())
+	assert.Equal(t, []string{"-mod=vendor", "-race"}, settings.buildFlags())
+	assert.Equal(t, []string{"-mod=vendor", "-race", "-tags=e2e,integration,slow"}, settings.compileArgs())
+	assert.Empty(t, TestSettings{}.args())
+	assert.Nil(t, TestSettings{}.environ())
+}
+
+func TestTestSettings_Validate(t *testing.T) {
+	assert.NoError(t, TestSettings{Flags: []string{"-short", "--count=1"}, Env: []string{"CGO_ENABLED=0", "EMPTY="}}.Validate())
+	assert.Error(t, TestSettings{Flags: []string{"-mod", "vendor"}}.Validate())
+	assert.Error(t, TestSettings{Flags: []string{"-run=TestA"}}.Validate())
+	assert.Error(t, TestSettings{Flags: []string{"-json"}}.Validate())
+	assert.Error(t, TestSettings{Env: []string{"CGO_ENABLED"}}.Validate())
+	assert.Error(t, TestSettings{Env: []string{"=1"}}.Validate())
+}
+
+func TestTestSettings_HasFlag(t *testing.T) {
+	settings := TestSettings{Flags: []string{"-short", "--overlay=overlay.json"}}
+
+	assert.True(t, settings.HasFlag("short"))
+	assert.True(t, settings.HasFlag("overlay"))
+	assert.False(t, settings.HasFlag("race"))
+}
+
+func TestTestSettings_Fingerprint(t *testing.T) {
+	assert.Empty(t, TestSettings{}.Fingerprint())
+
+	short := TestSettings{Flags: []string{"-short"}}.Fingerprint()
+	assert.NotEmpty(t, short)
+	assert.Equal(t, short, TestSettings{Flags: []string{"-short"}}.Fingerprint())
+	assert.NotEqual(t, short, TestSettings{Tags: []string{"-short"}}.Fingerprint())
+	assert.NotEqual(t, short, TestSettings{Flags: []string{"-short"}, Env: []string{"CGO_ENABLED=0"}}.Fingerprint())
+}
@@ -0,0 +1,88 @@
+package adapter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	m "gooze.dev/pkg/gooze/internal/model"
+)
+
+// errLocked reports a workspace slot another workspace holds.
+var errLocked = errors.New("locked")
+
+// workspaceRoot is the directory stable workspace directories are created in.
+func workspaceRoot() string {
+	return filepath.Join(os.TempDir(), "gooze-workspaces")
+}
+
+// CreateWorkspaceDir creates an empty directory at <temp>/gooze-workspaces/
+// <key>/<slot>, in the lowest slot no other workspace of this or, on Unix,
+// another gooze process holds. The slot is locked until RemoveWorkspaceDir, so
+// a worker gets the same path from one run to the next.
+func (a *LocalSourceFSAdapter) CreateWorkspaceDir(ctx context.Context, key string) (m.Path, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	base := filepath.Join(workspaceRoot(), key)
+	if err := os.MkdirAll(base, 0o750); err != nil {
+		return "", err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for slot := 0; ; slot++ {
+		dir := filepath.Join(base, strconv.Itoa(slot))
+		if _, held := a.workspaceLocks[dir]; held {
+			continue
+		}
+
+		lock, err := lockFile(dir + ".lock")
+		if errors.Is(err, errLocked) {
+			continue
+		}
+
+		if err != nil {
+			return "", fmt.Errorf("lock workspace %s: %w", dir, err)
+		}
+
+		// Clear what a run that did not clean up left behind.
+		if err := os.RemoveAll(dir); err == nil {
+			err = os.Mkdir(dir, 0o750)
+		}
+
+		if err != nil {
+			_ = lock.Close()
+			return "", err
+		}
+
+		if a.workspaceLocks == nil {
+			a.workspaceLocks = map[string]*os.File{}
+		}
+
+		a.workspaceLocks[dir] = lock
+
+		return m.Path(dir), nil
+	}
+}
+
+// RemoveWorkspaceDir removes a directory CreateWorkspaceDir created and
+// releases its slot.
+func (a *LocalSourceFSAdapter) RemoveWorkspaceDir(ctx context.Context, dir m.Path) error {
+	err := a.RemoveAll(ctx, dir)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if lock, ok := a.workspaceLocks[string(dir)]; ok {
+		delete(a.workspaceLocks, string(dir))
+		err = errors.Join(err, lock.Close())
+	}
+
+	return err
+}
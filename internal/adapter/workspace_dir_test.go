@@ -0,0 +1,49 @@
+package adapter
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	m "gooze.dev/pkg/gooze/internal/model"
+)
+
+func TestLocalSourceFSAdapter_CreateWorkspaceDir(t *testing.T) {
+	t.Setenv("TMPDIR", t.TempDir())
+
+	ctx := context.Background()
+	a := NewLocalSourceFSAdapter()
+	base := filepath.Join(os.TempDir(), "gooze-workspaces", "project")
+
+	first, err := a.CreateWorkspaceDir(ctx, "project")
+	require.NoError(t, err)
+	assert.Equal(t, m.Path(filepath.Join(base, "0")), first)
+
+	second, err := a.CreateWorkspaceDir(ctx, "project")
+	require.NoError(t, err)
+	assert.Equal(t, m.Path(filepath.Join(base, "1")), second)
+
+	// A released slot is handed out again, emptied of what was left in it.
+	writeTestFile(t, filepath.Join(string(first), "stale.go"), "package stale\n")
+	require.NoError(t, a.RemoveWorkspaceDir(ctx, first))
+	assert.NoDirExists(t, string(first))
+
+	mustMkdir(t, string(first))
+	writeTestFile(t, filepath.Join(string(first), "stale.go"), "package stale\n")
+
+	again, err := a.CreateWorkspaceDir(ctx, "project")
+	require.NoError(t, err)
+	assert.Equal(t, first, again)
+	assert.NoFileExists(t, filepath.Join(string(again), "stale.go"))
+
+	other, err := a.CreateWorkspaceDir(ctx, "other")
+	require.NoError(t, err)
+	assert.Equal(t, m.Path(filepath.Join(os.TempDir(), "gooze-workspaces", "other", "0")), other)
+
+	require.NoError(t, a.RemoveWorkspaceDir(ctx, second))
+	require.NoError(t, a.RemoveWorkspaceDir(ctx, again))
+	require.NoError(t, a.RemoveWorkspaceDir(ctx, other))
+}
@@ -209,6 +209,41 @@ func (_c *MockUI_DisplayMutationScore_Call) RunAndReturn(run func(context.Contex
 	return _c
 }
 
+// DisplayScoreInterval provides a mock function with given fields: ctx, low, high
+func (_m *MockUI) DisplayScoreInterval(ctx context.Context, low float64, high float64) {
+	_m.Called(ctx, low, high)
+}
+
+// MockUI_DisplayScoreInterval_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DisplayScoreInterval'
+type MockUI_DisplayScoreInterval_Call struct {
+	*mock.Call
+}
+
+// DisplayScoreInterval is a helper method to define mock.On call
+//   - ctx context.Context
+//   - low float64
+//   - high float64
+func (_e *MockUI_Expecter) DisplayScoreInterval(ctx interface{}, low interface{}, high interface{}) *MockUI_DisplayScoreInterval_Call {
+	return &MockUI_DisplayScoreInterval_Call{Call: _e.mock.On("DisplayScoreInterval", ctx, low, high)}
+}
+
+func (_c *MockUI_DisplayScoreInterval_Call) Run(run func(ctx context.Context, low float64, high float64)) *MockUI_DisplayScoreInterval_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(float64), args[2].(float64))
+	})
+	return _c
+}
+
+func (_c *MockUI_DisplayScoreInterval_Call) Return() *MockUI_DisplayScoreInterval_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockUI_DisplayScoreInterval_Call) RunAndReturn(run func(context.Context, float64, float64)) *MockUI_DisplayScoreInterval_Call {
+	_c.Run(run)
+	return _c
+}
+
 // DisplayStartingTestInfo provides a mock function with given fields: ctx, currentMutation, threadID
 func (_m *MockUI) DisplayStartingTestInfo(ctx context.Context, currentMutation model.Mutation, threadID int) {
 	_m.Called(ctx, currentMutation, threadID)
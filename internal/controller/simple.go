@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
@@ -69,9 +71,33 @@ func (s *SimpleUI) DisplayEstimation(ctx context.Context, estimation domain.Esti
 	tableStr := renderEstimationTable(estimation)
 	s.printf("\n%s", tableStr)
 
+	if skipped := formatSkipped(estimation.Skipped); skipped != "" {
+		s.printf("Skipped files: %s\n", skipped)
+	}
+
 	return nil
 }
 
+// formatSkipped lists the files skipped per rule ("generated 3, vendor 12"),
+// ordered by rule name. It is empty when nothing was skipped.
+func formatSkipped(skipped map[string]int) string {
+	rules := make([]string, 0, len(skipped))
+	for rule, count := range skipped {
+		if count > 0 {
+			rules = append(rules, rule)
+		}
+	}
+
+	sort.Strings(rules)
+
+	parts := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		parts = append(parts, fmt.Sprintf("%s %d", rule, skipped[rule]))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
 func renderEstimationTable(estimation domain.Estimation) string {
 	var tableBuffer bytes.Buffer
 
@@ -163,6 +189,15 @@ func (s *SimpleUI) DisplayMutationScore(ctx context.Context, score float64) {
 	s.printf("Mutation score: %.2f%%\n", score*100)
 }
 
+// DisplayScoreInterval prints the 95% confidence interval of a sampled score.
+func (s *SimpleUI) DisplayScoreInterval(ctx context.Context, low, high float64) {
+	if err := ctx.Err(); err != nil {
+		return
+	}
+
+	s.printf("Score 95%% CI: %.2f%% - %.2f%%\n", low*100, high*100)
+}
+
 func (s *SimpleUI) printf(format string, args ...interface{}) {
 	_, _ = fmt.Fprintf(s.cmd.OutOrStdout(), format, args...)
 }
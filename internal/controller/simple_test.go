@@ -47,6 +47,32 @@ func TestSimpleUI_DisplayEstimation_PrintsTable(t *testing.T) {
 	}
 }
 
+func TestSimpleUI_DisplayEstimation_PrintsSkipped(t *testing.T) {
+	var buf bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&buf)
+
+	ui := NewSimpleUI(cmd)
+
+	estimation := domain.Estimation{
+		Total:   1,
+		Files:   []domain.FileEstimate{{Path: "a.go", Count: 1}},
+		Skipped: map[string]int{"vendor": 12, "generated": 3, "cgo": 0},
+	}
+
+	if err := ui.DisplayEstimation(context.Background(), estimation, nil); err != nil {
+		t.Fatalf("DisplayEstimation() error = %v", err)
+	}
+
+	if output := buf.String(); !strings.Contains(output, "Skipped files: generated 3, vendor 12\n") {
+		t.Fatalf("output missing skipped files\noutput:\n%s", output)
+	}
+
+	if got := formatSkipped(nil); got != "" {
+		t.Fatalf("formatSkipped(nil) = %q, want empty", got)
+	}
+}
+
 func TestSimpleUI_DisplayEstimation_Error(t *testing.T) {
 	var buf bytes.Buffer
 	cmd := &cobra.Command{}
@@ -101,6 +127,7 @@ func TestSimpleUI_OtherDisplays(t *testing.T) {
 	ui.DisplayCompletedTestInfo(ctx, m.Mutation{ID: "abcd1234567890", Type: m.MutationArithmetic}, result)
 	ui.DisplayCompletedTestInfo(ctx, m.Mutation{ID: "efgh5678901234", Type: m.MutationBoolean, Source: m.Source{Origin: &m.File{FullPath: "path/a.go"}}, DiffCode: []byte("--- original\n+++ mutated\n@@\n")}, result)
 	ui.DisplayMutationScore(ctx, 0.75)
+	ui.DisplayScoreInterval(ctx, 0.6, 0.9)
 
 	output := buf.String()
 	for _, want := range []string{
@@ -113,6 +140,7 @@ func TestSimpleUI_OtherDisplays(t *testing.T) {
 		"File: path/a.go",
 		"--- original",
 		"Mutation score: 75.00%",
+		"Score 95% CI: 60.00% - 90.00%",
 	} {
 		if !strings.Contains(output, want) {
 			t.Fatalf("output missing %q\noutput:\n%s", want, output)
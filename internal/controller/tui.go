@@ -148,6 +148,7 @@ func (t *TUI) DisplayEstimation(ctx context.Context, estimation domain.Estimatio
 		total:     estimation.Total,
 		paths:     len(estimation.Files),
 		fileStats: fileStats,
+		skipped:   formatSkipped(estimation.Skipped),
 	})
 
 	// Don't close immediately - let user interact with the UI
@@ -246,6 +247,16 @@ func (t *TUI) DisplayMutationScore(ctx context.Context, score float64) {
 	t.send(mutationScoreMsg{score: score})
 }
 
+// DisplayScoreInterval shows the 95% confidence interval of a sampled score.
+func (t *TUI) DisplayScoreInterval(ctx context.Context, low, high float64) {
+	if err := ctx.Err(); err != nil {
+		return
+	}
+
+	t.ensureStarted(ctx)
+	t.send(scoreIntervalMsg{low: low, high: high})
+}
+
 func (t *TUI) ensureStarted(ctx context.Context) {
 	_ = t.Start(ctx)
 }
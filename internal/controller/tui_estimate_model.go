@@ -77,6 +77,7 @@ type estimateModel struct {
 	delegate     estimateDelegate
 	total        int
 	totalFiles   int
+	skipped      string
 	rendered     bool
 	animOffset   int
 	lastSelected int
@@ -160,6 +161,7 @@ func (m estimateModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 func (m estimateModel) handleEstimationMsg(msg estimationMsg) estimateModel {
 	m.total = msg.total
 	m.totalFiles = msg.paths
+	m.skipped = msg.skipped
 
 	// Create sorted file items
 	statsList := make([]fileStat, 0, len(msg.fileStats))
@@ -209,11 +211,16 @@ func (m estimateModel) View() string {
 	title := titleStyle.Render("🧬 Gooze Mutation Estimate")
 
 	// 2. Summary
-	summary := summaryStyle.Render(fmt.Sprintf(
+	summaryText := fmt.Sprintf(
 		"Total Mutations: %s   Files: %s",
 		accentStyle.Render(fmt.Sprintf("%d", m.total)),
 		accentStyle.Render(fmt.Sprintf("%d", m.totalFiles)),
-	))
+	)
+	if m.skipped != "" {
+		summaryText += "   Skipped: " + accentStyle.Render(m.skipped)
+	}
+
+	summary := summaryStyle.Render(summaryText)
 
 	// 3. Table with border
 	table := m.renderTable()
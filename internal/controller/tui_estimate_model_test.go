@@ -78,6 +78,15 @@ func TestEstimateModel_HandleEstimationMsgAndView(t *testing.T) {
 		t.Fatalf("View() missing title\n%s", view)
 	}
 
+	if strings.Contains(view, "Skipped:") {
+		t.Fatalf("View() shows skipped files without any\n%s", view)
+	}
+
+	m = m.handleEstimationMsg(estimationMsg{total: 3, paths: 2, fileStats: msg.fileStats, skipped: "vendor 4"})
+	if view := m.View(); !strings.Contains(view, "Skipped:") || !strings.Contains(view, "vendor 4") {
+		t.Fatalf("View() missing skipped files\n%s", view)
+	}
+
 	if cmd := m.Init(); cmd == nil {
 		t.Fatalf("Init() returned nil cmd")
 	}
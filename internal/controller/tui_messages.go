@@ -5,6 +5,7 @@ type estimationMsg struct {
 	total     int
 	paths     int
 	fileStats map[string]fileStat
+	skipped   string
 	err       error
 }
 
@@ -44,6 +45,11 @@ type mutationScoreMsg struct {
 	score float64
 }
 
+type scoreIntervalMsg struct {
+	low  float64
+	high float64
+}
+
 // List item types.
 type fileItem struct {
 	path  string
@@ -193,6 +193,7 @@ func TestTUI_DisplayMethods_NoProgram(t *testing.T) {
 	tui.DisplayCompletedTestInfo(ctx, mutationWithOrigin(), completedResult())
 	tui.DisplayCompletedTestInfo(ctx, mutationWithoutOrigin(), mResultEmpty())
 	tui.DisplayMutationScore(ctx, 0.5)
+	tui.DisplayScoreInterval(ctx, 0.4, 0.6)
 }
 
 var errSentinel = errors.New("boom")
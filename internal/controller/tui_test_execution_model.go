@@ -99,6 +99,9 @@ type testExecutionModel struct {
 	currentStatus     string
 	mutationScore     float64
 	mutationScoreSet  bool
+	scoreLow          float64
+	scoreHigh         float64
+	scoreIntervalSet  bool
 	totalMutations    int
 	completedCount    int
 	progressPercent   float64
@@ -183,6 +186,9 @@ func (m testExecutionModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case mutationScoreMsg:
 		m.mutationScore = msg.score
 		m.mutationScoreSet = true
+	case scoreIntervalMsg:
+		m.scoreLow, m.scoreHigh = msg.low, msg.high
+		m.scoreIntervalSet = true
 	}
 
 	return m, cmd
@@ -377,6 +383,10 @@ func (m testExecutionModel) viewResults() string {
 		summaryParts = append(summaryParts, fmt.Sprintf("Score: %s", accentStyle.Render(fmt.Sprintf("%.2f%%", m.mutationScore*100))))
 	}
 
+	if m.scoreIntervalSet {
+		summaryParts = append(summaryParts, fmt.Sprintf("95%% CI: %s", accentStyle.Render(fmt.Sprintf("%.2f%% - %.2f%%", m.scoreLow*100, m.scoreHigh*100))))
+	}
+
 	summary := summaryStyle.Render(strings.Join(summaryParts, "  •  "))
 
 	// 3. Results table with list
@@ -222,6 +222,12 @@ func TestTestExecutionModel_WindowSizeAndViews(t *testing.T) {
 		t.Fatalf("viewResults missing title")
 	}
 
+	updated, _ := m.Update(scoreIntervalMsg{low: 0.4, high: 0.6})
+	m = updated.(testExecutionModel)
+	if got := m.viewResults(); !strings.Contains(got, "40.00% - 60.00%") {
+		t.Fatalf("viewResults missing score interval")
+	}
+
 	box := m.renderResultsBox("6")
 	if !strings.Contains(box, "ID") {
 		t.Fatalf("renderResultsBox missing headers")
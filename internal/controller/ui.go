@@ -53,4 +53,5 @@ type UI interface {
 	DisplayStartingTestInfo(ctx context.Context, currentMutation m.Mutation, threadID int)
 	DisplayCompletedTestInfo(ctx context.Context, currentMutation m.Mutation, mutationResult m.Result)
 	DisplayMutationScore(ctx context.Context, score float64)
+	DisplayScoreInterval(ctx context.Context, low, high float64)
 }
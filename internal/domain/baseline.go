@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"bytes"
+	"time"
+
+	m "gooze.dev/pkg/gooze/internal/model"
+)
+
+// Baseline is the outcome of running the tests of the affected packages once
+// against the unmutated project, before any mutant is tested.
+type Baseline struct {
+	// Durations maps each test package directory to how long its tests took.
+	Durations map[m.Path]time.Duration
+	// Profile is the coverage profile of the run, merged across packages. It is
+	// only recorded when requested.
+	Profile []byte
+}
+
+// Timeouts sets how long a mutant's tests may run before it is reported as a
+// timeout.
+type Timeouts struct {
+	// Fixed applies to mutants whose tests have no baseline duration. Zero
+	// means no limit.
+	Fixed time.Duration
+	// Factor and Constant derive a mutant's timeout from the baseline duration
+	// of its test packages: Factor*baseline + Constant. A Factor of zero
+	// disables adaptive timeouts.
+	Factor   float64
+	Constant time.Duration
+}
+
+// mutationTimeouts resolves the timeout of each mutant from the baseline
+// durations of the test packages it runs.
+type mutationTimeouts struct {
+	Timeouts
+
+	durations map[m.Path]time.Duration
+}
+
+// forMutation returns the mutant's timeout: adaptive when every test package it
+// runs has a baseline duration, the fixed timeout otherwise.
+func (t mutationTimeouts) forMutation(mutation m.Mutation) time.Duration {
+	if t.Factor <= 0 || len(t.durations) == 0 || len(mutation.Source.Tests) == 0 {
+		return t.Fixed
+	}
+
+	var baseline time.Duration
+
+	for _, dir := range testPackageDirs(mutation.Source.Tests) {
+		duration, ok := t.durations[dir]
+		if !ok {
+			return t.Fixed
+		}
+
+		baseline += duration
+	}
+
+	return time.Duration(t.Factor*float64(baseline)) + t.Constant
+}
+
+// mergeProfiles concatenates coverage profiles, keeping only the first "mode:"
+// header.
+func mergeProfiles(profiles [][]byte) []byte {
+	var merged bytes.Buffer
+
+	for _, profile := range profiles {
+		for line := range bytes.Lines(profile) {
+			if bytes.HasPrefix(line, []byte("mode:")) && merged.Len() > 0 {
+				continue
+			}
+
+			merged.Write(line)
+
+			if !bytes.HasSuffix(line, []byte("\n")) {
+				merged.WriteByte('\n')
+			}
+		}
+	}
+
+	return merged.Bytes()
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	m "gooze.dev/pkg/gooze/internal/model"
+)
+
+func TestMutationTimeouts_ForMutation(t *testing.T) {
+	timeouts := mutationTimeouts{
+		Timeouts:  Timeouts{Fixed: time.Minute, Factor: 3, Constant: 10 * time.Second},
+		durations: map[m.Path]time.Duration{"/p/pkg": 2 * time.Second, "/p/e2e": time.Second},
+	}
+
+	mutation := func(tests ...m.Path) m.Mutation {
+		files := make([]*m.File, len(tests))
+		for i, test := range tests {
+			files[i] = &m.File{FullPath: test}
+		}
+
+		return m.Mutation{Source: m.Source{Tests: files}}
+	}
+
+	assert.Equal(t, 16*time.Second, timeouts.forMutation(mutation("/p/pkg/a_test.go", "/p/pkg/b_test.go")))
+	assert.Equal(t, 19*time.Second, timeouts.forMutation(mutation("/p/pkg/a_test.go", "/p/e2e/e2e_test.go")))
+	assert.Equal(t, time.Minute, timeouts.forMutation(mutation("/p/other/a_test.go")), "no baseline for the package")
+	assert.Equal(t, time.Minute, timeouts.forMutation(mutation()), "no tests")
+
+	timeouts.Factor = 0
+	assert.Equal(t, time.Minute, timeouts.forMutation(mutation("/p/pkg/a_test.go")), "adaptive timeouts disabled")
+}
+
+func TestMergeProfiles(t *testing.T) {
+	merged := mergeProfiles([][]byte{
+		[]byte("mode: set\nmod/a.go:1.1,2.2 1 1\n"),
+		nil,
+		[]byte("mode: set\nmod/b.go:3.1,4.2 1 0"),
+	})
+
+	assert.Equal(t, "mode: set\nmod/a.go:1.1,2.2 1 1\nmod/b.go:3.1,4.2 1 0\n", string(merged))
+}
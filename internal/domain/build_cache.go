@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"log/slog"
+
+	m "gooze.dev/pkg/gooze/internal/model"
+)
+
+// WithStableWorkspaces copies the project of each workspace to a directory
+// whose path only depends on the project and the worker's slot, instead of a
+// random temp dir, so the go command's build and test caches keep matching the
+// copies from one run to the next.
+func WithStableWorkspaces(enabled bool) OrchestratorOption {
+	return func(to *orchestrator) {
+		to.stable = enabled
+	}
+}
+
+// WithWarmUp makes each workspace build the tests of its copy, with
+// `go test -run ^$`, before testing its first mutant, so the mutants'
+// runs only rebuild the mutated package and its dependents.
+func WithWarmUp(enabled bool) OrchestratorOption {
+	return func(to *orchestrator) {
+		to.warmUp = enabled
+	}
+}
+
+// warmUpRun matches no test, so a warm-up run only builds the test binaries.
+const warmUpRun = "^$"
+
+// createDir creates the directory the project at root is copied to.
+func (ws *workspace) createDir(ctx context.Context, root m.Path) (m.Path, error) {
+	if ws.stable {
+		return ws.fsAdapter.CreateWorkspaceDir(ctx, workspaceKey(root))
+	}
+
+	return ws.fsAdapter.CreateTempDir(ctx, "gooze-mutation-*")
+}
+
+// removeDir removes a directory createDir or, in overlay mode, prepareOverlay
+// created.
+func (ws *workspace) removeDir(ctx context.Context, dir m.Path) error {
+	if ws.stable && ws.mode != WorkspaceOverlay {
+		return ws.fsAdapter.RemoveWorkspaceDir(ctx, dir)
+	}
+
+	return ws.fsAdapter.RemoveAll(ctx, dir)
+}
+
+// warmUpBuild builds the tests of every package of the copied module. A
+// failure is only logged: the mutants' runs report it.
+func (ws *workspace) warmUpBuild(ctx context.Context) {
+	output, err := ws.testAdapter.RunGoTest(ctx, string(ws.moduleDir), "./...", warmUpRun, "")
+	if err != nil && ctx.Err() == nil {
+		slog.Warn("Warm-up build failed", "moduleDir", ws.moduleDir, "error", err, "output", output)
+	}
+}
+
+// workspaceKey names the stable workspace directories of the project at root.
+func workspaceKey(root m.Path) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(root)))[:16]
+}
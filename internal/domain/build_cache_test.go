@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	adaptermocks "gooze.dev/pkg/gooze/internal/adapter/mocks"
+	m "gooze.dev/pkg/gooze/internal/model"
+)
+
+// expectCopyRun sets up a run of the test mutation in a copy of /project at
+// tmpDir, with the given test outcome.
+func expectCopyRun(ctx context.Context, fsAdapter *adaptermocks.MockSourceFSAdapter, trAdapter *adaptermocks.MockTestRunnerAdapter,
+	mutation m.Mutation, tmpDir m.Path, testErr error,
+) *mock.Call {
+	projectRoot := m.Path("/project")
+
+	fsAdapter.EXPECT().FindProjectRoot(ctx, mutation.Source.Origin.FullPath).Return(projectRoot, nil)
+	fsAdapter.EXPECT().CopyModule(ctx, projectRoot, tmpDir).Return(tmpDir, nil)
+	fsAdapter.EXPECT().RelPath(ctx, projectRoot, mutation.Source.Origin.FullPath).Return(m.Path("main.go"), nil)
+	fsAdapter.EXPECT().JoinPath(ctx, string(tmpDir), "main.go").Return(m.Path(string(tmpDir) + "/main.go"))
+	fsAdapter.EXPECT().RelPath(ctx, projectRoot, mutation.Source.Tests[0].FullPath).Return(m.Path("main_test.go"), nil)
+	fsAdapter.EXPECT().JoinPath(ctx, string(tmpDir), "main_test.go").Return(m.Path(string(tmpDir) + "/main_test.go"))
+	fsAdapter.EXPECT().ReadFile(ctx, m.Path(string(tmpDir)+"/main.go")).Return([]byte("package main\n"), nil)
+	fsAdapter.EXPECT().WriteFile(mock.Anything, m.Path(string(tmpDir)+"/main.go"), mock.Anything, os.FileMode(0o600)).Return(nil)
+
+	return trAdapter.EXPECT().RunGoTest(ctx, string(tmpDir), string(tmpDir), "", "").Return("", testErr).Once()
+}
+
+func TestOrchestrator_TestMutation_StableWorkspace(t *testing.T) {
+	fsAdapter := adaptermocks.NewMockSourceFSAdapter(t)
+	trAdapter := adaptermocks.NewMockTestRunnerAdapter(t)
+	orch := NewOrchestrator(fsAdapter, trAdapter, WithStableWorkspaces(true))
+	ctx := context.Background()
+	mutation := makeTestMutation()
+	tmpDir := m.Path("/tmp/gooze-workspaces/project/0")
+
+	// The copy goes to the project's stable slot, released afterwards.
+	fsAdapter.EXPECT().CreateWorkspaceDir(ctx, workspaceKey("/project")).Return(tmpDir, nil)
+	expectCopyRun(ctx, fsAdapter, trAdapter, mutation, tmpDir, errors.New("failed"))
+	fsAdapter.EXPECT().RemoveWorkspaceDir(mock.Anything, tmpDir).Return(nil)
+
+	result, err := orch.TestMutation(ctx, mutation)
+	require.NoError(t, err)
+	require.Equal(t, m.Killed, result[mutation.Type][0].Status)
+}
+
+func TestWorkspaceKey(t *testing.T) {
+	key := workspaceKey("/project")
+
+	require.Len(t, key, 16)
+	require.Equal(t, key, workspaceKey("/project"))
+	require.NotEqual(t, key, workspaceKey("/other"))
+}
+
+func TestOrchestrator_TestMutation_WarmUp(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		warmErr error
+	}{
+		{name: "builds the tests before the first mutant"},
+		{name: "a failed warm-up does not fail the mutant", warmErr: errors.New("build failed")},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			fsAdapter := adaptermocks.NewMockSourceFSAdapter(t)
+			trAdapter := adaptermocks.NewMockTestRunnerAdapter(t)
+			orch := NewOrchestrator(fsAdapter, trAdapter, WithWarmUp(true))
+			ctx := context.Background()
+			mutation := makeTestMutation()
+			tmpDir := m.Path("/tmp/mut")
+
+			fsAdapter.EXPECT().CreateTempDir(ctx, "gooze-mutation-*").Return(tmpDir, nil)
+			warmUp := trAdapter.EXPECT().RunGoTest(ctx, "/tmp/mut", "./...", "^$", "").Return("", tt.warmErr).Once()
+			expectCopyRun(ctx, fsAdapter, trAdapter, mutation, tmpDir, nil).NotBefore(warmUp)
+			fsAdapter.EXPECT().RemoveAll(mock.Anything, tmpDir).Return(nil)
+
+			result, err := orch.TestMutation(ctx, mutation)
+			require.NoError(t, err)
+			require.Equal(t, m.Survived, result[mutation.Type][0].Status)
+		})
+	}
+}
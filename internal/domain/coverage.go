@@ -0,0 +1,133 @@
+package domain
+
+import (
+	"bufio"
+	"bytes"
+	"strconv"
+	"strings"
+)
+
+// lineRange is an inclusive range of source lines covered by a profile block.
+type lineRange struct {
+	start int
+	end   int
+}
+
+// CoverageIndex answers whether a source line was executed according to a Go
+// coverage profile (the output of `go test -coverprofile`). Only blocks with a
+// non-zero count are recorded, so anything absent is treated as uncovered.
+type CoverageIndex struct {
+	// covered maps the profile's file path (usually an import path such as
+	// "example.com/mod/pkg/file.go") to the line ranges executed in it.
+	covered map[string][]lineRange
+}
+
+// ParseCoverage parses a Go coverage profile. Malformed block lines are skipped
+// rather than failing the whole profile.
+func ParseCoverage(profile []byte) (*CoverageIndex, error) {
+	idx := &CoverageIndex{covered: map[string][]lineRange{}}
+
+	scanner := bufio.NewScanner(bytes.NewReader(profile))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "mode:") {
+			continue
+		}
+
+		file, block, count, ok := parseCoverageBlock(line)
+		if !ok || count == 0 {
+			continue
+		}
+
+		idx.covered[file] = append(idx.covered[file], block)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return idx, nil
+}
+
+// parseCoverageBlock parses a single profile line of the form
+// "file.go:startLine.startCol,endLine.endCol numStmts count".
+func parseCoverageBlock(line string) (string, lineRange, int, bool) {
+	colon := strings.LastIndex(line, ":")
+	if colon <= 0 {
+		return "", lineRange{}, 0, false
+	}
+
+	file := line[:colon]
+
+	fields := strings.Fields(line[colon+1:])
+	if len(fields) != 3 {
+		return "", lineRange{}, 0, false
+	}
+
+	startEnd := strings.Split(fields[0], ",")
+	if len(startEnd) != 2 {
+		return "", lineRange{}, 0, false
+	}
+
+	start, ok := parseProfileLine(startEnd[0])
+	if !ok {
+		return "", lineRange{}, 0, false
+	}
+
+	end, ok := parseProfileLine(startEnd[1])
+	if !ok {
+		return "", lineRange{}, 0, false
+	}
+
+	count, err := strconv.Atoi(fields[2])
+	if err != nil {
+		return "", lineRange{}, 0, false
+	}
+
+	return file, lineRange{start: start, end: end}, count, true
+}
+
+// parseProfileLine extracts the line number from a "line.column" position.
+func parseProfileLine(position string) (int, bool) {
+	lineStr, _, found := strings.Cut(position, ".")
+	if !found {
+		return 0, false
+	}
+
+	line, err := strconv.Atoi(lineStr)
+	if err != nil {
+		return 0, false
+	}
+
+	return line, true
+}
+
+// Covers reports whether line in the file identified by shortPath (a path
+// relative to the module root) was executed. Profile paths are import paths, so
+// a file matches when its profile path ends with shortPath.
+func (c *CoverageIndex) Covers(shortPath string, line int) bool {
+	if c == nil {
+		return false
+	}
+
+	short := strings.TrimPrefix(strings.ReplaceAll(shortPath, "\\", "/"), "/")
+	if short == "" {
+		return false
+	}
+
+	for file, ranges := range c.covered {
+		if file != short && !strings.HasSuffix(file, "/"+short) {
+			continue
+		}
+
+		for _, r := range ranges {
+			if line >= r.start && line <= r.end {
+				return true
+			}
+		}
+	}
+
+	return false
+}
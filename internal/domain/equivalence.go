@@ -0,0 +1,238 @@
+package domain
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"path"
+	"slices"
+	"strings"
+
+	m "gooze.dev/pkg/gooze/internal/model"
+)
+
+// EquivalenceRule switches mutagens off inside the arguments of calls whose
+// callee matches Call. Mutants generated there are equivalent in practice
+// (log messages, capacity hints, debug output), so they are never tested.
+type EquivalenceRule struct {
+	// Call is a path.Match pattern such as "slog.*" or "metrics.Inc*". It is
+	// matched against the callee as written and each of its dotted suffixes, so
+	// "metrics.Inc*" also matches s.metrics.IncTotal.
+	Call string
+	// Args lists the (0-based) arguments the rule covers; empty covers all.
+	Args []int
+	// Mutagens names the mutagens switched off; empty switches off all of them.
+	Mutagens []string
+}
+
+// builtinEquivalenceRules are applied in addition to any configured rules.
+var builtinEquivalenceRules = []EquivalenceRule{
+	// The capacity of make([]T, len, cap) is a hint; it does not change contents.
+	{Call: "make", Args: []int{2}},
+	{Call: "log.*"},
+	{Call: "slog.*"},
+	{Call: "debug.*"},
+}
+
+// validate normalizes the rule and checks its pattern, arguments and mutagens.
+func (r EquivalenceRule) validate() (EquivalenceRule, error) {
+	r.Call = strings.TrimSpace(r.Call)
+	if r.Call == "" {
+		return EquivalenceRule{}, fmt.Errorf("equivalence rule without a call pattern")
+	}
+
+	if _, err := path.Match(r.Call, ""); err != nil {
+		return EquivalenceRule{}, fmt.Errorf("equivalence rule %q: %w", r.Call, err)
+	}
+
+	for _, arg := range r.Args {
+		if arg < 0 {
+			return EquivalenceRule{}, fmt.Errorf("equivalence rule %q: negative argument index %d", r.Call, arg)
+		}
+	}
+
+	names := make([]string, 0, len(r.Mutagens))
+
+	for _, name := range r.Mutagens {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		if !isKnownMutagen(name) {
+			return EquivalenceRule{}, fmt.Errorf("equivalence rule %q: unknown mutagen %q", r.Call, name)
+		}
+
+		names = append(names, name)
+	}
+
+	r.Mutagens = names
+
+	return r, nil
+}
+
+func isKnownMutagen(name string) bool {
+	for mutationType := range mutationGenerators {
+		if mutationType.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matches reports whether the callee name, or one of its dotted suffixes,
+// matches the rule's pattern.
+func (r EquivalenceRule) matches(callee string) bool {
+	for {
+		if ok, _ := path.Match(r.Call, callee); ok {
+			return true
+		}
+
+		_, rest, found := strings.Cut(callee, ".")
+		if !found {
+			return false
+		}
+
+		callee = rest
+	}
+}
+
+func (r EquivalenceRule) coversArg(i int) bool {
+	return len(r.Args) == 0 || slices.Contains(r.Args, i)
+}
+
+func (r EquivalenceRule) ignoreRule() ignoreRule {
+	if len(r.Mutagens) == 0 {
+		return ignoreRule{all: true}
+	}
+
+	rule := ignoreRule{names: make(map[string]struct{}, len(r.Mutagens))}
+	for _, name := range r.Mutagens {
+		rule.names[name] = struct{}{}
+	}
+
+	return rule
+}
+
+// String canonically encodes the rule, for versioning the mutagens it affects.
+func (r EquivalenceRule) String() string {
+	args := make([]string, 0, len(r.Args))
+	for _, arg := range r.Args {
+		args = append(args, fmt.Sprint(arg))
+	}
+
+	return r.Call + "(" + strings.Join(args, ",") + ")[" + strings.Join(r.Mutagens, ",") + "]"
+}
+
+// equivalenceIndex maps call arguments to the mutagens switched off inside them.
+type equivalenceIndex map[ast.Node]ignoreRule
+
+func buildEquivalenceIndex(file *ast.File, rules []EquivalenceRule) equivalenceIndex {
+	index := make(equivalenceIndex)
+	if len(rules) == 0 {
+		return index
+	}
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+
+		callee, ok := calleeName(call.Fun)
+		if !ok {
+			return true
+		}
+
+		for _, rule := range rules {
+			if !rule.matches(callee) {
+				continue
+			}
+
+			for i, arg := range call.Args {
+				if !rule.coversArg(i) {
+					continue
+				}
+
+				current := index[arg]
+				mergeIgnoreRule(&current, rule.ignoreRule())
+				index[arg] = current
+			}
+		}
+
+		return true
+	})
+
+	return index
+}
+
+// calleeName renders a call's function expression as a dotted name, e.g.
+// "s.metrics.Inc". Calls of anything else (closures, results) have no name.
+func calleeName(fun ast.Expr) (string, bool) {
+	switch expr := fun.(type) {
+	case *ast.Ident:
+		return expr.Name, true
+	case *ast.SelectorExpr:
+		x, ok := calleeName(expr.X)
+		if !ok {
+			return "", false
+		}
+
+		return x + "." + expr.Sel.Name, true
+	case *ast.IndexExpr:
+		return calleeName(expr.X)
+	case *ast.IndexListExpr:
+		return calleeName(expr.X)
+	case *ast.ParenExpr:
+		return calleeName(expr.X)
+	default:
+		return "", false
+	}
+}
+
+// isIdentitySwap reports whether an arithmetic mutation of n only swaps the
+// operator of an identity operation for its inverse, as in x*1 -> x/1 or
+// x+0 -> x-0. Both sides evaluate to x, so the mutant is equivalent.
+func isIdentitySwap(n ast.Node, fset *token.FileSet, mutation m.Mutation) bool {
+	expr, ok := n.(*ast.BinaryExpr)
+	if !ok {
+		return false
+	}
+
+	lit, ok := ast.Unparen(expr.Y).(*ast.BasicLit)
+	if !ok || (lit.Kind != token.INT && lit.Kind != token.FLOAT) {
+		return false
+	}
+
+	var identity int64
+
+	var inverse map[byte]bool
+
+	switch expr.Op {
+	case token.MUL, token.QUO:
+		identity, inverse = 1, map[byte]bool{'*': true, '/': true}
+	case token.ADD, token.SUB:
+		identity, inverse = 0, map[byte]bool{'+': true, '-': true}
+	default:
+		return false
+	}
+
+	value := constant.MakeFromLiteral(lit.Value, lit.Kind, 0)
+	if value.Kind() == constant.Unknown || !constant.Compare(value, token.EQL, constant.MakeInt64(identity)) {
+		return false
+	}
+
+	file := fset.File(expr.OpPos)
+	if file == nil {
+		return false
+	}
+
+	offset := file.Offset(expr.OpPos)
+	if offset >= len(mutation.MutatedCode) {
+		return false
+	}
+
+	return inverse[mutation.MutatedCode[offset]]
+}
@@ -0,0 +1,149 @@
+package domain
+
+import (
+	"context"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gooze.dev/pkg/gooze/internal/adapter"
+	m "gooze.dev/pkg/gooze/internal/model"
+)
+
+func TestEquivalenceRule_Matches(t *testing.T) {
+	tests := []struct {
+		pattern string
+		callee  string
+		want    bool
+	}{
+		{"slog.*", "slog.Info", true},
+		{"slog.*", "slogger.Info", false},
+		{"metrics.Inc*", "metrics.IncTotal", true},
+		{"metrics.Inc*", "s.metrics.IncTotal", true},
+		{"metrics.Inc*", "metrics.Dec", false},
+		{"make", "make", true},
+		{"make", "remake", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+"/"+tt.callee, func(t *testing.T) {
+			if got := (EquivalenceRule{Call: tt.pattern}).matches(tt.callee); got != tt.want {
+				t.Fatalf("matches(%q) = %v, want %v", tt.callee, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalleeName(t *testing.T) {
+	tests := []struct {
+		expr string
+		want string
+		ok   bool
+	}{
+		{"f()", "f", true},
+		{"a.b.C()", "a.b.C", true},
+		{"Map[int](x)", "Map", true},
+		{"(s.log)()", "s.log", true},
+		{"func() {}()", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			expr, err := parser.ParseExpr(tt.expr)
+			if err != nil {
+				t.Fatalf("failed to parse %q: %v", tt.expr, err)
+			}
+
+			got, ok := calleeName(expr.(*ast.CallExpr).Fun)
+			if got != tt.want || ok != tt.ok {
+				t.Fatalf("calleeName(%q) = %q, %v; want %q, %v", tt.expr, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestBuildEquivalenceIndex_ArgsAndMutagens(t *testing.T) {
+	const src = "package p\n\n" +
+		"func f(n int) {\n" +
+		"\t_ = make([]int, n+1, n*2)\n" +
+		"\tmetrics.IncBy(\"hits\", n+1)\n" +
+		"}\n"
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "p.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+
+	index := buildEquivalenceIndex(file, append(builtinEquivalenceRules, EquivalenceRule{Call: "metrics.Inc*", Args: []int{1}, Mutagens: []string{"numbers"}}))
+
+	covered := map[string]ignoreRule{}
+	for node, rule := range index {
+		covered[src[fset.Position(node.Pos()).Offset:fset.Position(node.End()).Offset]] = rule
+	}
+
+	if len(covered) != 2 {
+		t.Fatalf("expected 2 covered arguments, got %v", covered)
+	}
+
+	if rule := covered["n*2"]; !rule.ignores(m.MutationArithmetic) {
+		t.Errorf("expected the make capacity to be covered for every mutagen")
+	}
+
+	if rule := covered["n+1"]; !rule.ignores(m.MutationNumbers) || rule.ignores(m.MutationArithmetic) {
+		t.Errorf("expected the metrics argument to be covered for numbers only")
+	}
+}
+
+func TestMutagen_GenerateMutation_EquivalenceRules(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "go.mod"), "module example.com/noise\n\ngo 1.22\n")
+	writeTestFile(t, filepath.Join(dir, "main.go"), "package main\n\n"+
+		"import \"log/slog\"\n\n"+
+		"func scale(x, n int) []int {\n"+
+		"\tslog.Info(\"scaling\", \"by\", n+2)\n"+
+		"\tout := make([]int, 0, n*4)\n"+
+		"\treturn append(out, x*1, x-3)\n"+
+		"}\n\n"+
+		"func main() { _ = scale(1, 2) }\n")
+
+	source := makeSourceV2(t, filepath.Join(dir, "main.go"))
+
+	arithmetic, err := newTestMutagen().GenerateMutation(context.Background(), source, m.MutationArithmetic)
+	if err != nil {
+		t.Fatalf("GenerateMutation failed: %v", err)
+	}
+
+	// slog and make capacity arguments are skipped; x*1 loses its x/1 swap.
+	// That leaves x*1 -> +, -, % and x-3 -> +, *, /, %.
+	if len(arithmetic) != 7 {
+		t.Fatalf("expected 7 arithmetic mutations, got %d", len(arithmetic))
+	}
+
+	for _, mutation := range arithmetic {
+		code := string(mutation.MutatedCode)
+		if strings.Contains(code, "x/1") || !strings.Contains(code, "n+2") || !strings.Contains(code, "n*4") {
+			t.Errorf("unexpected equivalent mutation:\n%s", mutation.DiffCode)
+		}
+	}
+
+	operators, err := OperatorConfig{Rules: []EquivalenceRule{{Call: "append", Args: []int{1}, Mutagens: []string{"arithmetic"}}}}.Resolve()
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+
+	mg := NewMutagen(adapter.NewLocalGoFileAdapter(), adapter.NewLocalSourceFSAdapter(), WithOperators(operators))
+
+	arithmetic, err = mg.GenerateMutation(context.Background(), source, m.MutationArithmetic)
+	if err != nil {
+		t.Fatalf("GenerateMutation failed: %v", err)
+	}
+
+	// The configured rule also covers x*1, leaving only x-3.
+	if len(arithmetic) != 4 {
+		t.Fatalf("expected 4 arithmetic mutations with a configured rule, got %d", len(arithmetic))
+	}
+}
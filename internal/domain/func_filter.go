@@ -0,0 +1,157 @@
+package domain
+
+import (
+	"fmt"
+	"go/ast"
+	"regexp"
+	"strings"
+
+	m "gooze.dev/pkg/gooze/internal/model"
+)
+
+// FuncFilter limits mutation to functions and methods whose name matches one of
+// the Match patterns (all of them when there are none) and none of the Exclude
+// patterns. A function is named by its plain name, a method by Receiver.Method
+// or its plain name. Patterns are unanchored regular expressions, like go test
+// -run. With Match patterns, declarations outside functions are not mutated.
+type FuncFilter struct {
+	Match   []string
+	Exclude []string
+}
+
+// funcMatcher is a compiled FuncFilter.
+type funcMatcher struct {
+	match   []*regexp.Regexp
+	exclude []*regexp.Regexp
+}
+
+func (f FuncFilter) compile() (funcMatcher, error) {
+	match, err := compilePatterns(f.Match)
+	if err != nil {
+		return funcMatcher{}, fmt.Errorf("invalid function pattern: %w", err)
+	}
+
+	exclude, err := compilePatterns(f.Exclude)
+	if err != nil {
+		return funcMatcher{}, fmt.Errorf("invalid excluded function pattern: %w", err)
+	}
+
+	return funcMatcher{match: match, exclude: exclude}, nil
+}
+
+func compilePatterns(patterns []string) ([]*regexp.Regexp, error) {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+
+	for _, pattern := range patterns {
+		if pattern == "" {
+			continue
+		}
+
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, err
+		}
+
+		compiled = append(compiled, re)
+	}
+
+	return compiled, nil
+}
+
+// stamp records the filter on the report.
+func (f FuncFilter) stamp(report m.Report) m.Report {
+	report.Funcs = m.FuncSelection(f)
+
+	return report
+}
+
+// includes reports whether mutations may be generated in the top-level
+// declaration decl.
+func (f funcMatcher) includes(decl ast.Node) bool {
+	return f.includesFunc(funcName(decl))
+}
+
+// includesFunc reports whether mutations may be generated in the function
+// named name by funcName.
+func (f funcMatcher) includesFunc(name string) bool {
+	if name == "" {
+		return len(f.match) == 0
+	}
+
+	names := funcNames(name)
+
+	if len(f.match) > 0 && !matchesAny(f.match, names) {
+		return false
+	}
+
+	return !matchesAny(f.exclude, names)
+}
+
+// covers reports whether the report is for a mutant the filter would test.
+func (f funcMatcher) covers(report m.Report) bool {
+	return f.includesFunc(report.Func)
+}
+
+// funcName names the function decl as a filter matches it: Receiver.Method for
+// a method, its name for a plain function. It is "" for other declarations.
+func funcName(decl ast.Node) string {
+	fn, ok := decl.(*ast.FuncDecl)
+	if !ok {
+		return ""
+	}
+
+	recv := receiverName(fn)
+	if recv == "" {
+		return fn.Name.Name
+	}
+
+	return recv + "." + fn.Name.Name
+}
+
+// funcNames returns the names the function named name by funcName can be
+// selected by: that name and, for a method, its plain name.
+func funcNames(name string) []string {
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		return []string{name, name[i+1:]}
+	}
+
+	return []string{name}
+}
+
+// receiverName returns the receiver type name of a method without pointer or
+// type parameters, or "" for a plain function.
+func receiverName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return ""
+	}
+
+	recv := fn.Recv.List[0].Type
+	if star, ok := recv.(*ast.StarExpr); ok {
+		recv = star.X
+	}
+
+	switch typ := recv.(type) {
+	case *ast.IndexExpr:
+		recv = typ.X
+	case *ast.IndexListExpr:
+		recv = typ.X
+	}
+
+	if ident, ok := recv.(*ast.Ident); ok {
+		return ident.Name
+	}
+
+	return ""
+}
+
+func matchesAny(patterns []*regexp.Regexp, names []string) bool {
+	for _, re := range patterns {
+		for _, name := range names {
+			if re.MatchString(name) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
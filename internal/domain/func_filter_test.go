@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"context"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	m "gooze.dev/pkg/gooze/internal/model"
+)
+
+func TestFuncFilter_Includes(t *testing.T) {
+	src := "package p\n\nvar v = 1\n\nfunc Parse() {}\n\nfunc (c *Config) Parse() {}\n\nfunc (l List[T]) Len() int { return 0 }\n\nfunc helper() {}\n"
+
+	file, err := parser.ParseFile(token.NewFileSet(), "p.go", src, 0)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		filter FuncFilter
+		want   []bool // v, Parse, Config.Parse, List.Len, helper
+	}{
+		{name: "no filter", filter: FuncFilter{}, want: []bool{true, true, true, true, true}},
+		{name: "plain name matches functions and methods", filter: FuncFilter{Match: []string{"^Parse$"}}, want: []bool{false, true, true, false, false}},
+		{name: "receiver-qualified name", filter: FuncFilter{Match: []string{`^Config\.`}}, want: []bool{false, false, true, false, false}},
+		{name: "generic receiver", filter: FuncFilter{Match: []string{`List\.Len`}}, want: []bool{false, false, false, true, false}},
+		{name: "exclude", filter: FuncFilter{Exclude: []string{"Parse"}}, want: []bool{true, false, false, true, true}},
+		{name: "match and exclude", filter: FuncFilter{Match: []string{"Parse"}, Exclude: []string{`Config\.`}}, want: []bool{false, true, false, false, false}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matcher, err := tt.filter.compile()
+			if err != nil {
+				t.Fatalf("compile: %v", err)
+			}
+
+			for i, decl := range file.Decls {
+				if got := matcher.includes(decl); got != tt.want[i] {
+					t.Errorf("decl %d (%s): includes = %v, want %v", i, declName(decl), got, tt.want[i])
+				}
+
+				// Stored reports are matched by the function name they record.
+				if got := matcher.covers(m.Report{Func: funcName(decl)}); got != tt.want[i] {
+					t.Errorf("decl %d (%s): covers = %v, want %v", i, declName(decl), got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestFuncFilter_InvalidPattern(t *testing.T) {
+	if _, err := (FuncFilter{Match: []string{"("}}).compile(); err == nil {
+		t.Fatal("expected an error for an invalid pattern")
+	}
+
+	if _, err := (FuncFilter{Exclude: []string{"["}}).compile(); err == nil {
+		t.Fatal("expected an error for an invalid excluded pattern")
+	}
+}
+
+func TestMutagen_StreamMutations_FuncFilter(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "go.mod"), "module example.com/funcs\n\ngo 1.22\n")
+	writeTestFile(t, filepath.Join(dir, "main.go"), "package main\n\nfunc add(a, b int) int { return a + b }\n\nfunc sub(a, b int) int { return a - b }\n\nfunc main() { _ = add(1, 2) + sub(3, 4) }\n")
+
+	mg := newTestMutagen()
+	source := makeSourceV2(t, filepath.Join(dir, "main.go"))
+
+	var mutations []m.Mutation
+
+	err := mg.StreamMutations(context.Background(), source, FuncFilter{Match: []string{"^sub$"}}, func(mutation m.Mutation) error {
+		mutations = append(mutations, mutation)
+		return nil
+	}, m.MutationArithmetic)
+	if err != nil {
+		t.Fatalf("StreamMutations failed: %v", err)
+	}
+
+	if len(mutations) == 0 {
+		t.Fatal("expected mutations in sub")
+	}
+
+	for _, mutation := range mutations {
+		if !strings.Contains(string(mutation.MutatedCode), "return a + b }") || !strings.Contains(string(mutation.MutatedCode), "add(1, 2) + sub") {
+			t.Fatalf("expected only sub to be mutated, got:\n%s", mutation.DiffCode)
+		}
+
+		if mutation.Func != "sub" {
+			t.Fatalf("expected the mutation to record its function, got %q", mutation.Func)
+		}
+	}
+
+	err = mg.StreamMutations(context.Background(), source, FuncFilter{Match: []string{"("}}, func(m.Mutation) error { return nil }, m.MutationArithmetic)
+	if err == nil {
+		t.Fatal("expected an error for an invalid pattern")
+	}
+}
+
+func declName(decl ast.Decl) string {
+	if fn, ok := decl.(*ast.FuncDecl); ok {
+		return funcDeclName(fn)
+	}
+
+	return "var"
+}
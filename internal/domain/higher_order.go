@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"gooze.dev/pkg/gooze/internal/adapter"
+	"gooze.dev/pkg/gooze/internal/domain/mutagens"
+	m "gooze.dev/pkg/gooze/internal/model"
+)
+
+// higherOrderMutations combines the first-order mutations of each top-level
+// declaration into higher-order mutations of up to order constituents, in
+// generation order. A mutation that overlaps every open group, or is left
+// alone at the end, stays first-order; so do the constituents of a combination
+// that does not type-check.
+func (mg *mutagen) higherOrderMutations(ctx context.Context, pkg *adapter.TypedPackage, content []byte, mutations []m.Mutation, order int) []m.Mutation {
+	var (
+		out    []m.Mutation
+		scopes []string
+	)
+
+	groups := map[string][][]m.Mutation{}
+
+	for _, mutation := range mutations {
+		if mutation.Scope == "" {
+			out = append(out, mutation)
+			continue
+		}
+
+		if _, ok := groups[mutation.Scope]; !ok {
+			scopes = append(scopes, mutation.Scope)
+		}
+
+		groups[mutation.Scope] = addToGroup(content, groups[mutation.Scope], mutation, order)
+	}
+
+	for _, scope := range scopes {
+		for _, group := range groups[scope] {
+			out = append(out, mg.combine(ctx, pkg, content, group)...)
+		}
+	}
+
+	return out
+}
+
+// addToGroup adds mutation to the first group that is not full and that it
+// does not overlap, or starts a new group.
+func addToGroup(content []byte, groups [][]m.Mutation, mutation m.Mutation, order int) [][]m.Mutation {
+	for i, group := range groups {
+		if len(group) < order && !overlapsAny(content, group, mutation) {
+			groups[i] = append(group, mutation)
+			return groups
+		}
+	}
+
+	return append(groups, []m.Mutation{mutation})
+}
+
+func overlapsAny(content []byte, group []m.Mutation, mutation m.Mutation) bool {
+	for _, member := range group {
+		if mutagens.Overlaps(content, member, mutation) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// combine turns a group of first-order mutations into one higher-order
+// mutation, or returns the group unchanged when it cannot be combined. The
+// duplicates of the constituents are dropped with them: they hold first-order
+// code, so the higher-order result says nothing about them.
+func (mg *mutagen) combine(ctx context.Context, pkg *adapter.TypedPackage, content []byte, group []m.Mutation) []m.Mutation {
+	if len(group) < 2 {
+		return group
+	}
+
+	mutated, diff, ok := mutagens.CombineMutations(content, group)
+	if !ok {
+		return group
+	}
+
+	if pkg != nil {
+		if err := mg.TypeCheck(ctx, pkg, mutated); err != nil {
+			slog.Debug("Keeping first-order mutations whose combination does not type-check", "file", group[0].Source.Origin.FullPath, "error", err)
+			return group
+		}
+	}
+
+	constituents := make([]string, 0, len(group))
+	line := group[0].Line
+
+	for _, mutation := range group {
+		constituents = append(constituents, mutation.ID)
+		line = min(line, mutation.Line)
+	}
+
+	return []m.Mutation{{
+		ID:           higherOrderID(constituents),
+		Source:       group[0].Source,
+		Type:         m.MutationHigherOrder,
+		MutatedCode:  mutated,
+		DiffCode:     diff,
+		Line:         line,
+		Scope:        group[0].Scope,
+		Func:         group[0].Func,
+		Constituents: constituents,
+	}}
+}
+
+// higherOrderID derives the ID of a higher-order mutation from the stable IDs
+// of its constituents.
+func higherOrderID(constituents []string) string {
+	h := sha256.Sum256([]byte(m.MutationHigherOrder.Name + "\x00" + strings.Join(constituents, "\x00")))
+
+	return fmt.Sprintf("%x", h)
+}
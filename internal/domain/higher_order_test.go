@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gooze.dev/pkg/gooze/internal/adapter"
+	m "gooze.dev/pkg/gooze/internal/model"
+)
+
+func TestMutagen_GenerateMutation_HigherOrder(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "go.mod"), "module example.com/hom\n\ngo 1.22\n")
+	writeTestFile(t, filepath.Join(dir, "main.go"), "package main\n\nfunc add(a, b int) int { return a + b }\n\nfunc calc(a, b int) int { return a*b - a }\n\nfunc main() { _ = add(1, 2) + calc(3, 4) }\n")
+
+	operators, err := OperatorConfig{HigherOrder: 2}.Resolve()
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+
+	mg := NewMutagen(adapter.NewLocalGoFileAdapter(), adapter.NewLocalSourceFSAdapter(), WithOperators(operators))
+	source := makeSourceV2(t, filepath.Join(dir, "main.go"))
+
+	firstOrder, err := newTestMutagen().GenerateMutation(context.Background(), source, m.MutationArithmetic)
+	if err != nil {
+		t.Fatalf("GenerateMutation (first order) failed: %v", err)
+	}
+
+	byID := map[string]m.Mutation{}
+	for _, mutation := range firstOrder {
+		byID[mutation.ID] = mutation
+	}
+
+	mutations, err := mg.GenerateMutation(context.Background(), source, m.MutationArithmetic)
+	if err != nil {
+		t.Fatalf("GenerateMutation failed: %v", err)
+	}
+
+	if len(mutations) >= len(firstOrder) {
+		t.Fatalf("expected fewer mutants than the %d first-order ones, got %d", len(firstOrder), len(mutations))
+	}
+
+	higherOrder := 0
+
+	for _, mutation := range mutations {
+		if mutation.Type != m.MutationHigherOrder {
+			if !strings.Contains(string(mutation.MutatedCode), "a*b - a") {
+				t.Errorf("expected only the single operator of add to stay first-order, got:\n%s", mutation.DiffCode)
+			}
+
+			continue
+		}
+
+		higherOrder++
+
+		if len(mutation.Constituents) != 2 {
+			t.Fatalf("expected 2 constituents, got %v", mutation.Constituents)
+		}
+
+		code := string(mutation.MutatedCode)
+		if !strings.Contains(code, "return a + b") || strings.Contains(code, "*b") || strings.Contains(code, "b - a") {
+			t.Errorf("expected both operators of calc and none of add to be mutated, got:\n%s", mutation.DiffCode)
+		}
+
+		for _, id := range mutation.Constituents {
+			if _, ok := byID[id]; !ok {
+				t.Errorf("constituent %s is not a first-order mutation ID", id)
+			}
+		}
+
+		if !strings.Contains(string(mutation.DiffCode), "+++ mutated") {
+			t.Errorf("expected a combined diff, got:\n%s", mutation.DiffCode)
+		}
+	}
+
+	if higherOrder == 0 {
+		t.Fatal("expected higher-order mutations")
+	}
+}
+
+func TestCombine_KeepsOverlappingMutationsFirstOrder(t *testing.T) {
+	content := []byte("package p\n\nvar x = 1 + 2\n")
+	a := m.Mutation{ID: "a", MutatedCode: []byte("package p\n\nvar x = 1 - 2\n")}
+	b := m.Mutation{ID: "b", MutatedCode: []byte("package p\n\nvar x = 1 * 2\n")}
+
+	groups := addToGroup(content, addToGroup(content, nil, a, 2), b, 2)
+	if len(groups) != 2 {
+		t.Fatalf("expected overlapping mutations in separate groups, got %d", len(groups))
+	}
+
+	mg := newTestMutagen().(*mutagen)
+	if got := mg.combine(context.Background(), nil, content, groups[0]); len(got) != 1 || got[0].ID != "a" {
+		t.Fatalf("expected a single mutation to stay first-order, got %+v", got)
+	}
+}
+
+func TestCombine_DropsDuplicatesOfConstituents(t *testing.T) {
+	content := []byte("package p\n\nvar x, y = 1 + 2, 3 + 4\n")
+	a := m.Mutation{ID: "a", MutatedCode: []byte("package p\n\nvar x, y = 1 - 2, 3 + 4\n")}
+	b := m.Mutation{ID: "b", MutatedCode: []byte("package p\n\nvar x, y = 1 + 2, 3 - 4\n")}
+	b.Duplicates = []m.Mutation{{ID: "b2", Type: m.MutationNumbers, MutatedCode: b.MutatedCode, DuplicateOf: "b"}}
+
+	mg := newTestMutagen().(*mutagen)
+
+	got := mg.combine(context.Background(), nil, content, []m.Mutation{a, b})
+	if len(got) != 1 || got[0].Type != m.MutationHigherOrder {
+		t.Fatalf("expected one higher-order mutation, got %+v", got)
+	}
+
+	if len(got[0].Duplicates) != 0 {
+		t.Fatalf("expected the constituents' duplicates to be dropped, got %+v", got[0].Duplicates)
+	}
+}
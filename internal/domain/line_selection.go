@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"sort"
+
+	m "gooze.dev/pkg/gooze/internal/model"
+)
+
+// LineSelection limits mutation to line ranges of the files it names, keyed by
+// absolute path. Files it does not name are not mutated. A nil selection
+// mutates every line of every file.
+type LineSelection map[m.Path][]m.LineRange
+
+// Enabled reports whether the selection limits mutation at all.
+func (s LineSelection) Enabled() bool {
+	return s != nil
+}
+
+// Add selects the lines of r in the file at path.
+func (s LineSelection) Add(path m.Path, r m.LineRange) {
+	s[path] = append(s[path], r)
+}
+
+// sources keeps the sources whose file is selected.
+func (s LineSelection) sources(sources []m.Source) []m.Source {
+	if !s.Enabled() {
+		return sources
+	}
+
+	selected := make([]m.Source, 0, len(sources))
+
+	for _, source := range sources {
+		if source.Origin != nil && len(s[source.Origin.FullPath]) > 0 {
+			selected = append(selected, source)
+		}
+	}
+
+	return selected
+}
+
+// files returns a source for each selected file, ordered by path.
+func (s LineSelection) files() []m.Source {
+	paths := make([]string, 0, len(s))
+	for path := range s {
+		paths = append(paths, string(path))
+	}
+
+	sort.Strings(paths)
+
+	files := make([]m.Source, 0, len(paths))
+	for _, path := range paths {
+		files = append(files, m.Source{Origin: &m.File{FullPath: m.Path(path)}})
+	}
+
+	return files
+}
+
+// includes reports whether the mutation is on a selected line.
+func (s LineSelection) includes(mutation m.Mutation) bool {
+	if !s.Enabled() {
+		return true
+	}
+
+	if mutation.Source.Origin == nil {
+		return false
+	}
+
+	return containsLine(s[mutation.Source.Origin.FullPath], mutation.Line)
+}
+
+// covers reports whether the report is for a mutant the selection would test:
+// one on a selected line of a selected file. Reports without a line cannot be
+// placed and are never covered.
+func (s LineSelection) covers(report m.Report) bool {
+	if report.Source.Origin == nil || report.Line == 0 {
+		return false
+	}
+
+	return containsLine(s[report.Source.Origin.FullPath], report.Line)
+}
+
+// stamp records the selected ranges of the report's file on the report.
+func (s LineSelection) stamp(report m.Report) m.Report {
+	if s.Enabled() && report.Source.Origin != nil {
+		report.Selection = s[report.Source.Origin.FullPath]
+	}
+
+	return report
+}
+
+// reportSelection collects the selections the reports were produced under.
+// It is nil when none of them was produced under a selection.
+func reportSelection(reports []m.Report) LineSelection {
+	var selection LineSelection
+
+	for _, report := range reports {
+		if len(report.Selection) == 0 || report.Source.Origin == nil {
+			continue
+		}
+
+		if selection == nil {
+			selection = LineSelection{}
+		}
+
+		path := report.Source.Origin.FullPath
+		if _, ok := selection[path]; !ok {
+			selection[path] = report.Selection
+		}
+	}
+
+	return selection
+}
+
+func containsLine(ranges []m.LineRange, line int) bool {
+	for _, r := range ranges {
+		if r.Contains(line) {
+			return true
+		}
+	}
+
+	return false
+}
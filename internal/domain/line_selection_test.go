@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	m "gooze.dev/pkg/gooze/internal/model"
+)
+
+func TestLineSelection_Includes(t *testing.T) {
+	foo := m.Source{Origin: &m.File{FullPath: "/abs/foo.go"}}
+	bar := m.Source{Origin: &m.File{FullPath: "/abs/bar.go"}}
+
+	selection := LineSelection{}
+	selection.Add("/abs/foo.go", m.LineRange{Start: 40, End: 80})
+	selection.Add("/abs/foo.go", m.LineRange{Start: 100, End: 100})
+
+	assert.True(t, selection.includes(m.Mutation{Source: foo, Line: 40}))
+	assert.True(t, selection.includes(m.Mutation{Source: foo, Line: 80}))
+	assert.True(t, selection.includes(m.Mutation{Source: foo, Line: 100}))
+	assert.False(t, selection.includes(m.Mutation{Source: foo, Line: 81}))
+	assert.False(t, selection.includes(m.Mutation{Source: bar, Line: 50}), "unselected files are not mutated")
+
+	var none LineSelection
+	assert.True(t, none.includes(m.Mutation{Source: bar, Line: 50}), "a nil selection mutates everything")
+}
+
+func TestLineSelection_Sources(t *testing.T) {
+	foo := m.Source{Origin: &m.File{FullPath: "/abs/foo.go"}}
+	bar := m.Source{Origin: &m.File{FullPath: "/abs/bar.go"}}
+
+	selection := LineSelection{"/abs/foo.go": {{Start: 1, End: 2}}}
+
+	assert.Equal(t, []m.Source{foo}, selection.sources([]m.Source{foo, bar}))
+	assert.Empty(t, LineSelection{}.sources([]m.Source{foo, bar}), "an empty selection selects nothing")
+
+	var none LineSelection
+	assert.Equal(t, []m.Source{foo, bar}, none.sources([]m.Source{foo, bar}))
+}
+
+func TestReportSelection_Covers(t *testing.T) {
+	foo := m.Source{Origin: &m.File{FullPath: "/abs/foo.go"}}
+	bar := m.Source{Origin: &m.File{FullPath: "/abs/bar.go"}}
+
+	selection := reportSelection([]m.Report{
+		{Source: foo, Line: 42, Selection: []m.LineRange{{Start: 40, End: 80}}},
+		{Source: bar, Line: 7},
+	})
+
+	assert.True(t, selection.covers(m.Report{Source: foo, Line: 50}))
+	assert.False(t, selection.covers(m.Report{Source: foo, Line: 10}), "lines outside the selection are not covered")
+	assert.False(t, selection.covers(m.Report{Source: foo}), "reports without a line are never covered")
+	assert.False(t, selection.covers(m.Report{Source: bar, Line: 7}), "files run without a selection are not covered")
+
+	assert.Nil(t, reportSelection([]m.Report{{Source: bar, Line: 7}}))
+}
@@ -6,6 +6,7 @@ import (
 	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+	domain "gooze.dev/pkg/gooze/internal/domain"
 	model "gooze.dev/pkg/gooze/internal/model"
 )
 
@@ -96,14 +97,14 @@ func (_c *MockMutagen_GenerateMutation_Call) RunAndReturn(run func(context.Conte
 	return _c
 }
 
-// StreamMutations provides a mock function with given fields: ctx, source, fn, mutationTypes
-func (_m *MockMutagen) StreamMutations(ctx context.Context, source model.Source, fn func(model.Mutation) error, mutationTypes ...model.MutationType) error {
+// StreamMutations provides a mock function with given fields: ctx, source, funcs, fn, mutationTypes
+func (_m *MockMutagen) StreamMutations(ctx context.Context, source model.Source, funcs domain.FuncFilter, fn func(model.Mutation) error, mutationTypes ...model.MutationType) error {
 	_va := make([]interface{}, len(mutationTypes))
 	for _i := range mutationTypes {
 		_va[_i] = mutationTypes[_i]
 	}
 	var _ca []interface{}
-	_ca = append(_ca, ctx, source, fn)
+	_ca = append(_ca, ctx, source, funcs, fn)
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
@@ -112,8 +113,8 @@ func (_m *MockMutagen) StreamMutations(ctx context.Context, source model.Source,
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, model.Source, func(model.Mutation) error, ...model.MutationType) error); ok {
-		r0 = rf(ctx, source, fn, mutationTypes...)
+	if rf, ok := ret.Get(0).(func(context.Context, model.Source, domain.FuncFilter, func(model.Mutation) error, ...model.MutationType) error); ok {
+		r0 = rf(ctx, source, funcs, fn, mutationTypes...)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -129,22 +130,23 @@ type MockMutagen_StreamMutations_Call struct {
 // StreamMutations is a helper method to define mock.On call
 //   - ctx context.Context
 //   - source model.Source
+//   - funcs domain.FuncFilter
 //   - fn func(model.Mutation) error
 //   - mutationTypes ...model.MutationType
-func (_e *MockMutagen_Expecter) StreamMutations(ctx interface{}, source interface{}, fn interface{}, mutationTypes ...interface{}) *MockMutagen_StreamMutations_Call {
+func (_e *MockMutagen_Expecter) StreamMutations(ctx interface{}, source interface{}, funcs interface{}, fn interface{}, mutationTypes ...interface{}) *MockMutagen_StreamMutations_Call {
 	return &MockMutagen_StreamMutations_Call{Call: _e.mock.On("StreamMutations",
-		append([]interface{}{ctx, source, fn}, mutationTypes...)...)}
+		append([]interface{}{ctx, source, funcs, fn}, mutationTypes...)...)}
 }
 
-func (_c *MockMutagen_StreamMutations_Call) Run(run func(ctx context.Context, source model.Source, fn func(model.Mutation) error, mutationTypes ...model.MutationType)) *MockMutagen_StreamMutations_Call {
+func (_c *MockMutagen_StreamMutations_Call) Run(run func(ctx context.Context, source model.Source, funcs domain.FuncFilter, fn func(model.Mutation) error, mutationTypes ...model.MutationType)) *MockMutagen_StreamMutations_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		variadicArgs := make([]model.MutationType, len(args)-3)
-		for i, a := range args[3:] {
+		variadicArgs := make([]model.MutationType, len(args)-4)
+		for i, a := range args[4:] {
 			if a != nil {
 				variadicArgs[i] = a.(model.MutationType)
 			}
 		}
-		run(args[0].(context.Context), args[1].(model.Source), args[2].(func(model.Mutation) error), variadicArgs...)
+		run(args[0].(context.Context), args[1].(model.Source), args[2].(domain.FuncFilter), args[3].(func(model.Mutation) error), variadicArgs...)
 	})
 	return _c
 }
@@ -154,7 +156,7 @@ func (_c *MockMutagen_StreamMutations_Call) Return(_a0 error) *MockMutagen_Strea
 	return _c
 }
 
-func (_c *MockMutagen_StreamMutations_Call) RunAndReturn(run func(context.Context, model.Source, func(model.Mutation) error, ...model.MutationType) error) *MockMutagen_StreamMutations_Call {
+func (_c *MockMutagen_StreamMutations_Call) RunAndReturn(run func(context.Context, model.Source, domain.FuncFilter, func(model.Mutation) error, ...model.MutationType) error) *MockMutagen_StreamMutations_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -23,6 +23,124 @@ func (_m *MockOrchestrator) EXPECT() *MockOrchestrator_Expecter {
 	return &MockOrchestrator_Expecter{mock: &_m.Mock}
 }
 
+// Baseline provides a mock function with given fields: ctx, sources, cover, reruns
+func (_m *MockOrchestrator) Baseline(ctx context.Context, sources []model.Source, cover bool, reruns int) (domain.Baseline, error) {
+	ret := _m.Called(ctx, sources, cover, reruns)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Baseline")
+	}
+
+	var r0 domain.Baseline
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []model.Source, bool, int) (domain.Baseline, error)); ok {
+		return rf(ctx, sources, cover, reruns)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []model.Source, bool, int) domain.Baseline); ok {
+		r0 = rf(ctx, sources, cover, reruns)
+	} else {
+		r0 = ret.Get(0).(domain.Baseline)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []model.Source, bool, int) error); ok {
+		r1 = rf(ctx, sources, cover, reruns)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockOrchestrator_Baseline_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Baseline'
+type MockOrchestrator_Baseline_Call struct {
+	*mock.Call
+}
+
+// Baseline is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sources []model.Source
+//   - cover bool
+//   - reruns int
+func (_e *MockOrchestrator_Expecter) Baseline(ctx interface{}, sources interface{}, cover interface{}, reruns interface{}) *MockOrchestrator_Baseline_Call {
+	return &MockOrchestrator_Baseline_Call{Call: _e.mock.On("Baseline", ctx, sources, cover, reruns)}
+}
+
+func (_c *MockOrchestrator_Baseline_Call) Run(run func(ctx context.Context, sources []model.Source, cover bool, reruns int)) *MockOrchestrator_Baseline_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]model.Source), args[2].(bool), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *MockOrchestrator_Baseline_Call) Return(_a0 domain.Baseline, _a1 error) *MockOrchestrator_Baseline_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockOrchestrator_Baseline_Call) RunAndReturn(run func(context.Context, []model.Source, bool, int) (domain.Baseline, error)) *MockOrchestrator_Baseline_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MapTestCoverage provides a mock function with given fields: ctx, sources
+func (_m *MockOrchestrator) MapTestCoverage(ctx context.Context, sources []model.Source) (domain.TestCoverage, error) {
+	ret := _m.Called(ctx, sources)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MapTestCoverage")
+	}
+
+	var r0 domain.TestCoverage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []model.Source) (domain.TestCoverage, error)); ok {
+		return rf(ctx, sources)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []model.Source) domain.TestCoverage); ok {
+		r0 = rf(ctx, sources)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(domain.TestCoverage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []model.Source) error); ok {
+		r1 = rf(ctx, sources)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockOrchestrator_MapTestCoverage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MapTestCoverage'
+type MockOrchestrator_MapTestCoverage_Call struct {
+	*mock.Call
+}
+
+// MapTestCoverage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sources []model.Source
+func (_e *MockOrchestrator_Expecter) MapTestCoverage(ctx interface{}, sources interface{}) *MockOrchestrator_MapTestCoverage_Call {
+	return &MockOrchestrator_MapTestCoverage_Call{Call: _e.mock.On("MapTestCoverage", ctx, sources)}
+}
+
+func (_c *MockOrchestrator_MapTestCoverage_Call) Run(run func(ctx context.Context, sources []model.Source)) *MockOrchestrator_MapTestCoverage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]model.Source))
+	})
+	return _c
+}
+
+func (_c *MockOrchestrator_MapTestCoverage_Call) Return(_a0 domain.TestCoverage, _a1 error) *MockOrchestrator_MapTestCoverage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockOrchestrator_MapTestCoverage_Call) RunAndReturn(run func(context.Context, []model.Source) (domain.TestCoverage, error)) *MockOrchestrator_MapTestCoverage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewWorkspace provides a mock function with no fields
 func (_m *MockOrchestrator) NewWorkspace() domain.Workspace {
 	ret := _m.Called()
@@ -7,6 +7,8 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 	model "gooze.dev/pkg/gooze/internal/model"
+
+	time "time"
 )
 
 // MockWorkspace is an autogenerated mock type for the Workspace type
@@ -55,6 +57,131 @@ func (_c *MockWorkspace_Close_Call) RunAndReturn(run func(context.Context)) *Moc
 	return _c
 }
 
+// Equivalent provides a mock function with given fields: ctx, mutation
+func (_m *MockWorkspace) Equivalent(ctx context.Context, mutation model.Mutation) (bool, error) {
+	ret := _m.Called(ctx, mutation)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Equivalent")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.Mutation) (bool, error)); ok {
+		return rf(ctx, mutation)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.Mutation) bool); ok {
+		r0 = rf(ctx, mutation)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.Mutation) error); ok {
+		r1 = rf(ctx, mutation)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWorkspace_Equivalent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Equivalent'
+type MockWorkspace_Equivalent_Call struct {
+	*mock.Call
+}
+
+// Equivalent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - mutation model.Mutation
+func (_e *MockWorkspace_Expecter) Equivalent(ctx interface{}, mutation interface{}) *MockWorkspace_Equivalent_Call {
+	return &MockWorkspace_Equivalent_Call{Call: _e.mock.On("Equivalent", ctx, mutation)}
+}
+
+func (_c *MockWorkspace_Equivalent_Call) Run(run func(ctx context.Context, mutation model.Mutation)) *MockWorkspace_Equivalent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(model.Mutation))
+	})
+	return _c
+}
+
+func (_c *MockWorkspace_Equivalent_Call) Return(_a0 bool, _a1 error) *MockWorkspace_Equivalent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWorkspace_Equivalent_Call) RunAndReturn(run func(context.Context, model.Mutation) (bool, error)) *MockWorkspace_Equivalent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Recheck provides a mock function with given fields: ctx, mutation, runs, timeout
+func (_m *MockWorkspace) Recheck(ctx context.Context, mutation model.Mutation, runs int, timeout time.Duration) ([]string, bool, error) {
+	ret := _m.Called(ctx, mutation, runs, timeout)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Recheck")
+	}
+
+	var r0 []string
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.Mutation, int, time.Duration) ([]string, bool, error)); ok {
+		return rf(ctx, mutation, runs, timeout)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.Mutation, int, time.Duration) []string); ok {
+		r0 = rf(ctx, mutation, runs, timeout)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.Mutation, int, time.Duration) bool); ok {
+		r1 = rf(ctx, mutation, runs, timeout)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, model.Mutation, int, time.Duration) error); ok {
+		r2 = rf(ctx, mutation, runs, timeout)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockWorkspace_Recheck_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Recheck'
+type MockWorkspace_Recheck_Call struct {
+	*mock.Call
+}
+
+// Recheck is a helper method to define mock.On call
+//   - ctx context.Context
+//   - mutation model.Mutation
+//   - runs int
+//   - timeout time.Duration
+func (_e *MockWorkspace_Expecter) Recheck(ctx interface{}, mutation interface{}, runs interface{}, timeout interface{}) *MockWorkspace_Recheck_Call {
+	return &MockWorkspace_Recheck_Call{Call: _e.mock.On("Recheck", ctx, mutation, runs, timeout)}
+}
+
+func (_c *MockWorkspace_Recheck_Call) Run(run func(ctx context.Context, mutation model.Mutation, runs int, timeout time.Duration)) *MockWorkspace_Recheck_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(model.Mutation), args[2].(int), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockWorkspace_Recheck_Call) Return(tests []string, flaky bool, err error) *MockWorkspace_Recheck_Call {
+	_c.Call.Return(tests, flaky, err)
+	return _c
+}
+
+func (_c *MockWorkspace_Recheck_Call) RunAndReturn(run func(context.Context, model.Mutation, int, time.Duration) ([]string, bool, error)) *MockWorkspace_Recheck_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Run provides a mock function with given fields: ctx, mutation
 func (_m *MockWorkspace) Run(ctx context.Context, mutation model.Mutation) (model.Result, error) {
 	ret := _m.Called(ctx, mutation)
@@ -4,9 +4,13 @@ package domain
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"fmt"
 	"go/ast"
 	"go/token"
+	"go/types"
+	"log/slog"
+	"slices"
 
 	"gooze.dev/pkg/gooze/internal/adapter"
 	"gooze.dev/pkg/gooze/internal/domain/mutagens"
@@ -17,30 +21,57 @@ import (
 type Mutagen interface {
 	// GenerateMutation returns every mutation for a source as a slice.
 	GenerateMutation(ctx context.Context, source m.Source, mutationTypes ...m.MutationType) ([]m.Mutation, error)
-	// StreamMutations invokes fn for each generated mutation without retaining
-	// them, so callers can process mutations one at a time. If fn returns an
-	// error, streaming stops and that error is returned.
-	StreamMutations(ctx context.Context, source m.Source, fn func(m.Mutation) error, mutationTypes ...m.MutationType) error
+	// StreamMutations invokes fn for each mutation generated in the functions
+	// funcs selects, retaining only one file's mutations at a time. Mutations
+	// whose code duplicates an earlier one are not passed to fn but attached to
+	// it as Duplicates. If fn returns an error, streaming stops and that error is
+	// returned.
+	StreamMutations(ctx context.Context, source m.Source, funcs FuncFilter, fn func(m.Mutation) error, mutationTypes ...m.MutationType) error
 }
 
 // mutagen handles pure mutation generation logic.
 type mutagen struct {
 	adapter.GoFileAdapter
 	adapter.SourceFSAdapter
+
+	operators  OperatorConfig
+	generators map[m.MutationType]mutagens.Generator
+	rules      []EquivalenceRule
+	schemata   bool
+}
+
+// MutagenOption configures a Mutagen created by NewMutagen.
+type MutagenOption func(*mutagen)
+
+// WithOperators runs the mutagens with the given (resolved) operator config.
+// Generated mutations carry the effective mutation type for that config.
+func WithOperators(operators OperatorConfig) MutagenOption {
+	return func(mg *mutagen) {
+		mg.operators = operators
+	}
 }
 
 // NewMutagen creates a new Mutagen instance.
-func NewMutagen(goFileAdapter adapter.GoFileAdapter, sourceFSAdapter adapter.SourceFSAdapter) Mutagen {
-	return &mutagen{
+func NewMutagen(goFileAdapter adapter.GoFileAdapter, sourceFSAdapter adapter.SourceFSAdapter, opts ...MutagenOption) Mutagen {
+	mg := &mutagen{
 		GoFileAdapter:   goFileAdapter,
 		SourceFSAdapter: sourceFSAdapter,
 	}
+
+	for _, opt := range opts {
+		opt(mg)
+	}
+
+	mg.generators = mg.operators.generators()
+	mg.rules = append(slices.Clone(builtinEquivalenceRules), mg.operators.Rules...)
+
+	return mg
 }
 
 func (mg *mutagen) GenerateMutation(ctx context.Context, source m.Source, mutationTypes ...m.MutationType) ([]m.Mutation, error) {
 	mutations := make([]m.Mutation, 0)
 
-	err := mg.StreamMutations(ctx, source, func(mutation m.Mutation) error {
+	err := mg.StreamMutations(ctx, source, FuncFilter{}, func(mutation m.Mutation) error {
 		mutations = append(mutations, mutation)
 		return nil
 	}, mutationTypes...)
@@ -51,12 +82,17 @@ func (mg *mutagen) GenerateMutation(ctx context.Context, source m.Source, mutati
 	return mutations, nil
 }
 
-func (mg *mutagen) StreamMutations(ctx context.Context, source m.Source, fn func(m.Mutation) error, mutationTypes ...m.MutationType) error {
+func (mg *mutagen) StreamMutations(ctx context.Context, source m.Source, funcs FuncFilter, fn func(m.Mutation) error, mutationTypes ...m.MutationType) error {
 	if err := validateSource(source); err != nil {
 		return err
 	}
 
-	mutationTypes, err := resolveMutationTypes(mutationTypes)
+	scope, err := funcs.compile()
+	if err != nil {
+		return err
+	}
+
+	mutationTypes, err = resolveMutationTypes(mutationTypes)
 	if err != nil {
 		return err
 	}
@@ -70,17 +106,82 @@ func (mg *mutagen) StreamMutations(ctx context.Context, source m.Source, fn func
 		return err
 	}
 
+	pkg := mg.loadTypedPackage(ctx, source, content)
+
+	var info *types.Info
+	if pkg != nil {
+		fset, file, info = pkg.Fset, pkg.File, pkg.Info
+	}
+
+	// Mutations are collected per file so that byte-identical mutants from
+	// different mutagens are tested once: later ones ride along as Duplicates
+	// of the first.
+	var unique []m.Mutation
+
+	byCode := map[[sha256.Size]byte]int{}
+
 	for _, mutationType := range mutationTypes {
-		for _, mutation := range collectMutations(mutationType, file, fset, content, source) {
-			if err := fn(mutation); err != nil {
-				return err
+		gen := mg.generators[mutationType]
+		effectiveType := mg.operators.MutationType(mutationType)
+
+		for _, mutation := range collectMutations(mutationType, gen, file, fset, content, source, info, mg.rules, scope) {
+			mutation.Type = effectiveType
+
+			key := sha256.Sum256(mutation.MutatedCode)
+			if i, ok := byCode[key]; ok {
+				// A mutagen repeating itself adds nothing; another mutagen
+				// producing the same code records the shared result.
+				if !originatesFrom(unique[i], mutation.Type) {
+					mutation.DuplicateOf = unique[i].ID
+					unique[i].Duplicates = append(unique[i].Duplicates, mutation)
+				}
+
+				continue
+			}
+
+			if pkg != nil {
+				if err := mg.TypeCheck(ctx, pkg, mutation.MutatedCode); err != nil {
+					slog.Debug("Discarding mutation that does not type-check", "file", source.Origin.FullPath, "line", mutation.Line, "type", mutationType.Name, "error", err)
+					continue
+				}
 			}
+
+			byCode[key] = len(unique)
+			unique = append(unique, mutation)
+		}
+	}
+
+	if order := mg.operators.HigherOrder; order > 1 {
+		unique = mg.higherOrderMutations(ctx, pkg, content, unique, order)
+	}
+
+	unique = mg.weaveSchema(ctx, pkg, content, unique)
+
+	for _, mutation := range unique {
+		if err := fn(mutation); err != nil {
+			return err
 		}
 	}
 
 	return nil
 }
 
+// originatesFrom reports whether mutationType already produced mutation or one
+// of its duplicates.
+func originatesFrom(mutation m.Mutation, mutationType m.MutationType) bool {
+	if mutation.Type == mutationType {
+		return true
+	}
+
+	for _, duplicate := range mutation.Duplicates {
+		if duplicate.Type == mutationType {
+			return true
+		}
+	}
+
+	return false
+}
+
 func validateSource(source m.Source) error {
 	if source.Origin == nil || source.Origin.FullPath == "" {
 		return fmt.Errorf("missing source origin")
@@ -99,11 +200,11 @@ func validateAdapters(mg *mutagen) error {
 
 func resolveMutationTypes(mutationTypes []m.MutationType) ([]m.MutationType, error) {
 	if len(mutationTypes) == 0 {
-		return []m.MutationType{m.MutationArithmetic, m.MutationBoolean, m.MutationNumbers, m.MutationComparison, m.MutationLogical, m.MutationUnary, m.MutationBranch}, nil
+		return []m.MutationType{m.MutationArithmetic, m.MutationBoolean, m.MutationNumbers, m.MutationComparison, m.MutationLogical, m.MutationUnary, m.MutationBranch, m.MutationCollection}, nil
 	}
 
 	for _, mutationType := range mutationTypes {
-		if mutationType != m.MutationArithmetic && mutationType != m.MutationBoolean && mutationType != m.MutationNumbers && mutationType != m.MutationComparison && mutationType != m.MutationLogical && mutationType != m.MutationUnary && mutationType != m.MutationBranch {
+		if mutationType != m.MutationArithmetic && mutationType != m.MutationBoolean && mutationType != m.MutationNumbers && mutationType != m.MutationComparison && mutationType != m.MutationLogical && mutationType != m.MutationUnary && mutationType != m.MutationBranch && mutationType != m.MutationCollection {
 			return nil, fmt.Errorf("unsupported mutation type: %s", mutationType.Name)
 		}
 	}
@@ -127,25 +228,60 @@ func (mg *mutagen) loadSourceAST(ctx context.Context, source m.Source) ([]byte,
 	return content, fset, file, nil
 }
 
-func collectMutations(mutationType m.MutationType, file *ast.File, fset *token.FileSet, content []byte, source m.Source) []m.Mutation {
+// loadTypedPackage loads the type-checked package of source. It returns nil when
+// the package cannot be loaded or does not type-check; mutations are then
+// generated from syntax alone and not type-checked.
+func (mg *mutagen) loadTypedPackage(ctx context.Context, source m.Source, content []byte) *adapter.TypedPackage {
+	pkg, err := mg.LoadPackage(ctx, string(source.Origin.FullPath), content)
+	if err != nil {
+		slog.Debug("Generating mutations without type information", "file", source.Origin.FullPath, "error", err)
+		return nil
+	}
+
+	return pkg
+}
+
+func collectMutations(
+	mutationType m.MutationType,
+	gen mutagens.Generator,
+	file *ast.File,
+	fset *token.FileSet,
+	content []byte,
+	source m.Source,
+	info *types.Info,
+	rules []EquivalenceRule,
+	scope funcMatcher,
+) []m.Mutation {
+	if gen == nil {
+		return nil
+	}
+
 	ignore := buildIgnoreIndex(file, fset, content)
 	if ignore.file.ignores(mutationType) {
 		return nil
 	}
 
+	equivalent := buildEquivalenceIndex(file, rules)
 	mutations := make([]m.Mutation, 0)
 
+	var path astPath
+
 	ast.Inspect(file, func(n ast.Node) bool {
 		if n == nil {
+			path.pop()
 			return true
 		}
 
-		// Function-level ignore: if the annotation is directly above the func decl,
-		// skip traversing the function body entirely.
-		if fd, ok := n.(*ast.FuncDecl); ok {
-			if rule, ok := ignore.funcByPos[fd.Pos()]; ok && rule.ignores(mutationType) {
-				return false
-			}
+		path.push(n)
+
+		if n == path.declNode() && !scope.includes(n) {
+			path.pop()
+			return false
+		}
+
+		if !visitNode(n, mutationType, ignore, equivalent, fset) {
+			path.pop()
+			return false
 		}
 
 		// Line-level ignore: if the annotation is on the same line (trailing) or
@@ -155,8 +291,23 @@ func collectMutations(mutationType m.MutationType, file *ast.File, fset *token.F
 			return true
 		}
 
-		nodeMutations := generateMutationsForNode(mutationType, n, fset, content, source)
+		nodeMutations := gen(n, fset, content, source, info)
+		if mutationType == m.MutationArithmetic {
+			nodeMutations = slices.DeleteFunc(nodeMutations, func(mutation m.Mutation) bool {
+				return isIdentitySwap(n, fset, mutation)
+			})
+		}
+
+		scope, fn := "", ""
+		if len(nodeMutations) > 0 {
+			scope = scopeHash(path.declNode(), fset, content)
+			fn = funcName(path.declNode())
+		}
+
 		for i := range nodeMutations {
+			nodeMutations[i].ID = stableMutationID(source, &path, mutationType, content, nodeMutations[i].MutatedCode)
+			nodeMutations[i].Scope = scope
+			nodeMutations[i].Func = fn
 			nodeMutations[i].Line = lineForOffset(content, firstDifference(content, nodeMutations[i].MutatedCode))
 		}
 
@@ -168,6 +319,27 @@ func collectMutations(mutationType m.MutationType, file *ast.File, fset *token.F
 	return mutations
 }
 
+// visitNode reports whether collectMutations should descend into n: whole
+// functions annotated with //gooze:ignore and arguments covered by an
+// equivalence rule are skipped for the mutation type.
+func visitNode(n ast.Node, mutationType m.MutationType, ignore ignoreIndex, equivalent equivalenceIndex, fset *token.FileSet) bool {
+	// Function-level ignore: if the annotation is directly above the func decl,
+	// skip traversing the function body entirely.
+	if fd, ok := n.(*ast.FuncDecl); ok {
+		if rule, ok := ignore.funcByPos[fd.Pos()]; ok && rule.ignores(mutationType) {
+			return false
+		}
+	}
+
+	// Equivalence rules: mutants inside matching call arguments change
+	// nothing a test can observe, so skip the whole argument.
+	if rule, ok := equivalent[n]; ok && rule.ignores(mutationType) {
+		return false
+	}
+
+	return true
+}
+
 // firstDifference returns the index of the first byte that differs between a and
 // b, or -1 if one is a prefix of the other. This locates where a mutation begins,
 // since MutatedCode shares an identical prefix with the original up to that point.
@@ -204,7 +376,8 @@ func lineForOffset(content []byte, offset int) int {
 	return 1 + bytes.Count(content[:offset], []byte{'\n'})
 }
 
-var mutationGenerators = map[m.MutationType]func(ast.Node, *token.FileSet, []byte, m.Source) []m.Mutation{
+// mutationGenerators holds the default-parameter generator for each mutation type.
+var mutationGenerators = map[m.MutationType]mutagens.Generator{
 	m.MutationArithmetic: mutagens.GenerateArithmeticMutations,
 	m.MutationBoolean:    mutagens.GenerateBooleanMutations,
 	m.MutationNumbers:    mutagens.GenerateNumberMutations,
@@ -214,19 +387,5 @@ var mutationGenerators = map[m.MutationType]func(ast.Node, *token.FileSet, []byt
 	m.MutationBranch:     mutagens.GenerateBranchMutations,
 	m.MutationStatement:  mutagens.GenerateStatementMutations,
 	m.MutationLoop:       mutagens.GenerateLoopMutations,
-}
-
-func generateMutationsForNode(
-	mutationType m.MutationType,
-	n ast.Node,
-	fset *token.FileSet,
-	content []byte,
-	source m.Source,
-) []m.Mutation {
-	gen, ok := mutationGenerators[mutationType]
-	if !ok {
-		return nil
-	}
-
-	return gen(n, fset, content, source)
+	m.MutationCollection: mutagens.GenerateCollectionMutations,
 }
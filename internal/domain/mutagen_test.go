@@ -117,6 +117,110 @@ func TestMutagen_GenerateMutation_DefaultTypes(t *testing.T) {
 	}
 }
 
+func TestMutagen_GenerateMutation_WithOperators_StrongNumbers(t *testing.T) {
+	operators, err := OperatorConfig{Level: LevelStrong}.Resolve()
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+
+	mg := NewMutagen(adapter.NewLocalGoFileAdapter(), adapter.NewLocalSourceFSAdapter(), WithOperators(operators))
+
+	source := makeSourceV2(t, filepath.Join("..", "..", "examples", "basic", "main.go"))
+	mutations, err := mg.GenerateMutation(context.Background(), source, m.MutationNumbers)
+	if err != nil {
+		t.Fatalf("GenerateMutation failed: %v", err)
+	}
+
+	// Four literals (3, 5, 3, 5), each with zero, one, n+1, n-1 and -n.
+	if len(mutations) != 20 {
+		t.Fatalf("expected 20 mutations, got %d", len(mutations))
+	}
+
+	want := operators.MutationType(m.MutationNumbers)
+	for _, mutation := range mutations {
+		if mutation.Type != want {
+			t.Fatalf("expected effective mutation type %v, got %v", want, mutation.Type)
+		}
+	}
+}
+
+func TestMutagen_GenerateMutation_DiscardsMutantsThatDoNotTypeCheck(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "go.mod"), "module example.com/typed\n\ngo 1.22\n")
+	writeTestFile(t, filepath.Join(dir, "main.go"), "package main\n\nvar digits = [3]int{4, 5, 6}\n\nfunc greet(name string) string { return \"hi \" + name }\n\nfunc main() { _ = greet(\"x\") }\n")
+
+	mg := newTestMutagen()
+	source := makeSourceV2(t, filepath.Join(dir, "main.go"))
+
+	arithmetic, err := mg.GenerateMutation(context.Background(), source, m.MutationArithmetic)
+	if err != nil {
+		t.Fatalf("GenerateMutation(arithmetic) failed: %v", err)
+	}
+
+	if len(arithmetic) != 0 {
+		t.Fatalf("expected no arithmetic mutations for string concatenation, got %d", len(arithmetic))
+	}
+
+	numbers, err := mg.GenerateMutation(context.Background(), source, m.MutationNumbers)
+	if err != nil {
+		t.Fatalf("GenerateMutation(numbers) failed: %v", err)
+	}
+
+	// The array length 3 cannot become 0 or 1 with three elements; each element
+	// literal still yields two mutations.
+	if len(numbers) != 6 {
+		t.Fatalf("expected 6 numbers mutations, got %d", len(numbers))
+	}
+
+	for _, mutation := range numbers {
+		if !strings.Contains(string(mutation.MutatedCode), "[3]int") {
+			t.Fatalf("expected array length to be left intact, got:\n%s", mutation.MutatedCode)
+		}
+	}
+}
+
+func TestMutagen_GenerateMutation_DeduplicatesIdenticalMutants(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "go.mod"), "module example.com/dedup\n\ngo 1.22\n")
+	writeTestFile(t, filepath.Join(dir, "main.go"), "package main\n\nfunc pick() int {\n\tif true {\n\t\treturn 1\n\t}\n\treturn 2\n}\n\nfunc main() { _ = pick() }\n")
+
+	mg := newTestMutagen()
+	source := makeSourceV2(t, filepath.Join(dir, "main.go"))
+
+	mutations, err := mg.GenerateMutation(context.Background(), source, m.MutationBoolean, m.MutationBranch)
+	if err != nil {
+		t.Fatalf("GenerateMutation failed: %v", err)
+	}
+
+	seen := map[string]bool{}
+	duplicates := 0
+
+	for _, mutation := range mutations {
+		if seen[string(mutation.MutatedCode)] {
+			t.Fatalf("mutated code generated twice:\n%s", mutation.DiffCode)
+		}
+
+		seen[string(mutation.MutatedCode)] = true
+
+		for _, duplicate := range mutation.Duplicates {
+			duplicates++
+
+			// Boolean's true -> false is also the branch mutagen's "force false".
+			if mutation.Type != m.MutationBoolean || duplicate.Type != m.MutationBranch {
+				t.Errorf("unexpected duplicate %v of %v", duplicate.Type, mutation.Type)
+			}
+
+			if duplicate.DuplicateOf != mutation.ID || duplicate.ID == mutation.ID {
+				t.Errorf("expected duplicate to point at %s, got %s (own ID %s)", mutation.ID, duplicate.DuplicateOf, duplicate.ID)
+			}
+		}
+	}
+
+	if duplicates != 1 {
+		t.Fatalf("expected 1 duplicate, got %d", duplicates)
+	}
+}
+
 func TestMutagen_GenerateMutation_InvalidType(t *testing.T) {
 	mg := newTestMutagen()
 
@@ -215,6 +319,14 @@ func readFileBytes(t *testing.T, path m.Path) []byte {
 	return content
 }
 
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+}
+
 func newTestMutagen() Mutagen {
 	return NewMutagen(adapter.NewLocalGoFileAdapter(), adapter.NewLocalSourceFSAdapter())
 }
@@ -4,13 +4,45 @@ package mutagens
 import (
 	"go/ast"
 	"go/token"
+	"go/types"
+	"slices"
 
 	m "gooze.dev/pkg/gooze/internal/model"
 )
 
 // GenerateArithmeticMutations generates arithmetic mutations for the given AST node.
-func GenerateArithmeticMutations(n ast.Node, fset *token.FileSet, content []byte, source m.Source) []m.Mutation {
-	return generateBinaryExprMutations(n, fset, content, source, m.MutationArithmetic, isArithmeticOp, getArithmeticAlternatives)
+//
+// With type information, string concatenation is left alone and % is not
+// offered for non-integer operands, since neither would compile.
+func GenerateArithmeticMutations(n ast.Node, fset *token.FileSet, content []byte, source m.Source, info *types.Info) []m.Mutation {
+	alternatives := getArithmeticAlternatives
+
+	if binExpr, ok := n.(*ast.BinaryExpr); ok && info != nil {
+		if basic, ok := underlyingBasic(info.TypeOf(binExpr)); ok {
+			switch {
+			case basic.Info()&types.IsString != 0:
+				return nil
+			case basic.Info()&types.IsInteger == 0:
+				alternatives = func(original token.Token) []token.Token {
+					return slices.DeleteFunc(getArithmeticAlternatives(original), func(op token.Token) bool {
+						return op == token.REM
+					})
+				}
+			}
+		}
+	}
+
+	return generateBinaryExprMutations(n, fset, content, source, m.MutationArithmetic, isArithmeticOp, alternatives)
+}
+
+func underlyingBasic(t types.Type) (*types.Basic, bool) {
+	if t == nil {
+		return nil, false
+	}
+
+	basic, ok := t.Underlying().(*types.Basic)
+
+	return basic, ok
 }
 
 func isArithmeticOp(op token.Token) bool {
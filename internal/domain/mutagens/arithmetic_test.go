@@ -5,6 +5,7 @@ import (
 	"go/ast"
 	"go/parser"
 	"go/token"
+	"go/types"
 	"os"
 	"path/filepath"
 	"strings"
@@ -30,7 +31,7 @@ func TestGenerateArithmeticMutations(t *testing.T) {
 	var mutations []m.Mutation
 
 	ast.Inspect(file, func(n ast.Node) bool {
-		mutations = append(mutations, GenerateArithmeticMutations(n, fset, content, source)...)
+		mutations = append(mutations, GenerateArithmeticMutations(n, fset, content, source, nil)...)
 		return true
 	})
 
@@ -101,3 +102,60 @@ func TestIsArithmeticOp(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateArithmeticMutations_WithTypeInfo(t *testing.T) {
+	tests := []struct {
+		name          string
+		code          string
+		expectedCount int
+	}{
+		{
+			name:          "integer operands get every alternative",
+			code:          "package main\nfunc f(a, b int) int { return a + b }",
+			expectedCount: 4,
+		},
+		{
+			name:          "string concatenation is skipped",
+			code:          "package main\nfunc f(a, b string) string { return a + b }",
+			expectedCount: 0,
+		},
+		{
+			name:          "float operands skip remainder",
+			code:          "package main\nfunc f(a, b float64) float64 { return a + b }",
+			expectedCount: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			file, err := parser.ParseFile(fset, "test.go", tt.code, parser.ParseComments)
+			if err != nil {
+				t.Fatalf("failed to parse code: %v", err)
+			}
+
+			info := &types.Info{Types: map[ast.Expr]types.TypeAndValue{}}
+			if _, err := (&types.Config{}).Check("main", fset, []*ast.File{file}, info); err != nil {
+				t.Fatalf("failed to type-check code: %v", err)
+			}
+
+			source := m.Source{Origin: &m.File{FullPath: "test.go"}}
+
+			var mutations []m.Mutation
+			ast.Inspect(file, func(n ast.Node) bool {
+				mutations = append(mutations, GenerateArithmeticMutations(n, fset, []byte(tt.code), source, info)...)
+				return true
+			})
+
+			if len(mutations) != tt.expectedCount {
+				t.Fatalf("expected %d mutations, got %d", tt.expectedCount, len(mutations))
+			}
+
+			for _, mutation := range mutations {
+				if strings.Contains(string(mutation.MutatedCode), "a % b") && tt.expectedCount != 4 {
+					t.Errorf("unexpected remainder mutation:\n%s", mutation.MutatedCode)
+				}
+			}
+		})
+	}
+}
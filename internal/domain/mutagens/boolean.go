@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"go/ast"
 	"go/token"
+	"go/types"
 
 	m "gooze.dev/pkg/gooze/internal/model"
 )
@@ -15,7 +16,7 @@ const (
 )
 
 // GenerateBooleanMutations generates boolean literal mutations for the given AST node.
-func GenerateBooleanMutations(n ast.Node, fset *token.FileSet, content []byte, source m.Source) []m.Mutation {
+func GenerateBooleanMutations(n ast.Node, fset *token.FileSet, content []byte, source m.Source, _ *types.Info) []m.Mutation {
 	ident, ok := n.(*ast.Ident)
 	if !ok {
 		return nil
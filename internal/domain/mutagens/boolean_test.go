@@ -30,7 +30,7 @@ func TestGenerateBooleanMutations(t *testing.T) {
 	var mutations []m.Mutation
 
 	ast.Inspect(file, func(n ast.Node) bool {
-		mutations = append(mutations, GenerateBooleanMutations(n, fset, content, source)...)
+		mutations = append(mutations, GenerateBooleanMutations(n, fset, content, source, nil)...)
 		return true
 	})
 
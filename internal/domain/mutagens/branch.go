@@ -5,13 +5,14 @@ import (
 	"fmt"
 	"go/ast"
 	"go/token"
+	"go/types"
 
 	m "gooze.dev/pkg/gooze/internal/model"
 )
 
 // GenerateBranchMutations generates branch mutations for the given AST node.
 // Branch mutations modify conditional statements to test boundary behavior.
-func GenerateBranchMutations(n ast.Node, fset *token.FileSet, content []byte, source m.Source) []m.Mutation {
+func GenerateBranchMutations(n ast.Node, fset *token.FileSet, content []byte, source m.Source, _ *types.Info) []m.Mutation {
 	if n == nil {
 		return nil
 	}
@@ -0,0 +1,301 @@
+package mutagens
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path"
+	"strconv"
+	"strings"
+
+	m "gooze.dev/pkg/gooze/internal/model"
+)
+
+// collectionMutator produces the replacements for one standard-library call.
+// Each replacement is a byte range of the original content and the text that
+// replaces it.
+type collectionMutator func(call *ast.CallExpr, fset *token.FileSet, content []byte) []replacement
+
+type replacement struct {
+	start, end int
+	text       string
+}
+
+// collectionMutators maps "importpath.Func" to the mutator for calls to it.
+var collectionMutators = map[string]collectionMutator{
+	"sort.Slice":            negateFuncLitReturns(1, "!"),
+	"sort.SliceStable":      combine(negateFuncLitReturns(1, "!"), renameCall("Slice")),
+	"sort.Stable":           renameCall("Sort"),
+	"slices.SortFunc":       negateFuncLitReturns(1, "-"),
+	"slices.SortStableFunc": negateFuncLitReturns(1, "-"),
+	"slices.Contains":       negateCall,
+	"slices.ContainsFunc":   negateCall,
+	"slices.Index":          replaceCall("-1"),
+	"slices.IndexFunc":      replaceCall("-1"),
+	"slices.Max":            renameCall("Min"),
+	"slices.Min":            renameCall("Max"),
+	"slices.MaxFunc":        renameCall("MinFunc"),
+	"slices.MinFunc":        renameCall("MaxFunc"),
+	"slices.Compact":        unwrapCall,
+	"slices.CompactFunc":    unwrapCall,
+	"slices.Clone":          unwrapCall,
+	"maps.Clone":            unwrapCall,
+	"cmp.Compare":           swapArgs,
+	"cmp.Less":              swapArgs,
+}
+
+// GenerateCollectionMutations generates mutations for calls into the sort,
+// slices, maps and cmp standard-library packages:
+//   - comparator results in sort.Slice / slices.SortFunc callbacks are reversed
+//   - cmp.Compare / cmp.Less arguments are swapped
+//   - slices.Contains is negated and slices.Index reports "not found"
+//   - slices.Max and slices.Min are swapped
+//   - sort.Stable becomes sort.Sort
+//   - slices.Compact / slices.Clone / maps.Clone calls are removed
+//
+// The package is resolved through the file's imports, so aliased imports are
+// recognized and a local identifier that merely shares a package name is not.
+func GenerateCollectionMutations(n ast.Node, fset *token.FileSet, content []byte, source m.Source, info *types.Info) []m.Mutation {
+	call, ok := n.(*ast.CallExpr)
+	if !ok {
+		return nil
+	}
+
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+
+	pkgIdent, ok := sel.X.(*ast.Ident)
+	if !ok || pkgIdent.Obj != nil || !isCollectionFunc(sel.Sel.Name) {
+		return nil
+	}
+
+	importPath, ok := calledPackage(pkgIdent, content, info)
+	if !ok {
+		return nil
+	}
+
+	mutator, ok := collectionMutators[importPath+"."+sel.Sel.Name]
+	if !ok {
+		return nil
+	}
+
+	replacements := mutator(call, fset, content)
+
+	mutations := make([]m.Mutation, 0, len(replacements))
+	for _, r := range replacements {
+		mutatedCode := replaceRange(content, r.start, r.end, r.text)
+		diff := diffCode(content, mutatedCode)
+		h := sha256.Sum256(mutatedCode)
+		id := fmt.Sprintf("%x", h)
+		mutations = append(mutations, m.Mutation{
+			ID:          id,
+			Source:      source,
+			Type:        m.MutationCollection,
+			MutatedCode: mutatedCode,
+			DiffCode:    diff,
+		})
+	}
+
+	return mutations
+}
+
+// isCollectionFunc is a cheap pre-check so imports are only resolved for calls
+// whose function name could match a mutator.
+func isCollectionFunc(name string) bool {
+	for key := range collectionMutators {
+		if strings.HasSuffix(key, "."+name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// calledPackage resolves the package a qualified call refers to, from the
+// type information when available and from the file's imports otherwise.
+func calledPackage(ident *ast.Ident, content []byte, info *types.Info) (string, bool) {
+	if info != nil {
+		pkgName, ok := info.Uses[ident].(*types.PkgName)
+		if !ok {
+			return "", false
+		}
+
+		return pkgName.Imported().Path(), true
+	}
+
+	return importedPackage(content, ident.Name)
+}
+
+// importedPackage resolves a package identifier to its import path using the
+// import declarations of content. Only the import block is parsed.
+func importedPackage(content []byte, name string) (string, bool) {
+	file, err := parser.ParseFile(token.NewFileSet(), "", content, parser.ImportsOnly)
+	if err != nil || file == nil {
+		return "", false
+	}
+
+	for _, spec := range file.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+
+		localName := path.Base(importPath)
+		if spec.Name != nil {
+			localName = spec.Name.Name
+		}
+
+		if localName == name {
+			return importPath, true
+		}
+	}
+
+	return "", false
+}
+
+// nodeRange returns the byte offsets spanned by node.
+func nodeRange(fset *token.FileSet, node ast.Node) (int, int, bool) {
+	start, ok1 := offsetForPos(fset, node.Pos())
+	end, ok2 := offsetForPos(fset, node.End())
+
+	return start, end, ok1 && ok2
+}
+
+func combine(mutators ...collectionMutator) collectionMutator {
+	return func(call *ast.CallExpr, fset *token.FileSet, content []byte) []replacement {
+		var out []replacement
+		for _, mutator := range mutators {
+			out = append(out, mutator(call, fset, content)...)
+		}
+
+		return out
+	}
+}
+
+// negateFuncLitReturns wraps every result returned by the function literal
+// passed as argument argIndex in op (e.g. "!" for less functions, "-" for
+// three-way comparators), reversing the ordering it defines.
+func negateFuncLitReturns(argIndex int, op string) collectionMutator {
+	return func(call *ast.CallExpr, fset *token.FileSet, content []byte) []replacement {
+		if argIndex >= len(call.Args) {
+			return nil
+		}
+
+		lit, ok := call.Args[argIndex].(*ast.FuncLit)
+		if !ok || lit.Body == nil {
+			return nil
+		}
+
+		var out []replacement
+
+		ast.Inspect(lit.Body, func(n ast.Node) bool {
+			switch node := n.(type) {
+			case *ast.FuncLit:
+				// Returns of nested closures do not belong to the comparator.
+				return false
+			case *ast.ReturnStmt:
+				if len(node.Results) != 1 {
+					return true
+				}
+
+				start, end, ok := nodeRange(fset, node.Results[0])
+				if ok {
+					out = append(out, replacement{start: start, end: end, text: op + "(" + string(content[start:end]) + ")"})
+				}
+			}
+
+			return true
+		})
+
+		return out
+	}
+}
+
+// renameCall replaces the selected function name, keeping the package qualifier.
+func renameCall(name string) collectionMutator {
+	return func(call *ast.CallExpr, fset *token.FileSet, _ []byte) []replacement {
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return nil
+		}
+
+		start, end, ok := nodeRange(fset, sel.Sel)
+		if !ok {
+			return nil
+		}
+
+		return []replacement{{start: start, end: end, text: name}}
+	}
+}
+
+// replaceCall replaces the whole call expression with text.
+func replaceCall(text string) collectionMutator {
+	return func(call *ast.CallExpr, fset *token.FileSet, _ []byte) []replacement {
+		start, end, ok := nodeRange(fset, call)
+		if !ok {
+			return nil
+		}
+
+		return []replacement{{start: start, end: end, text: text}}
+	}
+}
+
+// negateCall prefixes a boolean call with "!".
+func negateCall(call *ast.CallExpr, fset *token.FileSet, content []byte) []replacement {
+	start, end, ok := nodeRange(fset, call)
+	if !ok {
+		return nil
+	}
+
+	return []replacement{{start: start, end: end, text: "!" + string(content[start:end])}}
+}
+
+// unwrapCall replaces the call with its first argument, removing the call.
+func unwrapCall(call *ast.CallExpr, fset *token.FileSet, content []byte) []replacement {
+	if len(call.Args) == 0 {
+		return nil
+	}
+
+	start, end, ok := nodeRange(fset, call)
+	if !ok {
+		return nil
+	}
+
+	argStart, argEnd, ok := nodeRange(fset, call.Args[0])
+	if !ok {
+		return nil
+	}
+
+	return []replacement{{start: start, end: end, text: string(content[argStart:argEnd])}}
+}
+
+// swapArgs exchanges the first two arguments of the call.
+func swapArgs(call *ast.CallExpr, fset *token.FileSet, content []byte) []replacement {
+	if len(call.Args) != 2 {
+		return nil
+	}
+
+	aStart, aEnd, ok := nodeRange(fset, call.Args[0])
+	if !ok {
+		return nil
+	}
+
+	bStart, bEnd, ok := nodeRange(fset, call.Args[1])
+	if !ok {
+		return nil
+	}
+
+	a := string(content[aStart:aEnd])
+	b := string(content[bStart:bEnd])
+
+	if a == b {
+		return nil
+	}
+
+	return []replacement{{start: aStart, end: bEnd, text: b + string(content[aEnd:bStart]) + a}}
+}
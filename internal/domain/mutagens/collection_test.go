@@ -0,0 +1,169 @@
+package mutagens
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	m "gooze.dev/pkg/gooze/internal/model"
+)
+
+func TestGenerateCollectionMutations(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     string
+		expected []string
+	}{
+		{
+			name:     "sort.Slice less function is reversed",
+			code:     "package main\nimport \"sort\"\nfunc f(s []int) { sort.Slice(s, func(i, j int) bool { return s[i] < s[j] }) }",
+			expected: []string{"return !(s[i] < s[j])"},
+		},
+		{
+			name: "sort.SliceStable is reversed and made unstable",
+			code: "package main\nimport \"sort\"\nfunc f(s []int) { sort.SliceStable(s, func(i, j int) bool { return s[i] < s[j] }) }",
+			expected: []string{
+				"return !(s[i] < s[j])",
+				"sort.Slice(s,",
+			},
+		},
+		{
+			name:     "slices.SortFunc comparator is negated",
+			code:     "package main\nimport (\"cmp\"; \"slices\")\nfunc f(s []int) { slices.SortFunc(s, func(a, b int) int { return cmp.Compare(a, b) }) }",
+			expected: []string{"return -(cmp.Compare(a, b))", "cmp.Compare(b, a)"},
+		},
+		{
+			name:     "cmp.Compare arguments are swapped",
+			code:     "package main\nimport \"cmp\"\nfunc f(a, b int) int { return cmp.Compare(a, b) }",
+			expected: []string{"cmp.Compare(b, a)"},
+		},
+		{
+			name:     "slices.Contains is negated",
+			code:     "package main\nimport \"slices\"\nfunc f(s []int) bool { return slices.Contains(s, 1) }",
+			expected: []string{"return !slices.Contains(s, 1)"},
+		},
+		{
+			name:     "slices.Index reports not found",
+			code:     "package main\nimport \"slices\"\nfunc f(s []int) int { return slices.Index(s, 1) }",
+			expected: []string{"return -1"},
+		},
+		{
+			name:     "slices.Max becomes slices.Min",
+			code:     "package main\nimport \"slices\"\nfunc f(s []int) int { return slices.Max(s) }",
+			expected: []string{"slices.Min(s)"},
+		},
+		{
+			name:     "slices.Min becomes slices.Max",
+			code:     "package main\nimport \"slices\"\nfunc f(s []int) int { return slices.Min(s) }",
+			expected: []string{"slices.Max(s)"},
+		},
+		{
+			name:     "sort.Stable becomes sort.Sort",
+			code:     "package main\nimport \"sort\"\nfunc f(s sort.Interface) { sort.Stable(s) }",
+			expected: []string{"sort.Sort(s)"},
+		},
+		{
+			name:     "slices.Compact call is removed",
+			code:     "package main\nimport \"slices\"\nfunc f(s []int) []int { return slices.Compact(s) }",
+			expected: []string{"return s }"},
+		},
+		{
+			name:     "slices.Clone call is removed",
+			code:     "package main\nimport \"slices\"\nfunc f(s []int) []int { return slices.Clone(s) }",
+			expected: []string{"return s }"},
+		},
+		{
+			name:     "maps.Clone call is removed",
+			code:     "package main\nimport \"maps\"\nfunc f(v map[int]int) map[int]int { return maps.Clone(v) }",
+			expected: []string{"return v }"},
+		},
+		{
+			name:     "aliased import is resolved",
+			code:     "package main\nimport sl \"slices\"\nfunc f(s []int) int { return sl.Max(s) }",
+			expected: []string{"sl.Min(s)"},
+		},
+		{
+			name:     "package not imported",
+			code:     "package main\nfunc f(s []int) int { return slices.Max(s) }",
+			expected: nil,
+		},
+		{
+			name:     "local variable shadows package name",
+			code:     "package main\nimport _ \"slices\"\ntype t struct{}\nfunc (t) Max(s []int) int { return 0 }\nfunc f(s []int) int { slices := t{}; return slices.Max(s) }",
+			expected: nil,
+		},
+		{
+			name:     "identical cmp.Compare arguments are not swapped",
+			code:     "package main\nimport \"cmp\"\nfunc f(a int) int { return cmp.Compare(a, a) }",
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			file, err := parser.ParseFile(fset, "test.go", tt.code, parser.ParseComments)
+			if err != nil {
+				t.Fatalf("failed to parse code: %v", err)
+			}
+
+			content := []byte(tt.code)
+			source := m.Source{
+				Origin: &m.File{FullPath: "test.go"},
+			}
+
+			var mutations []m.Mutation
+
+			ast.Inspect(file, func(n ast.Node) bool {
+				mutations = append(mutations, GenerateCollectionMutations(n, fset, content, source, nil)...)
+				return true
+			})
+
+			if len(mutations) != len(tt.expected) {
+				t.Fatalf("expected %d mutations, got %d", len(tt.expected), len(mutations))
+			}
+
+			for i, mut := range mutations {
+				if mut.Type != m.MutationCollection {
+					t.Errorf("expected mutation type %v, got %v", m.MutationCollection, mut.Type)
+				}
+
+				if !strings.Contains(string(mut.MutatedCode), tt.expected[i]) {
+					t.Errorf("mutation %d: expected mutated code to contain %q, got:\n%s", i, tt.expected[i], mut.MutatedCode)
+				}
+
+				if _, err := parser.ParseFile(token.NewFileSet(), "mutated.go", mut.MutatedCode, parser.AllErrors); err != nil {
+					t.Errorf("mutation %d does not parse: %v", i, err)
+				}
+			}
+		})
+	}
+}
+
+func TestImportedPackage(t *testing.T) {
+	content := []byte("package main\nimport (\n\t\"sort\"\n\tsl \"slices\"\n\t\"golang.org/x/exp/maps\"\n)\n")
+
+	tests := []struct {
+		name     string
+		ident    string
+		wantPath string
+		wantOK   bool
+	}{
+		{"default name", "sort", "sort", true},
+		{"alias", "sl", "slices", true},
+		{"original name of aliased import", "slices", "", false},
+		{"last path element", "maps", "golang.org/x/exp/maps", true},
+		{"not imported", "cmp", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotPath, gotOK := importedPackage(content, tt.ident)
+			if gotPath != tt.wantPath || gotOK != tt.wantOK {
+				t.Errorf("importedPackage(%q) = (%q, %v), want (%q, %v)", tt.ident, gotPath, gotOK, tt.wantPath, tt.wantOK)
+			}
+		})
+	}
+}
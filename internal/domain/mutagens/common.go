@@ -6,12 +6,18 @@ import (
 	"fmt"
 	"go/ast"
 	"go/token"
+	"go/types"
 	"strings"
 
 	"github.com/pmezard/go-difflib/difflib"
 	m "gooze.dev/pkg/gooze/internal/model"
 )
 
+// Generator produces the mutations of one mutation type for a single AST node.
+// info holds the type information of the node's package; it is nil when the
+// package could not be type-checked, so generators must not rely on it.
+type Generator func(n ast.Node, fset *token.FileSet, content []byte, source m.Source, info *types.Info) []m.Mutation
+
 func offsetForPos(fset *token.FileSet, pos token.Pos) (int, bool) {
 	file := fset.File(pos)
 	if file == nil {
@@ -3,15 +3,45 @@ package mutagens
 import (
 	"go/ast"
 	"go/token"
+	"go/types"
 
 	m "gooze.dev/pkg/gooze/internal/model"
 )
 
+// ComparisonMode selects which replacements the comparison mutagen produces.
+type ComparisonMode string
+
+// Supported comparison modes.
+const (
+	// ComparisonFull replaces an operator with every other comparison operator.
+	ComparisonFull ComparisonMode = "full"
+	// ComparisonBoundary only produces the off-by-one boundary counterpart
+	// (< <-> <=, > <-> >=) and the negation of equality (== <-> !=).
+	ComparisonBoundary ComparisonMode = "boundary"
+)
+
+// IsValidComparisonMode reports whether mode is a known comparison mode.
+func IsValidComparisonMode(mode ComparisonMode) bool {
+	return mode == ComparisonFull || mode == ComparisonBoundary
+}
+
 // GenerateComparisonMutations generates comparison operator mutations for the given AST node.
-func GenerateComparisonMutations(n ast.Node, fset *token.FileSet, content []byte, source m.Source) []m.Mutation {
+func GenerateComparisonMutations(n ast.Node, fset *token.FileSet, content []byte, source m.Source, _ *types.Info) []m.Mutation {
 	return generateBinaryExprMutations(n, fset, content, source, m.MutationComparison, isComparisonOp, getComparisonAlternatives)
 }
 
+// ComparisonMutations returns a comparison generator for the given mode.
+func ComparisonMutations(mode ComparisonMode) Generator {
+	alternatives := getComparisonAlternatives
+	if mode == ComparisonBoundary {
+		alternatives = getComparisonBoundaryAlternatives
+	}
+
+	return func(n ast.Node, fset *token.FileSet, content []byte, source m.Source, _ *types.Info) []m.Mutation {
+		return generateBinaryExprMutations(n, fset, content, source, m.MutationComparison, isComparisonOp, alternatives)
+	}
+}
+
 func isComparisonOp(op token.Token) bool {
 	return op == token.LSS || op == token.GTR || op == token.LEQ ||
 		op == token.GEQ || op == token.EQL || op == token.NEQ
@@ -30,3 +60,22 @@ func getComparisonAlternatives(original token.Token) []token.Token {
 
 	return alternatives
 }
+
+func getComparisonBoundaryAlternatives(original token.Token) []token.Token {
+	switch original { //nolint:exhaustive
+	case token.LSS:
+		return []token.Token{token.LEQ}
+	case token.LEQ:
+		return []token.Token{token.LSS}
+	case token.GTR:
+		return []token.Token{token.GEQ}
+	case token.GEQ:
+		return []token.Token{token.GTR}
+	case token.EQL:
+		return []token.Token{token.NEQ}
+	case token.NEQ:
+		return []token.Token{token.EQL}
+	default:
+		return nil
+	}
+}
@@ -82,7 +82,7 @@ func TestGenerateComparisonMutations(t *testing.T) {
 
 			// Traverse AST and collect mutations
 			ast.Inspect(file, func(n ast.Node) bool {
-				mutations = append(mutations, GenerateComparisonMutations(n, fset, content, source)...)
+				mutations = append(mutations, GenerateComparisonMutations(n, fset, content, source, nil)...)
 				return true
 			})
 
@@ -158,3 +158,30 @@ func TestGetComparisonAlternatives(t *testing.T) {
 		})
 	}
 }
+
+func TestGetComparisonBoundaryAlternatives(t *testing.T) {
+	tests := []struct {
+		original token.Token
+		expected token.Token
+	}{
+		{token.LSS, token.LEQ},
+		{token.LEQ, token.LSS},
+		{token.GTR, token.GEQ},
+		{token.GEQ, token.GTR},
+		{token.EQL, token.NEQ},
+		{token.NEQ, token.EQL},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.original.String(), func(t *testing.T) {
+			alternatives := getComparisonBoundaryAlternatives(tt.original)
+			if len(alternatives) != 1 || alternatives[0] != tt.expected {
+				t.Errorf("getComparisonBoundaryAlternatives(%v) = %v, expected [%v]", tt.original, alternatives, tt.expected)
+			}
+		})
+	}
+
+	if alternatives := getComparisonBoundaryAlternatives(token.ADD); alternatives != nil {
+		t.Errorf("expected no alternatives for non-comparison operator, got %v", alternatives)
+	}
+}
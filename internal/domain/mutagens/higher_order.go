@@ -0,0 +1,85 @@
+package mutagens
+
+import (
+	"slices"
+
+	m "gooze.dev/pkg/gooze/internal/model"
+)
+
+// edit is the change a first-order mutation makes to the original content:
+// content[start:end] is replaced with replacement.
+type edit struct {
+	start       int
+	end         int
+	replacement []byte
+}
+
+// editOf recovers the edit that turns content into mutated by trimming their
+// common prefix and suffix.
+func editOf(content, mutated []byte) edit {
+	start := 0
+	for start < len(content) && start < len(mutated) && content[start] == mutated[start] {
+		start++
+	}
+
+	end := 0
+	for end < len(content)-start && end < len(mutated)-start && content[len(content)-1-end] == mutated[len(mutated)-1-end] {
+		end++
+	}
+
+	return edit{start: start, end: len(content) - end, replacement: mutated[start : len(mutated)-end]}
+}
+
+// EditSpan returns the range of content, content[start:end], that mutated
+// replaces.
+func EditSpan(content, mutated []byte) (start, end int) {
+	e := editOf(content, mutated)
+
+	return e.start, e.end
+}
+
+// CombineMutations applies the edits of several first-order mutations of the
+// same content at once, producing the MutatedCode and DiffCode of a
+// higher-order mutation. It reports false when two edits overlap, since they
+// cannot both apply.
+func CombineMutations(content []byte, mutations []m.Mutation) (mutatedCode, diff []byte, ok bool) {
+	edits := make([]edit, 0, len(mutations))
+	for _, mutation := range mutations {
+		edits = append(edits, editOf(content, mutation.MutatedCode))
+	}
+
+	if !disjoint(edits) {
+		return nil, nil, false
+	}
+
+	slices.SortFunc(edits, func(a, b edit) int { return a.start - b.start })
+
+	// Apply from the end so earlier offsets stay valid.
+	mutated := content
+	for i := len(edits) - 1; i >= 0; i-- {
+		mutated = replaceRange(mutated, edits[i].start, edits[i].end, string(edits[i].replacement))
+	}
+
+	return mutated, diffCode(content, mutated), true
+}
+
+// Overlaps reports whether the mutations change overlapping (or touching)
+// parts of content, so they cannot be combined.
+func Overlaps(content []byte, a, b m.Mutation) bool {
+	return !disjoint([]edit{editOf(content, a.MutatedCode), editOf(content, b.MutatedCode)})
+}
+
+// disjoint reports whether no two edits touch the same bytes. Edits that merely
+// meet are treated as overlapping, as are two insertions at the same offset.
+func disjoint(edits []edit) bool {
+	sorted := slices.Clone(edits)
+	slices.SortFunc(sorted, func(a, b edit) int { return a.start - b.start })
+
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i].start <= sorted[i-1].end {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,49 @@
+package mutagens
+
+import (
+	"strings"
+	"testing"
+
+	m "gooze.dev/pkg/gooze/internal/model"
+)
+
+func TestCombineMutations(t *testing.T) {
+	content := []byte("package p\n\nfunc f(a, b int) int {\n\treturn a*b - a\n}\n")
+	star := m.Mutation{MutatedCode: []byte("package p\n\nfunc f(a, b int) int {\n\treturn a+b - a\n}\n")}
+	minus := m.Mutation{MutatedCode: []byte("package p\n\nfunc f(a, b int) int {\n\treturn a*b + a\n}\n")}
+
+	mutated, diff, ok := CombineMutations(content, []m.Mutation{minus, star})
+	if !ok {
+		t.Fatal("expected disjoint mutations to combine")
+	}
+
+	if want := "package p\n\nfunc f(a, b int) int {\n\treturn a+b + a\n}\n"; string(mutated) != want {
+		t.Fatalf("combined code = %q, want %q", mutated, want)
+	}
+
+	if !strings.Contains(string(diff), "+\treturn a+b + a") {
+		t.Fatalf("expected combined diff, got:\n%s", diff)
+	}
+}
+
+func TestCombineMutations_Overlapping(t *testing.T) {
+	content := []byte("x := a * b\n")
+	plus := m.Mutation{MutatedCode: []byte("x := a + b\n")}
+	minus := m.Mutation{MutatedCode: []byte("x := a - b\n")}
+
+	if _, _, ok := CombineMutations(content, []m.Mutation{plus, minus}); ok {
+		t.Fatal("expected overlapping mutations not to combine")
+	}
+
+	if !Overlaps(content, plus, minus) {
+		t.Fatal("expected Overlaps to report the shared operator")
+	}
+}
+
+func TestEditSpan(t *testing.T) {
+	content := []byte("x := a * b\n")
+
+	if start, end := EditSpan(content, []byte("x := a + b\n")); start != 7 || end != 8 {
+		t.Fatalf("EditSpan() = %d, %d, want 7, 8", start, end)
+	}
+}
@@ -3,12 +3,13 @@ package mutagens
 import (
 	"go/ast"
 	"go/token"
+	"go/types"
 
 	m "gooze.dev/pkg/gooze/internal/model"
 )
 
 // GenerateLogicalMutations generates logical operator mutations for the given AST node.
-func GenerateLogicalMutations(n ast.Node, fset *token.FileSet, content []byte, source m.Source) []m.Mutation {
+func GenerateLogicalMutations(n ast.Node, fset *token.FileSet, content []byte, source m.Source, _ *types.Info) []m.Mutation {
 	return generateBinaryExprMutations(n, fset, content, source, m.MutationLogical, isLogicalOp, getLogicalAlternatives)
 }
 
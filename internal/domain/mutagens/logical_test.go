@@ -63,7 +63,7 @@ func TestGenerateLogicalMutations(t *testing.T) {
 			mutations := []m.Mutation{}
 
 			ast.Inspect(file, func(n ast.Node) bool {
-				mutations = append(mutations, GenerateLogicalMutations(n, fset, content, source)...)
+				mutations = append(mutations, GenerateLogicalMutations(n, fset, content, source, nil)...)
 				return true
 			})
 
@@ -5,13 +5,14 @@ import (
 	"fmt"
 	"go/ast"
 	"go/token"
+	"go/types"
 
 	m "gooze.dev/pkg/gooze/internal/model"
 )
 
 // GenerateLoopMutations generates loop mutations for the given AST node.
 // Loop mutations test loop boundaries, loop body execution, and control flow.
-func GenerateLoopMutations(n ast.Node, fset *token.FileSet, content []byte, source m.Source) []m.Mutation {
+func GenerateLoopMutations(n ast.Node, fset *token.FileSet, content []byte, source m.Source, _ *types.Info) []m.Mutation {
 	var mutations []m.Mutation
 
 	switch stmt := n.(type) {
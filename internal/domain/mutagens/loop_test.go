@@ -35,7 +35,7 @@ func foo(n int) int {
 
 	var mutations []m.Mutation
 	ast.Inspect(file, func(n ast.Node) bool {
-		mutations = append(mutations, GenerateLoopMutations(n, fset, []byte(source), src)...)
+		mutations = append(mutations, GenerateLoopMutations(n, fset, []byte(source), src, nil)...)
 		return true
 	})
 
@@ -83,7 +83,7 @@ func TestGenerateLoopMutations_RangeLoopBodyRemoval(t *testing.T) {
 
 	var mutations []m.Mutation
 	ast.Inspect(file, func(n ast.Node) bool {
-		mutations = append(mutations, GenerateLoopMutations(n, fset, content, src)...)
+		mutations = append(mutations, GenerateLoopMutations(n, fset, content, src, nil)...)
 		return true
 	})
 
@@ -123,7 +123,7 @@ func TestGenerateLoopMutations_BreakRemoval(t *testing.T) {
 
 	var mutations []m.Mutation
 	ast.Inspect(file, func(n ast.Node) bool {
-		mutations = append(mutations, GenerateLoopMutations(n, fset, content, src)...)
+		mutations = append(mutations, GenerateLoopMutations(n, fset, content, src, nil)...)
 		return true
 	})
 
@@ -164,7 +164,7 @@ func TestGenerateLoopMutations_ContinueRemoval(t *testing.T) {
 
 	var mutations []m.Mutation
 	ast.Inspect(file, func(n ast.Node) bool {
-		mutations = append(mutations, GenerateLoopMutations(n, fset, content, src)...)
+		mutations = append(mutations, GenerateLoopMutations(n, fset, content, src, nil)...)
 		return true
 	})
 
@@ -210,7 +210,7 @@ func bar() int {
 
 	var mutations []m.Mutation
 	ast.Inspect(file, func(n ast.Node) bool {
-		mutations = append(mutations, GenerateLoopMutations(n, fset, []byte(source), src)...)
+		mutations = append(mutations, GenerateLoopMutations(n, fset, []byte(source), src, nil)...)
 		return true
 	})
 
@@ -257,7 +257,7 @@ func nested(n int) int {
 
 	var mutations []m.Mutation
 	ast.Inspect(file, func(n ast.Node) bool {
-		mutations = append(mutations, GenerateLoopMutations(n, fset, []byte(source), src)...)
+		mutations = append(mutations, GenerateLoopMutations(n, fset, []byte(source), src, nil)...)
 		return true
 	})
 
@@ -302,7 +302,7 @@ func TestGenerateLoopMutations_RecursiveCallRemoval(t *testing.T) {
 
 	var mutations []m.Mutation
 	ast.Inspect(file, func(n ast.Node) bool {
-		mutations = append(mutations, GenerateLoopMutations(n, fset, content, src)...)
+		mutations = append(mutations, GenerateLoopMutations(n, fset, content, src, nil)...)
 		return true
 	})
 
@@ -6,80 +6,167 @@ import (
 	"go/ast"
 	"go/constant"
 	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
 
 	m "gooze.dev/pkg/gooze/internal/model"
 )
 
+// NumberVariant names one replacement the numbers mutagen can produce for a
+// numeric literal.
+type NumberVariant string
+
+// Supported number variants.
+const (
+	// NumberZero replaces the literal with 0 (or 0.0).
+	NumberZero NumberVariant = "zero"
+	// NumberOne replaces the literal with 1 (or 1.0).
+	NumberOne NumberVariant = "one"
+	// NumberIncrement replaces n with n+1.
+	NumberIncrement NumberVariant = "increment"
+	// NumberDecrement replaces n with n-1.
+	NumberDecrement NumberVariant = "decrement"
+	// NumberNegate replaces n with -n.
+	NumberNegate NumberVariant = "negate"
+)
+
+// DefaultNumberVariants are the variants produced by GenerateNumberMutations.
+var DefaultNumberVariants = []NumberVariant{NumberZero, NumberOne}
+
+// IsValidNumberVariant reports whether v is a known number variant.
+func IsValidNumberVariant(v NumberVariant) bool {
+	switch v {
+	case NumberZero, NumberOne, NumberIncrement, NumberDecrement, NumberNegate:
+		return true
+	default:
+		return false
+	}
+}
+
 // GenerateNumberMutations generates numeric literal mutations for the given AST node.
 //
 // Currently supported:
 //   - token.INT:  mutate to 0 and/or 1
 //   - token.FLOAT: mutate to 0.0 and/or 1.0
-func GenerateNumberMutations(n ast.Node, fset *token.FileSet, content []byte, source m.Source) []m.Mutation {
-	lit, ok := n.(*ast.BasicLit)
-	if !ok {
-		return nil
+func GenerateNumberMutations(n ast.Node, fset *token.FileSet, content []byte, source m.Source, info *types.Info) []m.Mutation {
+	return NumberMutations(DefaultNumberVariants)(n, fset, content, source, info)
+}
+
+// NumberMutations returns a numbers generator that produces the given variants,
+// in order. Variants that would leave the literal unchanged or repeat an earlier
+// replacement are skipped.
+func NumberMutations(variants []NumberVariant) Generator {
+	return func(n ast.Node, fset *token.FileSet, content []byte, source m.Source, _ *types.Info) []m.Mutation {
+		lit, ok := n.(*ast.BasicLit)
+		if !ok {
+			return nil
+		}
+
+		if lit.Kind != token.INT && lit.Kind != token.FLOAT {
+			return nil
+		}
+
+		start, ok := offsetForPos(fset, lit.Pos())
+		if !ok {
+			return nil
+		}
+
+		end := start + len(lit.Value)
+
+		alternatives := numberAlternatives(lit.Kind, lit.Value, variants)
+		if len(alternatives) == 0 {
+			return nil
+		}
+
+		mutations := make([]m.Mutation, 0, len(alternatives))
+		for _, alt := range alternatives {
+			mutatedCode := replaceRange(content, start, end, alt)
+			diff := diffCode(content, mutatedCode)
+			h := sha256.Sum256(mutatedCode)
+			id := fmt.Sprintf("%x", h)
+			mutations = append(mutations, m.Mutation{
+				ID:          id,
+				Source:      source,
+				Type:        m.MutationNumbers,
+				MutatedCode: mutatedCode,
+				DiffCode:    diff,
+			})
+		}
+
+		return mutations
 	}
+}
 
-	if lit.Kind != token.INT && lit.Kind != token.FLOAT {
+func numberAlternatives(kind token.Token, literal string, variants []NumberVariant) []string {
+	original := constant.MakeFromLiteral(literal, kind, 0)
+	if original.Kind() == constant.Unknown {
 		return nil
 	}
 
-	start, ok := offsetForPos(fset, lit.Pos())
-	if !ok {
+	if kind != token.INT && kind != token.FLOAT {
 		return nil
 	}
 
-	end := start + len(lit.Value)
+	alternatives := make([]string, 0, len(variants))
+	seen := map[string]struct{}{}
 
-	alternatives := numberAlternatives(lit.Kind, lit.Value)
-	if len(alternatives) == 0 {
-		return nil
-	}
+	for _, variant := range variants {
+		value, ok := numberVariantValue(original, variant)
+		if !ok || constant.Compare(original, token.EQL, value) {
+			continue
+		}
+
+		lit := formatNumber(value, kind)
+		if _, dup := seen[lit]; dup {
+			continue
+		}
 
-	mutations := make([]m.Mutation, 0, len(alternatives))
-	for _, alt := range alternatives {
-		mutatedCode := replaceRange(content, start, end, alt)
-		diff := diffCode(content, mutatedCode)
-		h := sha256.Sum256(mutatedCode)
-		id := fmt.Sprintf("%x", h)
-		mutations = append(mutations, m.Mutation{
-			ID:          id,
-			Source:      source,
-			Type:        m.MutationNumbers,
-			MutatedCode: mutatedCode,
-			DiffCode:    diff,
-		})
+		seen[lit] = struct{}{}
+		alternatives = append(alternatives, lit)
 	}
 
-	return mutations
+	return alternatives
 }
 
-func numberAlternatives(kind token.Token, literal string) []string {
-	original := constant.MakeFromLiteral(literal, kind, 0)
-	if original.Kind() == constant.Unknown {
-		return nil
-	}
+func numberVariantValue(original constant.Value, variant NumberVariant) (constant.Value, bool) {
+	one := constant.MakeInt64(1)
 
-	switch kind { //nolint:exhaustive
-	case token.INT:
-		return numericAlternatives(original, constant.MakeInt64(0), constant.MakeInt64(1), "0", "1")
-	case token.FLOAT:
-		return numericAlternatives(original, constant.MakeFloat64(0), constant.MakeFloat64(1), "0.0", "1.0")
+	switch variant {
+	case NumberZero:
+		return constant.MakeInt64(0), true
+	case NumberOne:
+		return one, true
+	case NumberIncrement:
+		return constant.BinaryOp(original, token.ADD, one), true
+	case NumberDecrement:
+		return constant.BinaryOp(original, token.SUB, one), true
+	case NumberNegate:
+		return constant.UnaryOp(token.SUB, original, 0), true
 	default:
-		return nil
+		return nil, false
 	}
 }
 
-func numericAlternatives(original, zero, one constant.Value, zeroLit, oneLit string) []string {
-	variants := make([]string, 0, 2)
-	if !constant.Compare(original, token.EQL, zero) {
-		variants = append(variants, zeroLit)
+// formatNumber renders value as a literal of the original kind. Negative values
+// are parenthesized so they stay a single operand (e.g. `a - (-1)`).
+func formatNumber(value constant.Value, kind token.Token) string {
+	var lit string
+
+	if kind == token.FLOAT {
+		f, _ := constant.Float64Val(value)
+		lit = strconv.FormatFloat(f, 'g', -1, 64)
+
+		if !strings.ContainsAny(lit, ".eEn") {
+			lit += ".0"
+		}
+	} else {
+		lit = value.ExactString()
 	}
 
-	if !constant.Compare(original, token.EQL, one) {
-		variants = append(variants, oneLit)
+	if strings.HasPrefix(lit, "-") {
+		return "(" + lit + ")"
 	}
 
-	return variants
+	return lit
 }
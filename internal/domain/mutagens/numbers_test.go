@@ -4,6 +4,7 @@ import (
 	"go/ast"
 	"go/parser"
 	"go/token"
+	"strings"
 	"testing"
 
 	m "gooze.dev/pkg/gooze/internal/model"
@@ -59,7 +60,7 @@ func TestGenerateNumberMutations(t *testing.T) {
 
 			var mutations []m.Mutation
 			ast.Inspect(file, func(n ast.Node) bool {
-				mutations = append(mutations, GenerateNumberMutations(n, fset, []byte(tt.code), source)...)
+				mutations = append(mutations, GenerateNumberMutations(n, fset, []byte(tt.code), source, nil)...)
 				return true
 			})
 
@@ -78,3 +79,64 @@ func TestGenerateNumberMutations(t *testing.T) {
 		})
 	}
 }
+
+func TestNumberAlternatives(t *testing.T) {
+	all := []NumberVariant{NumberZero, NumberOne, NumberIncrement, NumberDecrement, NumberNegate}
+
+	tests := []struct {
+		name     string
+		kind     token.Token
+		literal  string
+		variants []NumberVariant
+		expected []string
+	}{
+		{"default variants", token.INT, "5", DefaultNumberVariants, []string{"0", "1"}},
+		{"all variants on int", token.INT, "5", all, []string{"0", "1", "6", "4", "(-5)"}},
+		{"duplicates and no-ops are skipped", token.INT, "1", all, []string{"0", "2", "(-1)"}},
+		{"zero is not negated", token.INT, "0", []NumberVariant{NumberNegate, NumberDecrement}, []string{"(-1)"}},
+		{"float variants", token.FLOAT, "2.5", all, []string{"0.0", "1.0", "3.5", "1.5", "(-2.5)"}},
+		{"hex literal", token.INT, "0x10", []NumberVariant{NumberIncrement}, []string{"17"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := numberAlternatives(tt.kind, tt.literal, tt.variants)
+			if len(got) != len(tt.expected) {
+				t.Fatalf("numberAlternatives(%q) = %v, want %v", tt.literal, got, tt.expected)
+			}
+
+			for i := range got {
+				if got[i] != tt.expected[i] {
+					t.Errorf("numberAlternatives(%q)[%d] = %q, want %q", tt.literal, i, got[i], tt.expected[i])
+				}
+			}
+		})
+	}
+}
+
+func TestNumberMutations_ParenthesizesNegatives(t *testing.T) {
+	code := "package main\nfunc f(a int) int { return a - 3 }"
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", code, parser.AllErrors)
+	if err != nil {
+		t.Fatalf("failed to parse code: %v", err)
+	}
+
+	gen := NumberMutations([]NumberVariant{NumberNegate})
+	source := m.Source{Origin: &m.File{FullPath: "test.go"}}
+
+	var mutations []m.Mutation
+	ast.Inspect(file, func(n ast.Node) bool {
+		mutations = append(mutations, gen(n, fset, []byte(code), source, nil)...)
+		return true
+	})
+
+	if len(mutations) != 1 {
+		t.Fatalf("expected 1 mutation, got %d", len(mutations))
+	}
+
+	if want := "return a - (-3)"; !strings.Contains(string(mutations[0].MutatedCode), want) {
+		t.Errorf("expected mutated code to contain %q, got:\n%s", want, mutations[0].MutatedCode)
+	}
+}
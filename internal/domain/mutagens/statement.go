@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"go/ast"
 	"go/token"
+	"go/types"
 
 	m "gooze.dev/pkg/gooze/internal/model"
 )
@@ -17,7 +18,7 @@ import (
 // - Defer statements
 // - Go statements (goroutine launches)
 // - Send statements (channel sends).
-func GenerateStatementMutations(n ast.Node, fset *token.FileSet, content []byte, source m.Source) []m.Mutation {
+func GenerateStatementMutations(n ast.Node, fset *token.FileSet, content []byte, source m.Source, _ *types.Info) []m.Mutation {
 	switch stmt := n.(type) {
 	case *ast.AssignStmt:
 		return deleteStatement(stmt, fset, content, source)
@@ -31,7 +31,7 @@ func TestGenerateStatementMutations_AssignmentDeletion(t *testing.T) {
 
 	var mutations []m.Mutation
 	ast.Inspect(file, func(n ast.Node) bool {
-		mutations = append(mutations, GenerateStatementMutations(n, fset, content, src)...)
+		mutations = append(mutations, GenerateStatementMutations(n, fset, content, src, nil)...)
 		return true
 	})
 
@@ -75,7 +75,7 @@ func TestGenerateStatementMutations_ExpressionDeletion(t *testing.T) {
 
 	var mutations []m.Mutation
 	ast.Inspect(file, func(n ast.Node) bool {
-		mutations = append(mutations, GenerateStatementMutations(n, fset, content, src)...)
+		mutations = append(mutations, GenerateStatementMutations(n, fset, content, src, nil)...)
 		return true
 	})
 
@@ -125,7 +125,7 @@ func TestGenerateStatementMutations_DeferDeletion(t *testing.T) {
 
 	var mutations []m.Mutation
 	ast.Inspect(file, func(n ast.Node) bool {
-		mutations = append(mutations, GenerateStatementMutations(n, fset, content, src)...)
+		mutations = append(mutations, GenerateStatementMutations(n, fset, content, src, nil)...)
 		return true
 	})
 
@@ -168,7 +168,7 @@ func TestGenerateStatementMutations_GoDeletion(t *testing.T) {
 
 	var mutations []m.Mutation
 	ast.Inspect(file, func(n ast.Node) bool {
-		mutations = append(mutations, GenerateStatementMutations(n, fset, content, src)...)
+		mutations = append(mutations, GenerateStatementMutations(n, fset, content, src, nil)...)
 		return true
 	})
 
@@ -211,7 +211,7 @@ func TestGenerateStatementMutations_SendStatementDeletion(t *testing.T) {
 
 	var mutations []m.Mutation
 	ast.Inspect(file, func(n ast.Node) bool {
-		mutations = append(mutations, GenerateStatementMutations(n, fset, content, src)...)
+		mutations = append(mutations, GenerateStatementMutations(n, fset, content, src, nil)...)
 		return true
 	})
 
@@ -254,7 +254,7 @@ func TestGenerateStatementMutations_OnlyDeletions(t *testing.T) {
 
 	var mutations []m.Mutation
 	ast.Inspect(file, func(n ast.Node) bool {
-		mutations = append(mutations, GenerateStatementMutations(n, fset, content, src)...)
+		mutations = append(mutations, GenerateStatementMutations(n, fset, content, src, nil)...)
 		return true
 	})
 
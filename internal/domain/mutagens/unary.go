@@ -5,12 +5,13 @@ import (
 	"fmt"
 	"go/ast"
 	"go/token"
+	"go/types"
 
 	m "gooze.dev/pkg/gooze/internal/model"
 )
 
 // GenerateUnaryMutations generates unary operator mutations for the given AST node.
-func GenerateUnaryMutations(n ast.Node, fset *token.FileSet, content []byte, source m.Source) []m.Mutation {
+func GenerateUnaryMutations(n ast.Node, fset *token.FileSet, content []byte, source m.Source, _ *types.Info) []m.Mutation {
 	unaryExpr, ok := n.(*ast.UnaryExpr)
 	if !ok {
 		return nil
@@ -59,7 +59,7 @@ func TestGenerateUnaryMutations(t *testing.T) {
 			var mutations []m.Mutation
 
 			ast.Inspect(file, func(n ast.Node) bool {
-				mutations = append(mutations, GenerateUnaryMutations(n, fset, []byte(tt.code), source)...)
+				mutations = append(mutations, GenerateUnaryMutations(n, fset, []byte(tt.code), source, nil)...)
 				return true
 			})
 
@@ -0,0 +1,208 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"strings"
+
+	m "gooze.dev/pkg/gooze/internal/model"
+)
+
+// astPath tracks the position of ast.Inspect in a file as a path of steps from
+// the enclosing top-level declaration. A step names the node type and how many
+// earlier siblings of that type its parent has, so the path to a node does not
+// change when code is added elsewhere in the file.
+type astPath struct {
+	steps []astStep
+	decls map[string]int
+}
+
+type astStep struct {
+	node     ast.Node
+	label    string
+	siblings map[string]int
+}
+
+// push enters n, which must be a child of the current top of the path.
+func (p *astPath) push(n ast.Node) {
+	var label string
+
+	switch {
+	case len(p.steps) == 0:
+		// The *ast.File itself.
+	case len(p.steps) == 1:
+		label = p.declLabel(n)
+	default:
+		kind := strings.TrimPrefix(fmt.Sprintf("%T", n), "*ast.")
+		parent := p.steps[len(p.steps)-1]
+		label = fmt.Sprintf("%s%d", kind, parent.siblings[kind])
+		parent.siblings[kind]++
+	}
+
+	p.steps = append(p.steps, astStep{node: n, label: label, siblings: map[string]int{}})
+}
+
+func (p *astPath) pop() {
+	if len(p.steps) > 0 {
+		p.steps = p.steps[:len(p.steps)-1]
+	}
+}
+
+// declLabel names a top-level declaration: funcs by (receiver and) name, other
+// declarations by their first declared name. Repeated labels (several init
+// funcs, blank vars) are numbered in order.
+func (p *astPath) declLabel(n ast.Node) string {
+	label := strings.TrimPrefix(fmt.Sprintf("%T", n), "*ast.")
+
+	switch decl := n.(type) {
+	case *ast.FuncDecl:
+		label = funcDeclName(decl)
+	case *ast.GenDecl:
+		label = decl.Tok.String()
+		if name := firstSpecName(decl); name != "" {
+			label += " " + name
+		}
+	}
+
+	if p.decls == nil {
+		p.decls = map[string]int{}
+	}
+
+	seen := p.decls[label]
+	p.decls[label]++
+
+	if seen > 0 {
+		label = fmt.Sprintf("%s#%d", label, seen)
+	}
+
+	return label
+}
+
+// decl returns the label of the enclosing top-level declaration.
+func (p *astPath) decl() string {
+	if len(p.steps) < 2 {
+		return ""
+	}
+
+	return p.steps[1].label
+}
+
+// declNode returns the enclosing top-level declaration, or nil outside one.
+func (p *astPath) declNode() ast.Node {
+	if len(p.steps) < 2 {
+		return nil
+	}
+
+	return p.steps[1].node
+}
+
+func (p *astPath) String() string {
+	labels := make([]string, 0, len(p.steps))
+	for _, step := range p.steps[min(len(p.steps), 2):] {
+		labels = append(labels, step.label)
+	}
+
+	return strings.Join(labels, "/")
+}
+
+func funcDeclName(decl *ast.FuncDecl) string {
+	if decl.Recv == nil || len(decl.Recv.List) == 0 {
+		return decl.Name.Name
+	}
+
+	pointer := ""
+	if _, ok := decl.Recv.List[0].Type.(*ast.StarExpr); ok {
+		pointer = "*"
+	}
+
+	name := receiverName(decl)
+	if name == "" {
+		name = "?"
+	}
+
+	return "(" + pointer + name + ")." + decl.Name.Name
+}
+
+func firstSpecName(decl *ast.GenDecl) string {
+	if len(decl.Specs) == 0 {
+		return ""
+	}
+
+	switch spec := decl.Specs[0].(type) {
+	case *ast.ValueSpec:
+		if len(spec.Names) > 0 {
+			return spec.Names[0].Name
+		}
+	case *ast.TypeSpec:
+		return spec.Name.Name
+	case *ast.ImportSpec:
+		return spec.Path.Value
+	}
+
+	return ""
+}
+
+// stableMutationID identifies a mutation by the file, the enclosing top-level
+// declaration, the path to the mutated node, the mutagen and the replacement it
+// made. Unlike a hash of the whole mutated file, it survives edits elsewhere in
+// the file.
+func stableMutationID(source m.Source, path *astPath, mutationType m.MutationType, content, mutated []byte) string {
+	file := ""
+	if source.Origin != nil {
+		file = string(source.Origin.ShortPath)
+		if file == "" {
+			file = string(source.Origin.FullPath)
+		}
+	}
+
+	original, replacement := changedSpans(content, mutated)
+
+	h := sha256.New()
+	for _, part := range []string{file, path.decl(), path.String(), mutationType.Name, string(original), string(replacement)} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+// changedSpans trims the common prefix and suffix of a and b and returns what
+// is left of each: the original code a mutation replaced and its replacement.
+func changedSpans(a, b []byte) ([]byte, []byte) {
+	start := firstDifference(a, b)
+	if start < 0 {
+		return nil, nil
+	}
+
+	end := 0
+	for end < len(a)-start && end < len(b)-start && a[len(a)-1-end] == b[len(b)-1-end] {
+		end++
+	}
+
+	return a[start : len(a)-end], b[start : len(b)-end]
+}
+
+// scopeHash hashes the source of a top-level declaration. Results of mutations
+// in a declaration whose source (and tests) did not change can be reused from
+// the cache.
+func scopeHash(decl ast.Node, fset *token.FileSet, content []byte) string {
+	if decl == nil {
+		return ""
+	}
+
+	file := fset.File(decl.Pos())
+	if file == nil {
+		return ""
+	}
+
+	start, end := file.Offset(decl.Pos()), file.Offset(decl.End())
+	if start < 0 || end > len(content) || start > end {
+		return ""
+	}
+
+	h := sha256.Sum256(content[start:end])
+
+	return fmt.Sprintf("%x", h)
+}
@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"gooze.dev/pkg/gooze/internal/domain/mutagens"
+	m "gooze.dev/pkg/gooze/internal/model"
+)
+
+func collectTestMutations(t *testing.T, src string, mutationType m.MutationType, gen mutagens.Generator) []m.Mutation {
+	t.Helper()
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "p.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+
+	source := m.Source{Origin: &m.File{FullPath: "/abs/p.go", ShortPath: "p.go"}}
+
+	return collectMutations(mutationType, gen, file, fset, []byte(src), source, nil, nil, funcMatcher{})
+}
+
+func TestCollectMutations_StableIDs(t *testing.T) {
+	const original = "package p\n\n" +
+		"func add(a, b int) int { return a + b }\n\n" +
+		"func sub(a, b int) int { return a - b }\n"
+	const edited = "package p\n\n" +
+		"// a new comment shifts every offset\n" +
+		"var limit = 10\n\n" +
+		"func add(a, b int) int { return a + b }\n\n" +
+		"func sub(a, b int) int { x := 1; _ = x; return a - b }\n"
+
+	before := collectTestMutations(t, original, m.MutationArithmetic, mutagens.GenerateArithmeticMutations)
+	after := collectTestMutations(t, edited, m.MutationArithmetic, mutagens.GenerateArithmeticMutations)
+
+	if len(before) != 8 || len(after) != 8 {
+		t.Fatalf("expected 8 mutations before and after, got %d and %d", len(before), len(after))
+	}
+
+	ids := map[string]bool{}
+	for _, mutation := range before {
+		if ids[mutation.ID] {
+			t.Fatalf("duplicate mutation ID %s", mutation.ID)
+		}
+
+		ids[mutation.ID] = true
+	}
+
+	for i, mutation := range after {
+		if mutation.ID != before[i].ID {
+			t.Errorf("mutation %d: ID changed from %s to %s", i, before[i].ID, mutation.ID)
+		}
+	}
+
+	// add is untouched; sub gained a statement.
+	if after[0].Scope != before[0].Scope {
+		t.Errorf("expected the scope of the unchanged function to be stable")
+	}
+
+	if after[4].Scope == before[4].Scope {
+		t.Errorf("expected the scope of the edited function to change")
+	}
+}
+
+func TestCollectMutations_IDsDistinguishReplacementsAndDecls(t *testing.T) {
+	const src = "package p\n\n" +
+		"func init() { _ = 1 }\n\n" +
+		"func init() { _ = 1 }\n\n" +
+		"type T struct{}\n\n" +
+		"func (T) one() int { return 1 }\n\n" +
+		"func (*T) two() int { return 1 }\n"
+
+	mutations := collectTestMutations(t, src, m.MutationNumbers, mutagens.GenerateNumberMutations)
+
+	// Each of the four literals becomes 0 (1 -> 1 is skipped).
+	if len(mutations) != 4 {
+		t.Fatalf("expected 4 mutations, got %d", len(mutations))
+	}
+
+	ids := map[string]bool{}
+	for _, mutation := range mutations {
+		if ids[mutation.ID] {
+			t.Fatalf("duplicate mutation ID %s", mutation.ID)
+		}
+
+		ids[mutation.ID] = true
+	}
+}
+
+func TestChangedSpans(t *testing.T) {
+	original, replacement := changedSpans([]byte("a + b"), []byte("a - b"))
+	if string(original) != "+" || string(replacement) != "-" {
+		t.Fatalf("got %q -> %q", original, replacement)
+	}
+
+	original, replacement = changedSpans([]byte("x = 1\ny = 2\n"), []byte("y = 2\n"))
+	if string(original) != "x = 1\n" || string(replacement) != "" {
+		t.Fatalf("got %q -> %q", original, replacement)
+	}
+}
@@ -5,49 +5,102 @@ import (
 	pkg "gooze.dev/pkg/gooze/pkg"
 )
 
-func mutationScoreFromReports(reports pkg.FileSpill[m.Report]) (float64, error) {
-	killed := 0
-	total := 0
+// scoreTally counts the mutations that make up a mutation score.
+type scoreTally struct {
+	killed  int
+	scored  int
+	skipped int
+}
+
+func (t *scoreTally) add(other scoreTally) {
+	t.killed += other.killed
+	t.scored += other.scored
+	t.skipped += other.skipped
+}
+
+// score returns the killed/scored ratio in the 0..1 range the reporters expect
+// (they render it as a percentage).
+func (t scoreTally) score() float64 {
+	return mutationScore(t.killed, t.scored)
+}
+
+// sampled reports whether mutants were skipped, so the score is an estimate.
+func (t scoreTally) sampled() bool {
+	return t.skipped > 0
+}
+
+// interval returns the 95% confidence interval of the score of all mutants,
+// estimated from the tested sample.
+func (t scoreTally) interval() (low, high float64) {
+	return pkg.ScoreInterval(t.killed, t.scored, t.scored+t.skipped)
+}
+
+func tallyReports(reports pkg.FileSpill[m.Report]) (scoreTally, error) {
+	var tally scoreTally
 
 	err := reports.Range(func(_ uint64, report m.Report) error {
-		k, t := countReport(report)
-		killed += k
-		total += t
+		tally.add(countReport(report))
 
 		return nil
 	})
+
+	return tally, err
+}
+
+func tallyReportSlice(reports []m.Report) scoreTally {
+	var tally scoreTally
+
+	for _, report := range reports {
+		tally.add(countReport(report))
+	}
+
+	return tally
+}
+
+func mutationScoreFromReports(reports pkg.FileSpill[m.Report]) (float64, error) {
+	tally, err := tallyReports(reports)
 	if err != nil {
 		return 0.0, err
 	}
 
-	return mutationScore(killed, total), nil
+	return tally.score(), nil
 }
 
 func mutationScoreFromReportSlice(reports []m.Report) float64 {
-	killed := 0
-	total := 0
+	return tallyReportSlice(reports).score()
+}
 
-	for _, report := range reports {
-		k, t := countReport(report)
-		killed += k
-		total += t
-	}
+// countReport counts the killed and scored mutations of a report. Equivalent
+// mutations cannot be killed, so they are left out of the score entirely, as are
+// flaky mutations, whose outcome is unknown, and duplicates, whose result is
+// already counted for the mutation they duplicate.
+// Skipped mutations were not tested; they only widen the score's interval.
+func countReport(report m.Report) scoreTally {
+	var tally scoreTally
 
-	return mutationScore(killed, total)
-}
+	if report.DuplicateOf != "" {
+		return tally
+	}
 
-func countReport(report m.Report) (killed, total int) {
 	for _, entries := range report.Result {
 		for _, entry := range entries {
-			total++
+			switch entry.Status {
+			case m.Equivalent, m.Flaky:
+				continue
+			case m.Skipped:
+				tally.skipped++
+				continue
+			}
+
+			tally.scored++
 
 			if entry.Status == m.Killed {
-				killed++
+				tally.killed++
 			}
 		}
 	}
 
-	return killed, total
+	return tally
 }
 
 // mutationScore returns the killed/total ratio in the 0..1 range the reporters
@@ -44,7 +44,7 @@ func TestMutationScoreFromReports(t *testing.T) {
 	score, err := mutationScoreFromReports(spill)
 	require.NoError(t, err)
 
-	require.Equal(t, 0.4, score)
+	require.Equal(t, 0.5, score)
 }
 
 func TestMutationScoreFromReports_NotCoveredCountsAsSurvivor(t *testing.T) {
@@ -69,6 +69,74 @@ func TestMutationScoreFromReports_NotCoveredCountsAsSurvivor(t *testing.T) {
 	require.Equal(t, 0.5, score)
 }
 
+func TestMutationScoreFromReports_EquivalentIsExcluded(t *testing.T) {
+	spill, err := goozepkg.NewFileSpill[m.Report]()
+	require.NoError(t, err)
+	defer spill.Close()
+
+	report := m.Report{
+		Result: m.Result{
+			m.MutationBoolean: {
+				{MutationID: "m1", Status: m.Killed, Err: nil},
+				{MutationID: "m2", Status: m.Equivalent, Err: nil},
+				{MutationID: "m3", Status: m.Equivalent, Err: nil},
+			},
+		},
+	}
+
+	require.NoError(t, spill.Append(report))
+
+	score, err := mutationScoreFromReports(spill)
+	require.NoError(t, err)
+
+	require.Equal(t, 1.0, score)
+}
+
+func TestMutationScoreFromReports_FlakyIsExcluded(t *testing.T) {
+	spill, err := goozepkg.NewFileSpill[m.Report]()
+	require.NoError(t, err)
+	defer spill.Close()
+
+	report := m.Report{
+		Result: m.Result{
+			m.MutationBoolean: {
+				{MutationID: "m1", Status: m.Killed, Err: nil},
+				{MutationID: "m2", Status: m.Survived, Err: nil},
+				{MutationID: "m3", Status: m.Flaky, Err: nil},
+			},
+		},
+	}
+
+	require.NoError(t, spill.Append(report))
+
+	score, err := mutationScoreFromReports(spill)
+	require.NoError(t, err)
+
+	require.Equal(t, 0.5, score)
+}
+
+func TestMutationScoreFromReports_DuplicatesAreExcluded(t *testing.T) {
+	spill, err := goozepkg.NewFileSpill[m.Report]()
+	require.NoError(t, err)
+	defer spill.Close()
+
+	require.NoError(t, spill.Append(m.Report{
+		Result: m.Result{m.MutationBoolean: {{MutationID: "m1", Status: m.Killed}}},
+	}))
+	require.NoError(t, spill.Append(m.Report{
+		Result: m.Result{m.MutationBoolean: {{MutationID: "m2", Status: m.Survived}}},
+	}))
+	require.NoError(t, spill.Append(m.Report{
+		Result:      m.Result{m.MutationBranch: {{MutationID: "m3", Status: m.Survived}}},
+		DuplicateOf: "m2",
+	}))
+
+	score, err := mutationScoreFromReports(spill)
+	require.NoError(t, err)
+
+	require.Equal(t, 0.5, score)
+}
+
 func TestMutationScoreFromReports_EmptySpillIsFull(t *testing.T) {
 	spill, err := goozepkg.NewFileSpill[m.Report]()
 	require.NoError(t, err)
@@ -0,0 +1,189 @@
+package domain
+
+import (
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"strings"
+
+	"gooze.dev/pkg/gooze/internal/domain/mutagens"
+	m "gooze.dev/pkg/gooze/internal/model"
+)
+
+// OperatorLevel names a preset of mutagen parameters.
+type OperatorLevel string
+
+// Supported operator levels.
+const (
+	// LevelLight produces the fewest mutants per operator, for fast (e.g. nightly) runs.
+	LevelLight OperatorLevel = "light"
+	// LevelDefault is the historical behavior of every mutagen.
+	LevelDefault OperatorLevel = "default"
+	// LevelStrong produces every supported replacement.
+	LevelStrong OperatorLevel = "strong"
+)
+
+// OperatorConfig holds the parameters the mutagens run with. Level selects a
+// preset; any per-mutagen field that is set overrides the preset's value. Rules
+// are applied on top of the built-in equivalence rules at every level.
+// HigherOrder, when 2 or more, combines up to that many first-order mutants of
+// the same function into one higher-order mutant.
+type OperatorConfig struct {
+	Level       OperatorLevel
+	Numbers     []mutagens.NumberVariant
+	Comparison  mutagens.ComparisonMode
+	Rules       []EquivalenceRule
+	HigherOrder int
+}
+
+var operatorPresets = map[OperatorLevel]OperatorConfig{
+	LevelLight: {
+		Numbers:    []mutagens.NumberVariant{mutagens.NumberZero},
+		Comparison: mutagens.ComparisonBoundary,
+	},
+	LevelDefault: {
+		Numbers:    mutagens.DefaultNumberVariants,
+		Comparison: mutagens.ComparisonFull,
+	},
+	LevelStrong: {
+		Numbers: []mutagens.NumberVariant{
+			mutagens.NumberZero, mutagens.NumberOne, mutagens.NumberIncrement, mutagens.NumberDecrement, mutagens.NumberNegate,
+		},
+		Comparison: mutagens.ComparisonFull,
+	},
+}
+
+// Resolve fills unset fields from the selected preset (the default level when
+// none is given) and validates the result.
+func (c OperatorConfig) Resolve() (OperatorConfig, error) {
+	level := OperatorLevel(strings.ToLower(strings.TrimSpace(string(c.Level))))
+	if level == "" {
+		level = LevelDefault
+	}
+
+	preset, ok := operatorPresets[level]
+	if !ok {
+		return OperatorConfig{}, fmt.Errorf("unknown operator level %q (want light, default or strong)", c.Level)
+	}
+
+	resolved := preset
+	resolved.Level = level
+
+	if len(c.Numbers) > 0 {
+		for _, variant := range c.Numbers {
+			if !mutagens.IsValidNumberVariant(variant) {
+				return OperatorConfig{}, fmt.Errorf("unknown numbers variant %q", variant)
+			}
+		}
+
+		resolved.Numbers = c.Numbers
+	}
+
+	if c.Comparison != "" {
+		if !mutagens.IsValidComparisonMode(c.Comparison) {
+			return OperatorConfig{}, fmt.Errorf("unknown comparison mode %q", c.Comparison)
+		}
+
+		resolved.Comparison = c.Comparison
+	}
+
+	if c.HigherOrder < 0 {
+		return OperatorConfig{}, fmt.Errorf("invalid higher order %d (want 0 to disable, or 2 or more)", c.HigherOrder)
+	}
+
+	resolved.HigherOrder = c.HigherOrder
+
+	for _, rule := range c.Rules {
+		rule, err := rule.validate()
+		if err != nil {
+			return OperatorConfig{}, err
+		}
+
+		resolved.Rules = append(resolved.Rules, rule)
+	}
+
+	return resolved, nil
+}
+
+// MutationType returns the effective mutation type for base under this config.
+// A mutagen running with non-default parameters gets a distinct version, so
+// cached results produced under other parameters are invalidated.
+func (c OperatorConfig) MutationType(base m.MutationType) m.MutationType {
+	params := c.params(base)
+	if params == operatorPresets[LevelDefault].params(base) {
+		return base
+	}
+
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(params))
+
+	return m.MutationType{Name: base.Name, Version: base.Version*1_000_000 + int(h.Sum32()%1_000_000)}
+}
+
+// MutationTypes maps each base type to its effective type.
+func (c OperatorConfig) MutationTypes(bases ...m.MutationType) []m.MutationType {
+	types := make([]m.MutationType, 0, len(bases))
+	for _, base := range bases {
+		types = append(types, c.MutationType(base))
+	}
+
+	return types
+}
+
+// params canonically encodes the parameters that affect base's generator. Unset
+// fields encode as their default so a zero config matches the default level.
+func (c OperatorConfig) params(base m.MutationType) string {
+	params := c.generatorParams(base)
+
+	for _, rule := range c.Rules {
+		if rule.ignoreRule().ignores(base) {
+			params += ";" + rule.String()
+		}
+	}
+
+	return params
+}
+
+func (c OperatorConfig) generatorParams(base m.MutationType) string {
+	switch base {
+	case m.MutationNumbers:
+		numbers := c.Numbers
+		if len(numbers) == 0 {
+			numbers = mutagens.DefaultNumberVariants
+		}
+
+		variants := make([]string, 0, len(numbers))
+		for _, variant := range numbers {
+			variants = append(variants, string(variant))
+		}
+
+		return strings.Join(variants, ",")
+	case m.MutationComparison:
+		if c.Comparison == "" {
+			return string(mutagens.ComparisonFull)
+		}
+
+		return string(c.Comparison)
+	default:
+		return ""
+	}
+}
+
+// generators returns the generator for every mutation type, parameterized by
+// this config. Types without parameters use their package-level generator.
+func (c OperatorConfig) generators() map[m.MutationType]mutagens.Generator {
+	generators := make(map[m.MutationType]mutagens.Generator, len(mutationGenerators))
+	for mutationType, gen := range mutationGenerators {
+		generators[mutationType] = gen
+	}
+
+	if len(c.Numbers) > 0 {
+		generators[m.MutationNumbers] = mutagens.NumberMutations(slices.Clone(c.Numbers))
+	}
+
+	if c.Comparison != "" {
+		generators[m.MutationComparison] = mutagens.ComparisonMutations(c.Comparison)
+	}
+
+	return generators
+}
@@ -0,0 +1,152 @@
+package domain
+
+import (
+	"slices"
+	"testing"
+
+	"gooze.dev/pkg/gooze/internal/domain/mutagens"
+	m "gooze.dev/pkg/gooze/internal/model"
+)
+
+func TestOperatorConfig_Resolve(t *testing.T) {
+	tests := []struct {
+		name       string
+		config     OperatorConfig
+		level      OperatorLevel
+		numbers    []mutagens.NumberVariant
+		comparison mutagens.ComparisonMode
+		wantErr    bool
+	}{
+		{
+			name:       "zero config is the default level",
+			config:     OperatorConfig{},
+			level:      LevelDefault,
+			numbers:    mutagens.DefaultNumberVariants,
+			comparison: mutagens.ComparisonFull,
+		},
+		{
+			name:       "light preset",
+			config:     OperatorConfig{Level: "LIGHT"},
+			level:      LevelLight,
+			numbers:    []mutagens.NumberVariant{mutagens.NumberZero},
+			comparison: mutagens.ComparisonBoundary,
+		},
+		{
+			name:       "override wins over preset",
+			config:     OperatorConfig{Level: LevelLight, Comparison: mutagens.ComparisonFull},
+			level:      LevelLight,
+			numbers:    []mutagens.NumberVariant{mutagens.NumberZero},
+			comparison: mutagens.ComparisonFull,
+		},
+		{
+			name:    "unknown level",
+			config:  OperatorConfig{Level: "extreme"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown numbers variant",
+			config:  OperatorConfig{Numbers: []mutagens.NumberVariant{"double"}},
+			wantErr: true,
+		},
+		{
+			name:    "unknown comparison mode",
+			config:  OperatorConfig{Comparison: "sideways"},
+			wantErr: true,
+		},
+		{
+			name:    "malformed rule pattern",
+			config:  OperatorConfig{Rules: []EquivalenceRule{{Call: "slog.["}}},
+			wantErr: true,
+		},
+		{
+			name:    "negative higher order",
+			config:  OperatorConfig{HigherOrder: -1},
+			wantErr: true,
+		},
+		{
+			name:    "rule with unknown mutagen",
+			config:  OperatorConfig{Rules: []EquivalenceRule{{Call: "slog.*", Mutagens: []string{"everything"}}}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.config.Resolve()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %+v", got)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Resolve failed: %v", err)
+			}
+
+			if got.Level != tt.level || !slices.Equal(got.Numbers, tt.numbers) || got.Comparison != tt.comparison {
+				t.Fatalf("Resolve() = %+v, want level=%s numbers=%v comparison=%s", got, tt.level, tt.numbers, tt.comparison)
+			}
+		})
+	}
+}
+
+func TestOperatorConfig_MutationType(t *testing.T) {
+	defaults, err := OperatorConfig{}.Resolve()
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+
+	for _, base := range DefaultMutations {
+		if got := defaults.MutationType(base); got != base {
+			t.Errorf("default level changed %v to %v", base, got)
+		}
+
+		if got := (OperatorConfig{}).MutationType(base); got != base {
+			t.Errorf("zero config changed %v to %v", base, got)
+		}
+	}
+
+	light, err := OperatorConfig{Level: LevelLight}.Resolve()
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+
+	for _, base := range []m.MutationType{m.MutationNumbers, m.MutationComparison} {
+		got := light.MutationType(base)
+		if got.Name != base.Name || got.Version == base.Version {
+			t.Errorf("expected light level to bump the version of %v, got %v", base, got)
+		}
+	}
+
+	if got := light.MutationType(m.MutationArithmetic); got != m.MutationArithmetic {
+		t.Errorf("expected arithmetic to be unaffected, got %v", got)
+	}
+
+	strong, err := OperatorConfig{Level: LevelStrong}.Resolve()
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+
+	if light.MutationType(m.MutationNumbers) == strong.MutationType(m.MutationNumbers) {
+		t.Errorf("expected light and strong numbers to have distinct versions")
+	}
+
+	if got := strong.MutationTypes(DefaultMutations...); len(got) != len(DefaultMutations) {
+		t.Errorf("expected %d mutation types, got %d", len(DefaultMutations), len(got))
+	}
+
+	ruled, err := OperatorConfig{Rules: []EquivalenceRule{{Call: "metrics.*", Mutagens: []string{"Numbers"}}}}.Resolve()
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+
+	if got := ruled.MutationType(m.MutationNumbers); got == m.MutationNumbers {
+		t.Errorf("expected a numbers rule to bump the numbers version")
+	}
+
+	if got := ruled.MutationType(m.MutationArithmetic); got != m.MutationArithmetic {
+		t.Errorf("expected arithmetic to be unaffected by a numbers rule, got %v", got)
+	}
+}
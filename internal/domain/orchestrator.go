@@ -2,16 +2,21 @@ package domain
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
 
 	"gooze.dev/pkg/gooze/internal/adapter"
 	m "gooze.dev/pkg/gooze/internal/model"
 )
 
 // Orchestrator coordinates applying a mutation to a temporary copy of
-// the project and running the corresponding tests to determine whether the
-// mutation is killed or survives.
+// the project, or to an overlay of it, and running the corresponding tests to
+// determine whether the mutation is killed or survives.
 type Orchestrator interface {
 	// TestMutation runs a single mutation in a throwaway workspace.
 	TestMutation(ctx context.Context, mutation m.Mutation) (m.Result, error)
@@ -19,29 +24,64 @@ type Orchestrator interface {
 	// own one so the project is copied once per worker instead of once per
 	// mutation. A Workspace is NOT safe for concurrent use.
 	NewWorkspace() Workspace
+	// MapTestCoverage runs every test of the sources' test packages on its own
+	// against the unmutated project and records the lines each one reaches.
+	MapTestCoverage(ctx context.Context, sources []m.Source) (TestCoverage, error)
+	// Baseline runs the tests of the sources' test packages once against the
+	// unmutated project, timing each package and, with cover, recording its
+	// coverage, then reruns more times to check their outcome is stable. It
+	// fails when any of those runs fail.
+	Baseline(ctx context.Context, sources []m.Source, cover bool, reruns int) (Baseline, error)
 }
 
 // Workspace is a per-worker copy of a project in which mutations are applied and
 // tested. It lazily copies the project the first time it sees a mutation for a
 // given project root and reuses that copy for subsequent mutations of the same
-// project, restoring the mutated file after each run.
+// project, restoring the mutated file after each run. The copy includes the
+// other modules the project's build reads from disk, such as those of its
+// go.work file, and tests run from the project's module. In WorkspaceOverlay
+// mode nothing is copied: tests run in the project itself, with the mutated
+// file supplied through a `go build -overlay` file.
 type Workspace interface {
 	Run(ctx context.Context, mutation m.Mutation) (m.Result, error)
+	// Equivalent reports whether the mutated package compiles to the same code
+	// as the original (trivial compiler equivalence), in which case no test can
+	// kill the mutation.
+	Equivalent(ctx context.Context, mutation m.Mutation) (bool, error)
+	// Recheck runs the tests of the mutation last passed to Run again, runs
+	// times, each under timeout, and reports whether any outcome differed from
+	// the first. When it did, it also returns the tests that failed, or were
+	// cut off by a timeout, in any run.
+	Recheck(ctx context.Context, mutation m.Mutation, runs int, timeout time.Duration) (tests []string, flaky bool, err error)
 	Close(ctx context.Context)
 }
 
 type orchestrator struct {
 	fsAdapter   adapter.SourceFSAdapter
 	testAdapter adapter.TestRunnerAdapter
+
+	mode   WorkspaceMode
+	stable bool
+	warmUp bool
 }
 
+// OrchestratorOption configures an Orchestrator created by NewOrchestrator.
+type OrchestratorOption func(*orchestrator)
+
 // NewOrchestrator constructs an Orchestrator backed by the provided
 // filesystem and test runner adapters.
-func NewOrchestrator(fsAdapter adapter.SourceFSAdapter, testAdapter adapter.TestRunnerAdapter) Orchestrator {
-	return &orchestrator{
+func NewOrchestrator(fsAdapter adapter.SourceFSAdapter, testAdapter adapter.TestRunnerAdapter, opts ...OrchestratorOption) Orchestrator {
+	to := &orchestrator{
 		fsAdapter:   fsAdapter,
 		testAdapter: testAdapter,
+		mode:        WorkspaceCopy,
+	}
+
+	for _, opt := range opts {
+		opt(to)
 	}
+
+	return to
 }
 
 // NewWorkspace returns a fresh reusable workspace.
@@ -49,9 +89,123 @@ func (to *orchestrator) NewWorkspace() Workspace {
 	return &workspace{
 		fsAdapter:   to.fsAdapter,
 		testAdapter: to.testAdapter,
+		mode:        to.mode,
+		stable:      to.stable,
+		warmUp:      to.warmUp,
 	}
 }
 
+// MapTestCoverage builds the per-test coverage map of the sources' test
+// packages. A test whose profile cannot be produced is recorded with unknown
+// coverage, so mutants keep running it.
+func (to *orchestrator) MapTestCoverage(ctx context.Context, sources []m.Source) (TestCoverage, error) {
+	coverage := TestCoverage{}
+
+	for _, source := range sources {
+		for _, test := range source.Tests {
+			dir := m.Path(filepath.Dir(string(test.FullPath)))
+			if _, ok := coverage[dir]; ok {
+				continue
+			}
+
+			tests, err := to.mapPackageCoverage(ctx, test.FullPath, dir)
+			if err != nil {
+				return nil, err
+			}
+
+			coverage[dir] = tests
+		}
+	}
+
+	return coverage, nil
+}
+
+// Baseline runs each test package of the sources once, plus reruns times, in
+// order of first appearance, and records the longest run of each.
+func (to *orchestrator) Baseline(ctx context.Context, sources []m.Source, cover bool, reruns int) (Baseline, error) {
+	baseline := Baseline{Durations: map[m.Path]time.Duration{}}
+
+	var profiles [][]byte
+
+	for _, source := range sources {
+		for _, test := range source.Tests {
+			dir := m.Path(filepath.Dir(string(test.FullPath)))
+			if _, ok := baseline.Durations[dir]; ok {
+				continue
+			}
+
+			projectRoot, err := to.fsAdapter.FindProjectRoot(ctx, test.FullPath)
+			if err != nil {
+				return Baseline{}, fmt.Errorf("find project root: %w", err)
+			}
+
+			for run := range reruns + 1 {
+				start := time.Now()
+
+				output, profile, err := to.testAdapter.RunBaseline(ctx, string(projectRoot), string(dir), cover && run == 0)
+				if err != nil {
+					if ctx.Err() != nil {
+						return Baseline{}, ctx.Err()
+					}
+
+					if run > 0 {
+						return Baseline{}, fmt.Errorf("tests are flaky without any mutation in %s, failing on run %d of %d: %s",
+							dir, run+1, reruns+1, strings.Join(failedTests(output), ", "))
+					}
+
+					return Baseline{}, fmt.Errorf("tests fail without any mutation in %s: %w\n%s", dir, err, strings.TrimSpace(output))
+				}
+
+				baseline.Durations[dir] = max(baseline.Durations[dir], time.Since(start))
+
+				if run == 0 {
+					profiles = append(profiles, profile)
+				}
+			}
+		}
+	}
+
+	if cover {
+		baseline.Profile = mergeProfiles(profiles)
+	}
+
+	return baseline, nil
+}
+
+func (to *orchestrator) mapPackageCoverage(ctx context.Context, testPath, dir m.Path) (map[string]*CoverageIndex, error) {
+	projectRoot, err := to.fsAdapter.FindProjectRoot(ctx, testPath)
+	if err != nil {
+		return nil, fmt.Errorf("find project root: %w", err)
+	}
+
+	names, err := to.testAdapter.ListTests(ctx, string(projectRoot), string(dir))
+	if err != nil {
+		return nil, fmt.Errorf("list tests: %w", err)
+	}
+
+	tests := make(map[string]*CoverageIndex, len(names))
+
+	for _, name := range names {
+		profile, err := to.testAdapter.CoverTest(ctx, string(projectRoot), string(dir), name)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+
+			slog.Warn("Test failed while mapping coverage", "dir", dir, "test", name, "error", err)
+		}
+
+		index, parseErr := ParseCoverage(profile)
+		if len(profile) == 0 || parseErr != nil {
+			index = nil
+		}
+
+		tests[name] = index
+	}
+
+	return tests, nil
+}
+
 // TestMutation runs a single mutation in a throwaway workspace.
 func (to *orchestrator) TestMutation(ctx context.Context, mutation m.Mutation) (m.Result, error) {
 	ws := to.NewWorkspace()
@@ -63,13 +217,34 @@ func (to *orchestrator) TestMutation(ctx context.Context, mutation m.Mutation) (
 type workspace struct {
 	fsAdapter   adapter.SourceFSAdapter
 	testAdapter adapter.TestRunnerAdapter
+	mode        WorkspaceMode
+	// stable copies the project to a directory whose path is the same from
+	// one run to the next, and warmUp builds its tests before the first mutant.
+	stable bool
+	warmUp bool
 
 	projectRoot m.Path
 	tmpDir      m.Path
+	// moduleDir is the copy of projectRoot within tmpDir, which also holds the
+	// other modules of its build. In overlay mode it is projectRoot itself.
+	moduleDir m.Path
+	// overlay is the -overlay file supplying the mutated source in overlay
+	// mode, while a mutation is applied.
+	overlay m.Path
+	// env selects the applied mutation's mutant in its schema, if any.
+	env []string
+
+	// originalHashes caches the compile hash of each unmutated package directory
+	// in the current copy.
+	originalHashes map[string]string
+
+	// last is the outcome of the last Run, which Recheck compares against.
+	last testRun
 }
 
 func (ws *workspace) Run(ctx context.Context, mutation m.Mutation) (m.Result, error) {
 	if err := ctx.Err(); err != nil {
+		ws.last = testRun{status: m.Timeout}
 		return resultForStatus(mutation, m.Timeout), nil
 	}
 
@@ -77,34 +252,178 @@ func (ws *workspace) Run(ctx context.Context, mutation m.Mutation) (m.Result, er
 		return m.Result{}, err
 	}
 
-	if mutation.Source.Test == nil {
+	if len(mutation.Source.Tests) == 0 {
+		ws.last = testRun{status: m.Survived}
 		return resultForNoTest(mutation), nil
 	}
 
-	if err := ws.ensurePrepared(ctx, mutation.Source.Origin.FullPath); err != nil {
+	targets, restore, err := ws.prepareRun(ctx, mutation)
+	if err != nil {
 		return m.Result{}, err
 	}
 
+	defer restore()
+
+	ws.last = ws.runTests(ctx, targets)
+
+	return resultForStatus(mutation, ws.last.status), nil
+}
+
+// Recheck runs the tests of the mutation again, runs times, each under timeout
+// (none when zero), and compares every outcome with that of the last Run. When
+// one differs, it reports the mutation as flaky along with the tests that
+// failed, or were cut off by a timeout, in any of the runs.
+func (ws *workspace) Recheck(ctx context.Context, mutation m.Mutation, runs int, timeout time.Duration) ([]string, bool, error) {
+	if err := validateMutation(mutation); err != nil {
+		return nil, false, err
+	}
+
+	first := ws.last
+
+	targets, restore, err := ws.prepareRun(ctx, mutation)
+	if err != nil {
+		return nil, false, err
+	}
+
+	defer restore()
+
+	involved := map[string]bool{}
+	flaky := false
+
+	for _, test := range first.tests {
+		involved[test] = true
+	}
+
+	for range runs {
+		runCtx := ctx
+
+		var cancel context.CancelFunc
+		if timeout > 0 {
+			runCtx, cancel = context.WithTimeout(ctx, timeout)
+		}
+
+		run := ws.runTests(runCtx, targets)
+
+		if cancel != nil {
+			cancel()
+		}
+
+		if err := ctx.Err(); err != nil {
+			return nil, false, err
+		}
+
+		for _, test := range run.tests {
+			involved[test] = true
+		}
+
+		flaky = flaky || run.status != first.status
+	}
+
+	if !flaky {
+		return nil, false, nil
+	}
+
+	tests := make([]string, 0, len(involved))
+	for test := range involved {
+		tests = append(tests, test)
+	}
+
+	sort.Strings(tests)
+
+	return tests, true, nil
+}
+
+// prepareRun applies the mutation to the workspace and returns its test targets
+// along with the function restoring the original source. A mutation woven into
+// a mutant schema is applied as the schema, with its mutant selected.
+func (ws *workspace) prepareRun(ctx context.Context, mutation m.Mutation) ([]testTarget, func(), error) {
+	if err := ws.ensurePrepared(ctx, mutation.Source.Origin.FullPath); err != nil {
+		return nil, nil, err
+	}
+
 	tmpSourcePath, err := ws.tmpPath(ctx, mutation.Source.Origin.FullPath)
 	if err != nil {
-		return m.Result{}, err
+		return nil, nil, err
 	}
 
-	tmpTestPath, err := ws.tmpPath(ctx, mutation.Source.Test.FullPath)
+	targets, err := ws.testTargets(ctx, mutation)
 	if err != nil {
-		return m.Result{}, err
+		return nil, nil, err
+	}
+
+	code := mutation.MutatedCode
+	if len(mutation.SchemaCode) > 0 {
+		code = mutation.SchemaCode
+	}
+
+	restore, err := ws.applyMutation(ctx, tmpSourcePath, code)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(mutation.SchemaCode) > 0 {
+		ws.env = []string{SchemaEnv + "=" + mutation.SchemaID}
+	}
+
+	return targets, func() {
+		ws.env = nil
+		restore()
+	}, nil
+}
+
+func (ws *workspace) Equivalent(ctx context.Context, mutation m.Mutation) (bool, error) {
+	if err := validateMutation(mutation); err != nil {
+		return false, err
+	}
+
+	if err := ws.ensurePrepared(ctx, mutation.Source.Origin.FullPath); err != nil {
+		return false, err
+	}
+
+	tmpSourcePath, err := ws.tmpPath(ctx, mutation.Source.Origin.FullPath)
+	if err != nil {
+		return false, err
+	}
+
+	pkgDir := filepath.Dir(string(tmpSourcePath))
+
+	original, err := ws.originalHash(ctx, pkgDir)
+	if err != nil {
+		return false, err
 	}
 
 	restore, err := ws.applyMutation(ctx, tmpSourcePath, mutation.MutatedCode)
 	if err != nil {
-		return m.Result{}, err
+		return false, err
 	}
 
 	defer restore()
 
-	status := ws.runTests(ctx, tmpTestPath)
+	mutated, err := ws.testAdapter.CompileHash(ctx, pkgDir, string(ws.overlay))
+	if err != nil {
+		return false, fmt.Errorf("compile mutated package: %w", err)
+	}
 
-	return resultForStatus(mutation, status), nil
+	return mutated == original, nil
+}
+
+func (ws *workspace) originalHash(ctx context.Context, pkgDir string) (string, error) {
+	if hash, ok := ws.originalHashes[pkgDir]; ok {
+		return hash, nil
+	}
+
+	hash, err := ws.testAdapter.CompileHash(ctx, pkgDir, "")
+	if err != nil {
+		return "", fmt.Errorf("compile original package: %w", err)
+	}
+
+	if ws.originalHashes == nil {
+		ws.originalHashes = map[string]string{}
+	}
+
+	ws.originalHashes[pkgDir] = hash
+
+	return hash, nil
 }
 
 // Close removes the workspace's temporary copy, if any.
@@ -123,22 +442,27 @@ func (ws *workspace) ensurePrepared(ctx context.Context, sourcePath m.Path) erro
 		return fmt.Errorf("failed to find project root: %w", err)
 	}
 
+	if ws.mode == WorkspaceOverlay {
+		return ws.prepareOverlay(ctx, root)
+	}
+
 	if ws.tmpDir != "" && ws.projectRoot == root {
 		return nil
 	}
 
 	ws.cleanup(ctx)
 
-	tmpDir, err := ws.fsAdapter.CreateTempDir(ctx, "gooze-mutation-*")
+	tmpDir, err := ws.createDir(ctx, root)
 	if err != nil {
 		slog.Error("Failed to create temp dir", "error", err)
 		return fmt.Errorf("failed to create temp dir: %w", err)
 	}
 
-	if err := ws.fsAdapter.CopyDir(ctx, root, tmpDir); err != nil {
+	moduleDir, err := ws.fsAdapter.CopyModule(ctx, root, tmpDir)
+	if err != nil {
 		slog.Error("Failed to copy project to temp dir", "projectRoot", root, "tmpDir", tmpDir, "error", err)
 
-		if removeErr := ws.fsAdapter.RemoveAll(context.WithoutCancel(ctx), tmpDir); removeErr != nil {
+		if removeErr := ws.removeDir(context.WithoutCancel(ctx), tmpDir); removeErr != nil {
 			slog.Error("Failed to clean up temp dir after copy failure", "tmpDir", tmpDir, "error", removeErr)
 		}
 
@@ -147,6 +471,11 @@ func (ws *workspace) ensurePrepared(ctx context.Context, sourcePath m.Path) erro
 
 	ws.projectRoot = root
 	ws.tmpDir = tmpDir
+	ws.moduleDir = moduleDir
+
+	if ws.warmUp {
+		ws.warmUpBuild(ctx)
+	}
 
 	return nil
 }
@@ -158,14 +487,19 @@ func (ws *workspace) tmpPath(ctx context.Context, fullPath m.Path) (m.Path, erro
 		return "", fmt.Errorf("failed to get relative path: %w", err)
 	}
 
-	return ws.fsAdapter.JoinPath(ctx, string(ws.tmpDir), string(relPath)), nil
+	return ws.fsAdapter.JoinPath(ctx, string(ws.moduleDir), string(relPath)), nil
 }
 
 // applyMutation writes the mutated source into the workspace and returns a
 // function that restores the original content, so the workspace can be reused
 // for the next mutation. Restoration uses a cancellation-free context so it runs
-// even if the mutation timed out.
+// even if the mutation timed out. In overlay mode the source is left as is and
+// overlaid instead.
 func (ws *workspace) applyMutation(ctx context.Context, path m.Path, mutatedCode []byte) (func(), error) {
+	if ws.mode == WorkspaceOverlay {
+		return ws.overlayMutation(ctx, path, mutatedCode)
+	}
+
 	original, err := ws.fsAdapter.ReadFile(ctx, path)
 	if err != nil {
 		slog.Error("Failed to read original file", "path", path, "error", err)
@@ -192,21 +526,139 @@ func (ws *workspace) writeFile(ctx context.Context, path m.Path, content []byte)
 	return nil
 }
 
-func (ws *workspace) runTests(ctx context.Context, testPath m.Path) m.TestStatus {
-	if err := ctx.Err(); err != nil {
-		return m.Timeout
+// testTarget is a test package to run against a mutation: its directory in the
+// workspace and the -run expression selecting its tests (empty for all).
+type testTarget struct {
+	dir string
+	run string
+}
+
+// testTargets returns the test packages to run for the mutation, in order of
+// first appearance. Go tests run per package, so every test file of a package
+// runs, whichever file covers the mutated code. Packages none of whose tests
+// reach the mutated line are left out.
+func (ws *workspace) testTargets(ctx context.Context, mutation m.Mutation) ([]testTarget, error) {
+	seen := make(map[m.Path]bool, len(mutation.Source.Tests))
+	targets := make([]testTarget, 0, len(mutation.Source.Tests))
+
+	for _, test := range mutation.Source.Tests {
+		dir := m.Path(filepath.Dir(string(test.FullPath)))
+		if seen[dir] {
+			continue
+		}
+
+		seen[dir] = true
+
+		run := ""
+
+		if mutation.CoveringTests != nil {
+			tests := mutation.CoveringTests[dir]
+			if len(tests) == 0 {
+				continue
+			}
+
+			run = runPattern(tests)
+		}
+
+		tmpTestPath, err := ws.tmpPath(ctx, test.FullPath)
+		if err != nil {
+			return nil, err
+		}
+
+		targets = append(targets, testTarget{dir: filepath.Dir(string(tmpTestPath)), run: run})
+	}
+
+	return targets, nil
+}
+
+// testRun is the outcome of running a mutation's tests once.
+type testRun struct {
+	status m.TestStatus
+	// tests are the tests that failed, or were still running when the run
+	// timed out.
+	tests []string
+}
+
+// runTests runs the test packages until one fails, which kills the mutant.
+// Runs stopped by their CPU time limit count as timeouts; runs out of memory or
+// file descriptors as errors.
+func (ws *workspace) runTests(ctx context.Context, targets []testTarget) testRun {
+	for _, target := range targets {
+		if err := ctx.Err(); err != nil {
+			return testRun{status: m.Timeout}
+		}
+
+		output, testErr := ws.testAdapter.RunGoTest(ctx, string(ws.moduleDir), target.dir, target.run, string(ws.overlay), ws.env...)
+		if testErr != nil {
+			switch {
+			case ctx.Err() != nil, errors.Is(testErr, adapter.ErrCPULimit):
+				return testRun{status: m.Timeout, tests: unfinishedTests(output)}
+			case errors.Is(testErr, adapter.ErrResourceLimit):
+				return testRun{status: m.Error, tests: unfinishedTests(output)}
+			}
+
+			return testRun{status: m.Killed, tests: failedTests(output)}
+		}
 	}
 
-	_, testErr := ws.testAdapter.RunGoTest(ctx, string(ws.tmpDir), string(testPath))
-	if testErr != nil {
-		if ctx.Err() != nil {
-			return m.Timeout
+	return testRun{status: m.Survived}
+}
+
+// failedTests returns the tests 'go test -v' output reports as failed.
+func failedTests(output string) []string {
+	var tests []string
+
+	for line := range strings.Lines(output) {
+		if name, ok := testResultName(line, "--- FAIL: "); ok {
+			tests = append(tests, name)
+		}
+	}
+
+	return tests
+}
+
+// unfinishedTests returns the tests 'go test -v' output shows as started but
+// never reported on, such as those running when the test binary was killed.
+func unfinishedTests(output string) []string {
+	var started []string
+
+	finished := map[string]bool{}
+
+	for line := range strings.Lines(output) {
+		trimmed := strings.TrimSpace(line)
+		if name, ok := strings.CutPrefix(trimmed, "=== RUN "); ok {
+			started = append(started, strings.TrimSpace(name))
+			continue
 		}
 
-		return m.Killed
+		for _, prefix := range []string{"--- PASS: ", "--- FAIL: ", "--- SKIP: "} {
+			if name, ok := testResultName(line, prefix); ok {
+				finished[name] = true
+			}
+		}
+	}
+
+	var tests []string
+
+	for _, name := range started {
+		if !finished[name] {
+			tests = append(tests, name)
+		}
 	}
 
-	return m.Survived
+	return tests
+}
+
+// testResultName extracts the test name from a '--- FAIL: TestX (0.00s)' line.
+func testResultName(line, prefix string) (string, bool) {
+	rest, ok := strings.CutPrefix(strings.TrimSpace(line), prefix)
+	if !ok {
+		return "", false
+	}
+
+	name, _, _ := strings.Cut(rest, " ")
+
+	return name, name != ""
 }
 
 func (ws *workspace) cleanup(ctx context.Context) {
@@ -214,12 +666,15 @@ func (ws *workspace) cleanup(ctx context.Context) {
 		return
 	}
 
-	if err := ws.fsAdapter.RemoveAll(ctx, ws.tmpDir); err != nil {
+	if err := ws.removeDir(ctx, ws.tmpDir); err != nil {
 		slog.Error("Failed to cleanup temp dir", "tmpDir", ws.tmpDir, "error", err)
 	}
 
 	ws.tmpDir = ""
+	ws.moduleDir = ""
+	ws.overlay = ""
 	ws.projectRoot = ""
+	ws.originalHashes = nil
 }
 
 func validateMutation(mutation m.Mutation) error {
@@ -3,11 +3,14 @@ package domain
 import (
 	"context"
 	"errors"
+	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"gooze.dev/pkg/gooze/internal/adapter"
 	adaptermocks "gooze.dev/pkg/gooze/internal/adapter/mocks"
 	m "gooze.dev/pkg/gooze/internal/model"
 )
@@ -19,7 +22,7 @@ func TestOrchestrator_TestMutation_NoOrigin(t *testing.T) {
 		Type: m.MutationArithmetic,
 		Source: m.Source{
 			Origin: nil,
-			Test:   &m.File{FullPath: m.Path("/project/main_test.go")},
+			Tests:  []*m.File{{FullPath: m.Path("/project/main_test.go")}},
 		},
 	}
 
@@ -35,7 +38,7 @@ func TestOrchestrator_TestMutation_NoTestFile(t *testing.T) {
 		Type: m.MutationBoolean,
 		Source: m.Source{
 			Origin: &m.File{FullPath: m.Path("/project/main.go")},
-			Test:   nil,
+			Tests:  nil,
 		},
 	}
 
@@ -75,16 +78,16 @@ func TestOrchestrator_TestMutation_TestFailureMarksKilled(t *testing.T) {
 
 	fsAdapter.EXPECT().FindProjectRoot(ctx, mutation.Source.Origin.FullPath).Return(projectRoot, nil)
 	fsAdapter.EXPECT().CreateTempDir(ctx, "gooze-mutation-*").Return(tmpDir, nil)
-	fsAdapter.EXPECT().CopyDir(ctx, projectRoot, tmpDir).Return(nil)
+	fsAdapter.EXPECT().CopyModule(ctx, projectRoot, tmpDir).Return(tmpDir, nil)
 	fsAdapter.EXPECT().RelPath(ctx, projectRoot, mutation.Source.Origin.FullPath).Return(m.Path("main.go"), nil)
 	fsAdapter.EXPECT().JoinPath(ctx, string(tmpDir), "main.go").Return(m.Path("/tmp/mut/main.go"))
-	fsAdapter.EXPECT().RelPath(ctx, projectRoot, mutation.Source.Test.FullPath).Return(m.Path("main_test.go"), nil)
+	fsAdapter.EXPECT().RelPath(ctx, projectRoot, mutation.Source.Tests[0].FullPath).Return(m.Path("main_test.go"), nil)
 	fsAdapter.EXPECT().JoinPath(ctx, string(tmpDir), "main_test.go").Return(m.Path("/tmp/mut/main_test.go"))
 	// Save the original, write the mutation, then restore the original so the
 	// workspace can be reused (restore runs under a cancellation-free context).
 	fsAdapter.EXPECT().ReadFile(ctx, m.Path("/tmp/mut/main.go")).Return(original, nil)
 	fsAdapter.EXPECT().WriteFile(ctx, m.Path("/tmp/mut/main.go"), mutation.MutatedCode, os.FileMode(0o600)).Return(nil)
-	trAdapter.EXPECT().RunGoTest(ctx, "/tmp/mut", "/tmp/mut/main_test.go").Return("boom", errors.New("failed"))
+	trAdapter.EXPECT().RunGoTest(ctx, "/tmp/mut", "/tmp/mut", "", "").Return("boom", errors.New("failed"))
 	fsAdapter.EXPECT().WriteFile(mock.Anything, m.Path("/tmp/mut/main.go"), original, os.FileMode(0o600)).Return(nil)
 	fsAdapter.EXPECT().RemoveAll(mock.Anything, tmpDir).Return(nil)
 
@@ -97,6 +100,390 @@ func TestOrchestrator_TestMutation_TestFailureMarksKilled(t *testing.T) {
 	require.Equal(t, m.Killed, entries[0].Status)
 }
 
+func TestOrchestrator_TestMutation_RunsFromModuleInWorkspaceCopy(t *testing.T) {
+	fsAdapter := adaptermocks.NewMockSourceFSAdapter(t)
+	trAdapter := adaptermocks.NewMockTestRunnerAdapter(t)
+	orch := NewOrchestrator(fsAdapter, trAdapter)
+	ctx := context.Background()
+	mutation := makeTestMutation()
+	projectRoot := m.Path("/project")
+	tmpDir := m.Path("/tmp/mut")
+	// The module sits next to the other modules of its go.work file.
+	moduleDir := m.Path("/tmp/mut/project")
+	original := []byte("package main\n")
+
+	fsAdapter.EXPECT().FindProjectRoot(ctx, mutation.Source.Origin.FullPath).Return(projectRoot, nil)
+	fsAdapter.EXPECT().CreateTempDir(ctx, "gooze-mutation-*").Return(tmpDir, nil)
+	fsAdapter.EXPECT().CopyModule(ctx, projectRoot, tmpDir).Return(moduleDir, nil)
+	fsAdapter.EXPECT().RelPath(ctx, projectRoot, mutation.Source.Origin.FullPath).Return(m.Path("main.go"), nil)
+	fsAdapter.EXPECT().JoinPath(ctx, string(moduleDir), "main.go").Return(m.Path("/tmp/mut/project/main.go"))
+	fsAdapter.EXPECT().RelPath(ctx, projectRoot, mutation.Source.Tests[0].FullPath).Return(m.Path("main_test.go"), nil)
+	fsAdapter.EXPECT().JoinPath(ctx, string(moduleDir), "main_test.go").Return(m.Path("/tmp/mut/project/main_test.go"))
+	fsAdapter.EXPECT().ReadFile(ctx, m.Path("/tmp/mut/project/main.go")).Return(original, nil)
+	fsAdapter.EXPECT().WriteFile(ctx, m.Path("/tmp/mut/project/main.go"), mutation.MutatedCode, os.FileMode(0o600)).Return(nil)
+	trAdapter.EXPECT().RunGoTest(ctx, "/tmp/mut/project", "/tmp/mut/project", "", "").Return("ok", nil)
+	fsAdapter.EXPECT().WriteFile(mock.Anything, m.Path("/tmp/mut/project/main.go"), original, os.FileMode(0o600)).Return(nil)
+	fsAdapter.EXPECT().RemoveAll(mock.Anything, tmpDir).Return(nil)
+
+	result, err := orch.TestMutation(ctx, mutation)
+	require.NoError(t, err)
+
+	entries := result[mutation.Type]
+	require.Len(t, entries, 1)
+	require.Equal(t, m.Survived, entries[0].Status)
+}
+
+func TestOrchestrator_TestMutation_ResourceLimits(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want m.TestStatus
+	}{
+		{name: "cpu limit", err: fmt.Errorf("%w: signal: killed", adapter.ErrCPULimit), want: m.Timeout},
+		{name: "resource limit", err: fmt.Errorf("%w: out of memory", adapter.ErrResourceLimit), want: m.Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fsAdapter := adaptermocks.NewMockSourceFSAdapter(t)
+			trAdapter := adaptermocks.NewMockTestRunnerAdapter(t)
+			orch := NewOrchestrator(fsAdapter, trAdapter)
+			ctx := context.Background()
+			mutation := makeTestMutation()
+			projectRoot := m.Path("/project")
+			tmpDir := m.Path("/tmp/mut")
+			original := []byte("package main\n")
+
+			fsAdapter.EXPECT().FindProjectRoot(ctx, mutation.Source.Origin.FullPath).Return(projectRoot, nil)
+			fsAdapter.EXPECT().CreateTempDir(ctx, "gooze-mutation-*").Return(tmpDir, nil)
+			fsAdapter.EXPECT().CopyModule(ctx, projectRoot, tmpDir).Return(tmpDir, nil)
+			fsAdapter.EXPECT().RelPath(ctx, projectRoot, mutation.Source.Origin.FullPath).Return(m.Path("main.go"), nil)
+			fsAdapter.EXPECT().JoinPath(ctx, string(tmpDir), "main.go").Return(m.Path("/tmp/mut/main.go"))
+			fsAdapter.EXPECT().RelPath(ctx, projectRoot, mutation.Source.Tests[0].FullPath).Return(m.Path("main_test.go"), nil)
+			fsAdapter.EXPECT().JoinPath(ctx, string(tmpDir), "main_test.go").Return(m.Path("/tmp/mut/main_test.go"))
+			fsAdapter.EXPECT().ReadFile(ctx, m.Path("/tmp/mut/main.go")).Return(original, nil)
+			fsAdapter.EXPECT().WriteFile(ctx, m.Path("/tmp/mut/main.go"), mutation.MutatedCode, os.FileMode(0o600)).Return(nil)
+			trAdapter.EXPECT().RunGoTest(ctx, "/tmp/mut", "/tmp/mut", "", "").Return("", tt.err)
+			fsAdapter.EXPECT().WriteFile(mock.Anything, m.Path("/tmp/mut/main.go"), original, os.FileMode(0o600)).Return(nil)
+			fsAdapter.EXPECT().RemoveAll(mock.Anything, tmpDir).Return(nil)
+
+			result, err := orch.TestMutation(ctx, mutation)
+			require.NoError(t, err)
+
+			entries := result[mutation.Type]
+			require.Len(t, entries, 1)
+			require.Equal(t, tt.want, entries[0].Status)
+		})
+	}
+}
+
+func TestOrchestrator_TestMutation_RunsEveryTestPackage(t *testing.T) {
+	fsAdapter := adaptermocks.NewMockSourceFSAdapter(t)
+	trAdapter := adaptermocks.NewMockTestRunnerAdapter(t)
+	orch := NewOrchestrator(fsAdapter, trAdapter)
+	ctx := context.Background()
+	projectRoot := m.Path("/project")
+	tmpDir := m.Path("/tmp/mut")
+
+	mutation := makeTestMutation()
+	mutation.Source.Tests = []*m.File{
+		{FullPath: m.Path("/project/main_test.go")},
+		{FullPath: m.Path("/project/api_test.go")},
+		{FullPath: m.Path("/project/test/e2e/e2e_test.go")},
+	}
+
+	original := []byte("package main\nfunc main() { _ = 1 + 2 }\n")
+
+	fsAdapter.EXPECT().FindProjectRoot(ctx, mutation.Source.Origin.FullPath).Return(projectRoot, nil)
+	fsAdapter.EXPECT().CreateTempDir(ctx, "gooze-mutation-*").Return(tmpDir, nil)
+	fsAdapter.EXPECT().CopyModule(ctx, projectRoot, tmpDir).Return(tmpDir, nil)
+	fsAdapter.EXPECT().RelPath(ctx, projectRoot, mutation.Source.Origin.FullPath).Return(m.Path("main.go"), nil)
+	fsAdapter.EXPECT().JoinPath(ctx, string(tmpDir), "main.go").Return(m.Path("/tmp/mut/main.go"))
+	fsAdapter.EXPECT().RelPath(ctx, projectRoot, m.Path("/project/main_test.go")).Return(m.Path("main_test.go"), nil)
+	fsAdapter.EXPECT().JoinPath(ctx, string(tmpDir), "main_test.go").Return(m.Path("/tmp/mut/main_test.go"))
+	fsAdapter.EXPECT().RelPath(ctx, projectRoot, m.Path("/project/test/e2e/e2e_test.go")).Return(m.Path("test/e2e/e2e_test.go"), nil)
+	fsAdapter.EXPECT().JoinPath(ctx, string(tmpDir), "test/e2e/e2e_test.go").Return(m.Path("/tmp/mut/test/e2e/e2e_test.go"))
+	fsAdapter.EXPECT().ReadFile(ctx, m.Path("/tmp/mut/main.go")).Return(original, nil)
+	fsAdapter.EXPECT().WriteFile(ctx, m.Path("/tmp/mut/main.go"), mutation.MutatedCode, os.FileMode(0o600)).Return(nil)
+	// Each package runs once, however many of its test files cover the source.
+	trAdapter.EXPECT().RunGoTest(ctx, "/tmp/mut", "/tmp/mut", "", "").Return("ok", nil).Once()
+	trAdapter.EXPECT().RunGoTest(ctx, "/tmp/mut", "/tmp/mut/test/e2e", "", "").Return("FAIL", errors.New("failed")).Once()
+	fsAdapter.EXPECT().WriteFile(mock.Anything, m.Path("/tmp/mut/main.go"), original, os.FileMode(0o600)).Return(nil)
+	fsAdapter.EXPECT().RemoveAll(mock.Anything, tmpDir).Return(nil)
+
+	result, err := orch.TestMutation(ctx, mutation)
+	require.NoError(t, err)
+	require.Equal(t, m.Killed, result[mutation.Type][0].Status)
+}
+
+func TestOrchestrator_TestMutation_RunsCoveringTests(t *testing.T) {
+	fsAdapter := adaptermocks.NewMockSourceFSAdapter(t)
+	trAdapter := adaptermocks.NewMockTestRunnerAdapter(t)
+	orch := NewOrchestrator(fsAdapter, trAdapter)
+	ctx := context.Background()
+	projectRoot := m.Path("/project")
+	tmpDir := m.Path("/tmp/mut")
+
+	mutation := makeTestMutation()
+	mutation.Source.Tests = []*m.File{
+		{FullPath: m.Path("/project/main_test.go")},
+		{FullPath: m.Path("/project/test/e2e/e2e_test.go")},
+	}
+	// No e2e test reaches the mutated line, so that package does not run.
+	mutation.CoveringTests = map[m.Path][]string{"/project": {"TestA", "TestB"}}
+
+	original := []byte("package main\nfunc main() { _ = 1 + 2 }\n")
+
+	fsAdapter.EXPECT().FindProjectRoot(ctx, mutation.Source.Origin.FullPath).Return(projectRoot, nil)
+	fsAdapter.EXPECT().CreateTempDir(ctx, "gooze-mutation-*").Return(tmpDir, nil)
+	fsAdapter.EXPECT().CopyModule(ctx, projectRoot, tmpDir).Return(tmpDir, nil)
+	fsAdapter.EXPECT().RelPath(ctx, projectRoot, mutation.Source.Origin.FullPath).Return(m.Path("main.go"), nil)
+	fsAdapter.EXPECT().JoinPath(ctx, string(tmpDir), "main.go").Return(m.Path("/tmp/mut/main.go"))
+	fsAdapter.EXPECT().RelPath(ctx, projectRoot, m.Path("/project/main_test.go")).Return(m.Path("main_test.go"), nil)
+	fsAdapter.EXPECT().JoinPath(ctx, string(tmpDir), "main_test.go").Return(m.Path("/tmp/mut/main_test.go"))
+	fsAdapter.EXPECT().ReadFile(ctx, m.Path("/tmp/mut/main.go")).Return(original, nil)
+	fsAdapter.EXPECT().WriteFile(ctx, m.Path("/tmp/mut/main.go"), mutation.MutatedCode, os.FileMode(0o600)).Return(nil)
+	trAdapter.EXPECT().RunGoTest(ctx, "/tmp/mut", "/tmp/mut", "^(TestA|TestB)$", "").Return("ok", nil).Once()
+	fsAdapter.EXPECT().WriteFile(mock.Anything, m.Path("/tmp/mut/main.go"), original, os.FileMode(0o600)).Return(nil)
+	fsAdapter.EXPECT().RemoveAll(mock.Anything, tmpDir).Return(nil)
+
+	result, err := orch.TestMutation(ctx, mutation)
+	require.NoError(t, err)
+	require.Equal(t, m.Survived, result[mutation.Type][0].Status)
+}
+
+func TestOrchestrator_MapTestCoverage(t *testing.T) {
+	fsAdapter := adaptermocks.NewMockSourceFSAdapter(t)
+	trAdapter := adaptermocks.NewMockTestRunnerAdapter(t)
+	orch := NewOrchestrator(fsAdapter, trAdapter)
+	ctx := context.Background()
+
+	sources := []m.Source{
+		{Origin: &m.File{FullPath: "/project/pkg/a.go"}, Tests: []*m.File{{FullPath: "/project/pkg/a_test.go"}}},
+		// Same package: mapped once.
+		{Origin: &m.File{FullPath: "/project/pkg/b.go"}, Tests: []*m.File{{FullPath: "/project/pkg/b_test.go"}}},
+		{Origin: &m.File{FullPath: "/project/untested/c.go"}},
+	}
+
+	fsAdapter.EXPECT().FindProjectRoot(ctx, m.Path("/project/pkg/a_test.go")).Return(m.Path("/project"), nil).Once()
+	trAdapter.EXPECT().ListTests(ctx, "/project", "/project/pkg").Return([]string{"TestA", "TestFlaky"}, nil).Once()
+	trAdapter.EXPECT().CoverTest(ctx, "/project", "/project/pkg", "TestA").
+		Return([]byte("mode: set\nmod/pkg/a.go:3.1,5.2 1 1\n"), nil).Once()
+	trAdapter.EXPECT().CoverTest(ctx, "/project", "/project/pkg", "TestFlaky").
+		Return(nil, errors.New("test failed")).Once()
+
+	coverage, err := orch.MapTestCoverage(ctx, sources)
+	require.NoError(t, err)
+
+	require.Contains(t, coverage, m.Path("/project/pkg"))
+	require.Len(t, coverage, 1)
+	require.True(t, coverage["/project/pkg"]["TestA"].Covers("pkg/a.go", 4))
+	require.False(t, coverage["/project/pkg"]["TestA"].Covers("pkg/a.go", 9))
+	require.Contains(t, coverage["/project/pkg"], "TestFlaky")
+	require.Nil(t, coverage["/project/pkg"]["TestFlaky"], "tests without a profile have unknown coverage")
+
+	t.Run("listing errors fail the mapping", func(t *testing.T) {
+		fsAdapter.EXPECT().FindProjectRoot(ctx, m.Path("/project/broken/x_test.go")).Return(m.Path("/project"), nil).Once()
+		trAdapter.EXPECT().ListTests(ctx, "/project", "/project/broken").Return(nil, errors.New("build failed")).Once()
+
+		_, err := orch.MapTestCoverage(ctx, []m.Source{{Origin: &m.File{FullPath: "/project/broken/x.go"}, Tests: []*m.File{{FullPath: "/project/broken/x_test.go"}}}})
+		require.Error(t, err)
+	})
+}
+
+func TestOrchestrator_Baseline(t *testing.T) {
+	fsAdapter := adaptermocks.NewMockSourceFSAdapter(t)
+	trAdapter := adaptermocks.NewMockTestRunnerAdapter(t)
+	orch := NewOrchestrator(fsAdapter, trAdapter)
+	ctx := context.Background()
+
+	sources := []m.Source{
+		{Origin: &m.File{FullPath: "/project/a/a.go"}, Tests: []*m.File{{FullPath: "/project/a/a_test.go"}}},
+		// Same package: run once.
+		{Origin: &m.File{FullPath: "/project/a/b.go"}, Tests: []*m.File{{FullPath: "/project/a/b_test.go"}}},
+		{Origin: &m.File{FullPath: "/project/c/c.go"}, Tests: []*m.File{{FullPath: "/project/c/c_test.go"}}},
+		{Origin: &m.File{FullPath: "/project/untested/d.go"}},
+	}
+
+	fsAdapter.EXPECT().FindProjectRoot(ctx, m.Path("/project/a/a_test.go")).Return(m.Path("/project"), nil).Once()
+	fsAdapter.EXPECT().FindProjectRoot(ctx, m.Path("/project/c/c_test.go")).Return(m.Path("/project"), nil).Once()
+	trAdapter.EXPECT().RunBaseline(ctx, "/project", "/project/a", true).
+		Return("ok", []byte("mode: set\nmod/a/a.go:1.1,2.2 1 1\n"), nil).Once()
+	trAdapter.EXPECT().RunBaseline(ctx, "/project", "/project/c", true).
+		Return("ok", []byte("mode: set\nmod/c/c.go:1.1,2.2 1 0\n"), nil).Once()
+
+	baseline, err := orch.Baseline(ctx, sources, true, 0)
+	require.NoError(t, err)
+
+	require.Len(t, baseline.Durations, 2)
+	require.Contains(t, baseline.Durations, m.Path("/project/a"))
+	require.Contains(t, baseline.Durations, m.Path("/project/c"))
+	require.Equal(t, "mode: set\nmod/a/a.go:1.1,2.2 1 1\nmod/c/c.go:1.1,2.2 1 0\n", string(baseline.Profile))
+}
+
+func TestOrchestrator_Baseline_FailingTests(t *testing.T) {
+	fsAdapter := adaptermocks.NewMockSourceFSAdapter(t)
+	trAdapter := adaptermocks.NewMockTestRunnerAdapter(t)
+	orch := NewOrchestrator(fsAdapter, trAdapter)
+	ctx := context.Background()
+
+	sources := []m.Source{{Origin: &m.File{FullPath: "/project/a/a.go"}, Tests: []*m.File{{FullPath: "/project/a/a_test.go"}}}}
+
+	fsAdapter.EXPECT().FindProjectRoot(ctx, m.Path("/project/a/a_test.go")).Return(m.Path("/project"), nil).Once()
+	trAdapter.EXPECT().RunBaseline(ctx, "/project", "/project/a", false).
+		Return("--- FAIL: TestA\nFAIL\n", nil, errors.New("exit status 1")).Once()
+
+	_, err := orch.Baseline(ctx, sources, false, 0)
+	require.ErrorContains(t, err, "tests fail without any mutation in /project/a: exit status 1")
+	require.ErrorContains(t, err, "--- FAIL: TestA")
+}
+
+func TestOrchestrator_Baseline_FlakyReruns(t *testing.T) {
+	fsAdapter := adaptermocks.NewMockSourceFSAdapter(t)
+	trAdapter := adaptermocks.NewMockTestRunnerAdapter(t)
+	orch := NewOrchestrator(fsAdapter, trAdapter)
+	ctx := context.Background()
+
+	sources := []m.Source{{Origin: &m.File{FullPath: "/project/a/a.go"}, Tests: []*m.File{{FullPath: "/project/a/a_test.go"}}}}
+
+	fsAdapter.EXPECT().FindProjectRoot(ctx, m.Path("/project/a/a_test.go")).Return(m.Path("/project"), nil).Once()
+	trAdapter.EXPECT().RunBaseline(ctx, "/project", "/project/a", true).Return("ok", []byte("mode: set\n"), nil).Once()
+	trAdapter.EXPECT().RunBaseline(ctx, "/project", "/project/a", false).Return("ok", nil, nil).Once()
+	trAdapter.EXPECT().RunBaseline(ctx, "/project", "/project/a", false).
+		Return("=== RUN   TestRace\n--- FAIL: TestRace (0.01s)\nFAIL\n", nil, errors.New("exit status 1")).Once()
+
+	_, err := orch.Baseline(ctx, sources, true, 2)
+	require.ErrorContains(t, err, "tests are flaky without any mutation in /project/a, failing on run 3 of 3: TestRace")
+}
+
+func TestWorkspace_Recheck(t *testing.T) {
+	ctx := context.Background()
+	mutation := makeTestMutation()
+	original := []byte("package main\nfunc main() { _ = 1 + 2 }\n")
+
+	newWorkspace := func(t *testing.T) (Workspace, *adaptermocks.MockTestRunnerAdapter) {
+		fsAdapter := adaptermocks.NewMockSourceFSAdapter(t)
+		trAdapter := adaptermocks.NewMockTestRunnerAdapter(t)
+
+		fsAdapter.EXPECT().FindProjectRoot(ctx, mutation.Source.Origin.FullPath).Return(m.Path("/project"), nil)
+		fsAdapter.EXPECT().CreateTempDir(ctx, "gooze-mutation-*").Return(m.Path("/tmp/mut"), nil).Once()
+		fsAdapter.EXPECT().CopyModule(ctx, m.Path("/project"), m.Path("/tmp/mut")).Return(m.Path("/tmp/mut"), nil).Once()
+		fsAdapter.EXPECT().RelPath(ctx, m.Path("/project"), mock.Anything).Return(m.Path("main.go"), nil)
+		fsAdapter.EXPECT().JoinPath(ctx, "/tmp/mut", "main.go").Return(m.Path("/tmp/mut/main.go"))
+		fsAdapter.EXPECT().ReadFile(ctx, m.Path("/tmp/mut/main.go")).Return(original, nil)
+		fsAdapter.EXPECT().WriteFile(mock.Anything, m.Path("/tmp/mut/main.go"), mock.Anything, os.FileMode(0o600)).Return(nil)
+		fsAdapter.EXPECT().RemoveAll(mock.Anything, m.Path("/tmp/mut")).Return(nil).Maybe()
+
+		return NewOrchestrator(fsAdapter, trAdapter).NewWorkspace(), trAdapter
+	}
+
+	t.Run("consistent survivor", func(t *testing.T) {
+		ws, trAdapter := newWorkspace(t)
+		defer ws.Close(ctx)
+
+		trAdapter.EXPECT().RunGoTest(mock.Anything, "/tmp/mut", "/tmp/mut", "", "").Return("ok", nil).Times(3)
+
+		result, err := ws.Run(ctx, mutation)
+		require.NoError(t, err)
+		require.Equal(t, m.Survived, result[mutation.Type][0].Status)
+
+		tests, flaky, err := ws.Recheck(ctx, mutation, 2, time.Minute)
+		require.NoError(t, err)
+		require.False(t, flaky)
+		require.Nil(t, tests)
+	})
+
+	t.Run("survivor killed on a rerun", func(t *testing.T) {
+		ws, trAdapter := newWorkspace(t)
+		defer ws.Close(ctx)
+
+		trAdapter.EXPECT().RunGoTest(mock.Anything, "/tmp/mut", "/tmp/mut", "", "").Return("ok", nil).Once()
+		trAdapter.EXPECT().RunGoTest(mock.Anything, "/tmp/mut", "/tmp/mut", "", "").
+			Return("=== RUN   TestRace\n--- FAIL: TestRace (0.01s)\n=== RUN   TestOK\n--- PASS: TestOK (0.00s)\nFAIL\n", errors.New("exit status 1")).Once()
+		trAdapter.EXPECT().RunGoTest(mock.Anything, "/tmp/mut", "/tmp/mut", "", "").Return("ok", nil).Once()
+
+		_, err := ws.Run(ctx, mutation)
+		require.NoError(t, err)
+
+		tests, flaky, err := ws.Recheck(ctx, mutation, 2, time.Minute)
+		require.NoError(t, err)
+		require.True(t, flaky)
+		require.Equal(t, []string{"TestRace"}, tests)
+	})
+}
+
+func TestUnfinishedTests(t *testing.T) {
+	output := "=== RUN   TestA\n--- PASS: TestA (0.00s)\n=== RUN   TestB\n=== RUN   TestB/sub\n    --- SKIP: TestB/sub (0.00s)\n=== RUN   TestC\n"
+
+	require.Equal(t, []string{"TestB", "TestC"}, unfinishedTests(output))
+	require.Equal(t, []string{"TestB/sub"}, failedTests("    --- FAIL: TestB/sub (0.00s)\n--- PASS: TestA (0.00s)\n"))
+}
+
+func TestWorkspace_Equivalent_ComparesCompileHashes(t *testing.T) {
+	fsAdapter := adaptermocks.NewMockSourceFSAdapter(t)
+	trAdapter := adaptermocks.NewMockTestRunnerAdapter(t)
+	orch := NewOrchestrator(fsAdapter, trAdapter)
+	ctx := context.Background()
+	projectRoot := m.Path("/project")
+	tmpDir := m.Path("/tmp/mut")
+
+	equivalent := makeTestMutation()
+	different := makeTestMutation()
+	different.ID = "other"
+	different.MutatedCode = []byte("package main\nfunc main() { _ = 1 * 2 }\n")
+
+	original := []byte("package main\nfunc main() { _ = 1 + 2 }\n")
+
+	fsAdapter.EXPECT().FindProjectRoot(ctx, equivalent.Source.Origin.FullPath).Return(projectRoot, nil)
+	fsAdapter.EXPECT().CreateTempDir(ctx, "gooze-mutation-*").Return(tmpDir, nil).Once()
+	fsAdapter.EXPECT().CopyModule(ctx, projectRoot, tmpDir).Return(tmpDir, nil).Once()
+	fsAdapter.EXPECT().RelPath(ctx, projectRoot, equivalent.Source.Origin.FullPath).Return(m.Path("main.go"), nil)
+	fsAdapter.EXPECT().JoinPath(ctx, string(tmpDir), "main.go").Return(m.Path("/tmp/mut/main.go"))
+	fsAdapter.EXPECT().ReadFile(ctx, m.Path("/tmp/mut/main.go")).Return(original, nil)
+	fsAdapter.EXPECT().WriteFile(mock.Anything, m.Path("/tmp/mut/main.go"), mock.Anything, os.FileMode(0o600)).Return(nil)
+	fsAdapter.EXPECT().RemoveAll(mock.Anything, tmpDir).Return(nil)
+
+	// The original package is compiled once and its hash reused.
+	trAdapter.EXPECT().CompileHash(ctx, "/tmp/mut", "").Return("original", nil).Once()
+	trAdapter.EXPECT().CompileHash(ctx, "/tmp/mut", "").Return("original", nil).Once()
+	trAdapter.EXPECT().CompileHash(ctx, "/tmp/mut", "").Return("changed", nil).Once()
+
+	ws := orch.NewWorkspace()
+	defer ws.Close(ctx)
+
+	same, err := ws.Equivalent(ctx, equivalent)
+	require.NoError(t, err)
+	require.True(t, same)
+
+	same, err = ws.Equivalent(ctx, different)
+	require.NoError(t, err)
+	require.False(t, same)
+}
+
+func TestWorkspace_Equivalent_CompileError(t *testing.T) {
+	fsAdapter := adaptermocks.NewMockSourceFSAdapter(t)
+	trAdapter := adaptermocks.NewMockTestRunnerAdapter(t)
+	orch := NewOrchestrator(fsAdapter, trAdapter)
+	ctx := context.Background()
+	mutation := makeTestMutation()
+	projectRoot := m.Path("/project")
+	tmpDir := m.Path("/tmp/mut")
+
+	fsAdapter.EXPECT().FindProjectRoot(ctx, mutation.Source.Origin.FullPath).Return(projectRoot, nil)
+	fsAdapter.EXPECT().CreateTempDir(ctx, "gooze-mutation-*").Return(tmpDir, nil)
+	fsAdapter.EXPECT().CopyModule(ctx, projectRoot, tmpDir).Return(tmpDir, nil)
+	fsAdapter.EXPECT().RelPath(ctx, projectRoot, mutation.Source.Origin.FullPath).Return(m.Path("main.go"), nil)
+	fsAdapter.EXPECT().JoinPath(ctx, string(tmpDir), "main.go").Return(m.Path("/tmp/mut/main.go"))
+	fsAdapter.EXPECT().RemoveAll(mock.Anything, tmpDir).Return(nil)
+	trAdapter.EXPECT().CompileHash(ctx, "/tmp/mut", "").Return("", errors.New("build failed"))
+
+	ws := orch.NewWorkspace()
+	defer ws.Close(ctx)
+
+	_, err := ws.Equivalent(ctx, mutation)
+	require.Error(t, err)
+}
+
 func TestOrchestrator_TestMutation_ContextCancelledReturnsTimeout(t *testing.T) {
 	orch := NewOrchestrator(nil, nil)
 	mutation := makeTestMutation()
@@ -149,7 +536,7 @@ func makeTestMutation() m.Mutation {
 		MutatedCode: []byte("package main\nfunc main() { _ = 1 + 1 }\n"),
 		Source: m.Source{
 			Origin: &m.File{FullPath: m.Path("/project/main.go")},
-			Test:   &m.File{FullPath: m.Path("/project/main_test.go")},
+			Tests:  []*m.File{{FullPath: m.Path("/project/main_test.go")}},
 		},
 	}
 }
@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+
+	m "gooze.dev/pkg/gooze/internal/model"
+)
+
+// WorkspaceMode selects how a workspace presents mutated sources to the go
+// command.
+type WorkspaceMode string
+
+const (
+	// WorkspaceCopy tests mutants in a per-worker copy of the project, writing
+	// each mutated file into the copy and restoring it after the run.
+	WorkspaceCopy WorkspaceMode = "copy"
+	// WorkspaceOverlay leaves the project untouched: the mutated file is
+	// written to a temporary directory and the go command reads it in place of
+	// the original through `-overlay`.
+	WorkspaceOverlay WorkspaceMode = "overlay"
+)
+
+// WithWorkspaceMode sets how the orchestrator's workspaces apply mutations.
+// The default is WorkspaceCopy.
+func WithWorkspaceMode(mode WorkspaceMode) OrchestratorOption {
+	return func(to *orchestrator) {
+		to.mode = mode
+	}
+}
+
+// overlayFile is the JSON document `go build -overlay` reads.
+type overlayFile struct {
+	Replace map[string]string
+}
+
+// prepareOverlay makes sure the workspace has a temporary directory for its
+// overlay files and tests the project that owns root in place.
+func (ws *workspace) prepareOverlay(ctx context.Context, root m.Path) error {
+	if ws.tmpDir == "" {
+		tmpDir, err := ws.fsAdapter.CreateTempDir(ctx, "gooze-overlay-*")
+		if err != nil {
+			slog.Error("Failed to create temp dir", "error", err)
+			return fmt.Errorf("failed to create temp dir: %w", err)
+		}
+
+		ws.tmpDir = tmpDir
+	}
+
+	ws.projectRoot = root
+	ws.moduleDir = root
+
+	return nil
+}
+
+// overlayMutation writes the mutated source into the workspace's temporary
+// directory along with an overlay file replacing path with it, which the next
+// test runs and builds use. The returned function drops the overlay.
+func (ws *workspace) overlayMutation(ctx context.Context, path m.Path, mutatedCode []byte) (func(), error) {
+	mutatedPath := m.Path(filepath.Join(string(ws.tmpDir), filepath.Base(string(path))))
+	if err := ws.writeFile(ctx, mutatedPath, mutatedCode); err != nil {
+		return nil, err
+	}
+
+	content, err := json.Marshal(overlayFile{Replace: map[string]string{string(path): string(mutatedPath)}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode overlay: %w", err)
+	}
+
+	overlayPath := m.Path(filepath.Join(string(ws.tmpDir), "overlay.json"))
+	if err := ws.writeFile(ctx, overlayPath, content); err != nil {
+		return nil, err
+	}
+
+	ws.overlay = overlayPath
+
+	return func() {
+		ws.overlay = ""
+	}, nil
+}
@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	adaptermocks "gooze.dev/pkg/gooze/internal/adapter/mocks"
+	m "gooze.dev/pkg/gooze/internal/model"
+)
+
+// expectOverlayPaths sets up the path lookups of the test mutation, which in
+// overlay mode resolve within the project itself.
+func expectOverlayPaths(fsAdapter *adaptermocks.MockSourceFSAdapter, mutation m.Mutation) {
+	projectRoot := m.Path("/project")
+
+	fsAdapter.EXPECT().FindProjectRoot(mock.Anything, mutation.Source.Origin.FullPath).Return(projectRoot, nil)
+	fsAdapter.EXPECT().RelPath(mock.Anything, projectRoot, mutation.Source.Origin.FullPath).Return(m.Path("main.go"), nil)
+	fsAdapter.EXPECT().JoinPath(mock.Anything, string(projectRoot), "main.go").Return(m.Path("/project/main.go"))
+	fsAdapter.EXPECT().RelPath(mock.Anything, projectRoot, mutation.Source.Tests[0].FullPath).Return(m.Path("main_test.go"), nil).Maybe()
+	fsAdapter.EXPECT().JoinPath(mock.Anything, string(projectRoot), "main_test.go").Return(m.Path("/project/main_test.go")).Maybe()
+}
+
+func TestOrchestrator_TestMutation_OverlayWorkspace(t *testing.T) {
+	fsAdapter := adaptermocks.NewMockSourceFSAdapter(t)
+	trAdapter := adaptermocks.NewMockTestRunnerAdapter(t)
+	orch := NewOrchestrator(fsAdapter, trAdapter, WithWorkspaceMode(WorkspaceOverlay))
+	ctx := context.Background()
+	mutation := makeTestMutation()
+	tmpDir := m.Path("/tmp/overlay")
+
+	expectOverlayPaths(fsAdapter, mutation)
+	// Nothing is copied, read or restored: the mutated source and the overlay
+	// mapping it over the original go to the temp dir.
+	fsAdapter.EXPECT().CreateTempDir(ctx, "gooze-overlay-*").Return(tmpDir, nil)
+	fsAdapter.EXPECT().WriteFile(ctx, m.Path("/tmp/overlay/main.go"), mutation.MutatedCode, os.FileMode(0o600)).Return(nil)
+	fsAdapter.EXPECT().WriteFile(ctx, m.Path("/tmp/overlay/overlay.json"),
+		[]byte(`{"Replace":{"/project/main.go":"/tmp/overlay/main.go"}}`), os.FileMode(0o600)).Return(nil)
+	trAdapter.EXPECT().RunGoTest(ctx, "/project", "/project", "", "/tmp/overlay/overlay.json").Return("FAIL", errors.New("failed"))
+	fsAdapter.EXPECT().RemoveAll(mock.Anything, tmpDir).Return(nil)
+
+	result, err := orch.TestMutation(ctx, mutation)
+	require.NoError(t, err)
+
+	entries := result[mutation.Type]
+	require.Len(t, entries, 1)
+	require.Equal(t, m.Killed, entries[0].Status)
+}
+
+func TestWorkspace_OverlayReusedAcrossMutations(t *testing.T) {
+	fsAdapter := adaptermocks.NewMockSourceFSAdapter(t)
+	trAdapter := adaptermocks.NewMockTestRunnerAdapter(t)
+	orch := NewOrchestrator(fsAdapter, trAdapter, WithWorkspaceMode(WorkspaceOverlay))
+	ctx := context.Background()
+	mutation := makeTestMutation()
+	tmpDir := m.Path("/tmp/overlay")
+
+	expectOverlayPaths(fsAdapter, mutation)
+	fsAdapter.EXPECT().CreateTempDir(ctx, "gooze-overlay-*").Return(tmpDir, nil).Once()
+	fsAdapter.EXPECT().WriteFile(ctx, mock.Anything, mock.Anything, os.FileMode(0o600)).Return(nil)
+	trAdapter.EXPECT().RunGoTest(ctx, "/project", "/project", "", "/tmp/overlay/overlay.json").Return("ok", nil).Once()
+	// The original package builds without the overlay, the mutant with it.
+	trAdapter.EXPECT().CompileHash(ctx, "/project", "").Return("original", nil).Once()
+	trAdapter.EXPECT().CompileHash(ctx, "/project", "/tmp/overlay/overlay.json").Return("original", nil).Once()
+	fsAdapter.EXPECT().RemoveAll(mock.Anything, tmpDir).Return(nil).Once()
+
+	ws := orch.NewWorkspace()
+	defer ws.Close(ctx)
+
+	result, err := ws.Run(ctx, mutation)
+	require.NoError(t, err)
+	require.Equal(t, m.Survived, result[mutation.Type][0].Status)
+
+	same, err := ws.Equivalent(ctx, mutation)
+	require.NoError(t, err)
+	require.True(t, same)
+}
@@ -19,6 +19,8 @@ type FileEstimate struct {
 type Estimation struct {
 	Total int
 	Files []FileEstimate
+	// Skipped counts, per skip rule, the files source discovery left out.
+	Skipped map[string]int
 }
 
 // Reporter is the presentation port the workflow depends on. It is defined in
@@ -35,4 +37,5 @@ type Reporter interface {
 	DisplayStartingTestInfo(ctx context.Context, mutation m.Mutation, threadID int)
 	DisplayCompletedTestInfo(ctx context.Context, mutation m.Mutation, result m.Result)
 	DisplayMutationScore(ctx context.Context, score float64)
+	DisplayScoreInterval(ctx context.Context, low, high float64)
 }
@@ -0,0 +1,140 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	m "gooze.dev/pkg/gooze/internal/model"
+)
+
+// resultCache holds the stored results of a previous run for the sources being
+// re-tested, keyed by mutation ID.
+type resultCache map[string]cachedResult
+
+type cachedResult struct {
+	mutationType m.MutationType
+	scope        string
+	testHash     string
+	result       m.Result
+}
+
+// reusableStatuses are the statuses a cached result may be reused with. Errors
+// and timeouts are retried; not-covered depends on the coverage profile.
+var reusableStatuses = map[m.TestStatus]bool{
+	m.Killed:     true,
+	m.Survived:   true,
+	m.Equivalent: true,
+}
+
+// lookup returns the cached result of mutation when it was produced by the same
+// mutagen version, in a declaration with the same source, against the same tests.
+func (c resultCache) lookup(mutation m.Mutation) (m.Result, bool) {
+	cached, ok := c[mutation.ID]
+	if !ok || mutation.Scope == "" {
+		return nil, false
+	}
+
+	if cached.mutationType != mutation.Type || cached.scope != mutation.Scope || cached.testHash != mutation.Source.TestHash() {
+		return nil, false
+	}
+
+	return cached.result, true
+}
+
+// staleReports are the stored reports a run replaces: those of the sources it
+// re-tests, except the reports of mutants outside the line selection, which
+// are put back since those mutants are not tested again.
+type staleReports struct {
+	dir        m.Path
+	sources    []m.Source
+	unselected []m.Report
+}
+
+// loadResultCache collects the stored results of the given (changed) sources so
+// mutants in unchanged declarations can reuse them. The stored reports of those
+// sources are only replaced by replaceStaleReports, once the run's reports are
+// ready, so a run that fails or is canceled keeps the previous results. Under a
+// line selection or a function filter, the reports of mutants outside it are
+// kept.
+func (w *workflow) loadResultCache(ctx context.Context, args EstimateArgs, sources []m.Source) (resultCache, staleReports, error) {
+	if !args.UseCache || args.Reports == "" || len(sources) == 0 {
+		return nil, staleReports{}, nil
+	}
+
+	funcs, err := args.Funcs.compile()
+	if err != nil {
+		return nil, staleReports{}, err
+	}
+
+	reports, err := w.loadReportsIfExists(ctx, args.Reports)
+	if err != nil {
+		return nil, staleReports{}, fmt.Errorf("load cached reports: %w", err)
+	}
+
+	changed := w.buildSourcePathMap(sources)
+	cache := resultCache{}
+	stale := staleReports{dir: args.Reports}
+
+	if len(reports) > 0 {
+		stale.sources = sources
+	}
+
+	for _, report := range reports {
+		if report.Source.Origin == nil {
+			continue
+		}
+
+		if _, ok := changed[string(report.Source.Origin.FullPath)]; !ok {
+			continue
+		}
+
+		if (args.Lines.Enabled() && report.Line > 0 && !args.Lines.covers(report)) || !funcs.covers(report) {
+			stale.unselected = append(stale.unselected, report)
+			continue
+		}
+
+		if report.Scope == "" {
+			continue
+		}
+
+		for mutationType, entries := range report.Result {
+			for _, entry := range entries {
+				if !reusableStatuses[entry.Status] {
+					continue
+				}
+
+				cache[entry.MutationID] = cachedResult{
+					mutationType: mutationType,
+					scope:        report.Scope,
+					testHash:     report.Source.TestHash(),
+					result:       m.Result{mutationType: {entry}},
+				}
+			}
+		}
+	}
+
+	slog.Debug("Loaded cached mutation results", "count", len(cache))
+
+	return cache, stale, nil
+}
+
+// replaceStaleReports removes the stale stored reports, then puts back those of
+// the mutants outside the line selection or the function filter.
+func (w *workflow) replaceStaleReports(ctx context.Context, stale staleReports) error {
+	if len(stale.sources) == 0 {
+		return nil
+	}
+
+	if err := w.reports.CleanReports(ctx, stale.dir, stale.sources); err != nil {
+		return fmt.Errorf("clean reports: %w", err)
+	}
+
+	if len(stale.unselected) > 0 {
+		if err := w.reports.SaveReports(ctx, stale.dir, stale.unselected); err != nil {
+			return fmt.Errorf("keep unselected reports: %w", err)
+		}
+	}
+
+	return nil
+}
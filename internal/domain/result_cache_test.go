@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"testing"
+
+	m "gooze.dev/pkg/gooze/internal/model"
+)
+
+func TestResultCache_Lookup(t *testing.T) {
+	source := m.Source{Origin: &m.File{FullPath: "/home/dev/p.go"}, Tests: []*m.File{{FullPath: "/home/dev/p_test.go", ShortPath: "p_test.go", Hash: "th"}}}
+	moved := m.Source{Origin: &m.File{FullPath: "/ci/p.go"}, Tests: []*m.File{{FullPath: "/ci/p_test.go", ShortPath: "p_test.go", Hash: "th"}}}
+	result := m.Result{m.MutationArithmetic: {{MutationID: "id", Status: m.Killed}}}
+
+	cache := resultCache{
+		"id": {mutationType: m.MutationArithmetic, scope: "s", testHash: source.TestHash(), result: result},
+	}
+
+	tests := []struct {
+		name     string
+		mutation m.Mutation
+		want     bool
+	}{
+		{"hit", m.Mutation{ID: "id", Type: m.MutationArithmetic, Scope: "s", Source: source}, true},
+		{"checkout moved", m.Mutation{ID: "id", Type: m.MutationArithmetic, Scope: "s", Source: moved}, true},
+		{"unknown id", m.Mutation{ID: "other", Type: m.MutationArithmetic, Scope: "s", Source: source}, false},
+		{"scope changed", m.Mutation{ID: "id", Type: m.MutationArithmetic, Scope: "t", Source: source}, false},
+		{"no scope", m.Mutation{ID: "id", Type: m.MutationArithmetic, Source: source}, false},
+		{"mutagen version changed", m.Mutation{ID: "id", Type: m.MutationType{Name: "arithmetic", Version: 2}, Scope: "s", Source: source}, false},
+		{"tests changed", m.Mutation{ID: "id", Type: m.MutationArithmetic, Scope: "s", Source: m.Source{Origin: source.Origin, Tests: []*m.File{{Hash: "new"}}}}, false},
+		{"test settings changed", m.Mutation{ID: "id", Type: m.MutationArithmetic, Scope: "s", Source: m.Source{Origin: source.Origin, Tests: source.Tests, TestConfig: "race"}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, ok := cache.lookup(tt.mutation); ok != tt.want {
+				t.Fatalf("lookup() = %v, want %v", ok, tt.want)
+			}
+		})
+	}
+
+	if _, ok := resultCache(nil).lookup(m.Mutation{ID: "id", Scope: "s"}); ok {
+		t.Fatalf("expected a nil cache to miss")
+	}
+}
@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"hash/fnv"
+	"math"
+	"math/rand/v2"
+
+	m "gooze.dev/pkg/gooze/internal/model"
+)
+
+// Sampling selects a reproducible subset of each file's mutants to test. The
+// rest are reported as skipped.
+type Sampling struct {
+	// Rate is the fraction (0, 1] of mutants to test; 0 tests all of them.
+	Rate float64
+	// MaxPerFile caps the mutants tested per file; 0 means no cap.
+	MaxPerFile int
+	// Seed makes the selection reproducible.
+	Seed int64
+}
+
+// Enabled reports whether the sampling selects a subset at all.
+func (s Sampling) Enabled() bool {
+	return (s.Rate > 0 && s.Rate < 1) || s.MaxPerFile > 0
+}
+
+// sample returns the IDs of the mutants of one file to test. Selection is
+// stratified by mutation type: every type contributes its share of the rate,
+// and a per-file cap is filled round-robin across types. The draw depends only
+// on the seed and the file, so it does not change with other files or shards.
+func (s Sampling) sample(file string, mutations []m.Mutation) map[string]bool {
+	selected := make(map[string]bool, len(mutations))
+	if !s.Enabled() {
+		for _, mutation := range mutations {
+			selected[mutation.ID] = true
+		}
+
+		return selected
+	}
+
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(file))
+	rng := rand.New(rand.NewPCG(uint64(s.Seed), h.Sum64())) //nolint:gosec // sampling, not security.
+
+	strata := stratify(mutations)
+
+	quotas := make([]int, len(strata))
+	for i, stratum := range strata {
+		rng.Shuffle(len(stratum), func(a, b int) { stratum[a], stratum[b] = stratum[b], stratum[a] })
+		quotas[i] = s.quota(len(stratum), rng)
+	}
+
+	budget := math.MaxInt
+	if s.MaxPerFile > 0 {
+		budget = s.MaxPerFile
+	}
+
+	for taken := 0; budget > 0; taken++ {
+		progressed := false
+
+		for i, stratum := range strata {
+			if taken >= quotas[i] || budget == 0 {
+				continue
+			}
+
+			selected[stratum[taken].ID] = true
+			budget--
+			progressed = true
+		}
+
+		if !progressed {
+			break
+		}
+	}
+
+	return selected
+}
+
+// quota is how many of n mutants the rate selects. The fractional part is
+// rounded at random, so small strata are sampled without bias.
+func (s Sampling) quota(n int, rng *rand.Rand) int {
+	if s.Rate <= 0 || s.Rate >= 1 {
+		return n
+	}
+
+	exact := s.Rate * float64(n)
+	quota := int(exact)
+
+	if rng.Float64() < exact-float64(quota) {
+		quota++
+	}
+
+	return quota
+}
+
+// stratify groups mutations by type, in order of first appearance.
+func stratify(mutations []m.Mutation) [][]m.Mutation {
+	index := map[m.MutationType]int{}
+
+	var strata [][]m.Mutation
+
+	for _, mutation := range mutations {
+		i, ok := index[mutation.Type]
+		if !ok {
+			i = len(strata)
+			index[mutation.Type] = i
+			strata = append(strata, nil)
+		}
+
+		strata[i] = append(strata[i], mutation)
+	}
+
+	return strata
+}
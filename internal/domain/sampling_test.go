@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	m "gooze.dev/pkg/gooze/internal/model"
+)
+
+func sampleMutations(perType int, types ...m.MutationType) []m.Mutation {
+	var mutations []m.Mutation
+
+	for _, mutationType := range types {
+		for i := range perType {
+			mutations = append(mutations, m.Mutation{ID: fmt.Sprintf("%s-%d", mutationType.Name, i), Type: mutationType})
+		}
+	}
+
+	return mutations
+}
+
+func countSelected(selected map[string]bool) int {
+	count := 0
+
+	for _, ok := range selected {
+		if ok {
+			count++
+		}
+	}
+
+	return count
+}
+
+func TestSampling_DisabledSelectsAll(t *testing.T) {
+	mutations := sampleMutations(5, m.MutationArithmetic)
+
+	for _, sampling := range []Sampling{{}, {Rate: 1}} {
+		require.False(t, sampling.Enabled())
+		require.Equal(t, 5, countSelected(sampling.sample("a.go", mutations)))
+	}
+}
+
+func TestSampling_IsReproducible(t *testing.T) {
+	mutations := sampleMutations(50, m.MutationArithmetic, m.MutationBoolean)
+	sampling := Sampling{Rate: 0.2, Seed: 7}
+
+	first := sampling.sample("a.go", mutations)
+	require.Equal(t, first, sampling.sample("a.go", mutations))
+	require.NotEqual(t, first, Sampling{Rate: 0.2, Seed: 8}.sample("a.go", mutations))
+	require.NotEqual(t, first, sampling.sample("b.go", mutations))
+}
+
+func TestSampling_StratifiesByType(t *testing.T) {
+	mutations := sampleMutations(50, m.MutationArithmetic, m.MutationBoolean)
+
+	selected := Sampling{Rate: 0.2, Seed: 1}.sample("a.go", mutations)
+
+	perType := map[m.MutationType]int{}
+	for _, mutation := range mutations {
+		if selected[mutation.ID] {
+			perType[mutation.Type]++
+		}
+	}
+
+	require.Equal(t, 10, perType[m.MutationArithmetic])
+	require.Equal(t, 10, perType[m.MutationBoolean])
+}
+
+func TestSampling_MaxPerFileSpreadsAcrossTypes(t *testing.T) {
+	mutations := sampleMutations(10, m.MutationArithmetic, m.MutationBoolean, m.MutationComparison)
+
+	selected := Sampling{MaxPerFile: 4, Seed: 3}.sample("a.go", mutations)
+	require.Equal(t, 4, countSelected(selected))
+
+	types := map[m.MutationType]bool{}
+	for _, mutation := range mutations {
+		if selected[mutation.ID] {
+			types[mutation.Type] = true
+		}
+	}
+
+	require.Len(t, types, 3)
+}
@@ -0,0 +1,233 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"gooze.dev/pkg/gooze/internal/adapter"
+	"gooze.dev/pkg/gooze/internal/domain/mutagens"
+	m "gooze.dev/pkg/gooze/internal/model"
+)
+
+// SchemaEnv is the environment variable selecting the mutant of a mutant
+// schema that runs; every switch is off when it is unset.
+const SchemaEnv = "GOOZE_MUTANT"
+
+// schemaSwitch is the package-level variable a schema's switches compare with
+// the SchemaID of their mutant, and schemaOS the name the schema imports os as
+// to set it.
+const (
+	schemaSwitch = "goozeSchemaMutant"
+	schemaOS     = "goozeSchemaOS"
+)
+
+// WithSchemata weaves the mutations of each file into a mutant schema: one
+// version of the file holding every mutated function body behind a runtime
+// switch, `if goozeSchemaMutant == "<id>" { mutated body }`, ahead of the
+// original one. The package is then compiled once for all of them, and each
+// mutant is selected by running the tests with SchemaEnv set to its SchemaID.
+// Mutations outside function bodies, in functions with labels or whose body
+// redeclares a parameter, and files whose schema does not type-check, are
+// tested on their own.
+func WithSchemata(enabled bool) MutagenOption {
+	return func(mg *mutagen) {
+		mg.schemata = enabled
+	}
+}
+
+// schemaFunc is a function whose body mutants can be woven into.
+type schemaFunc struct {
+	decl *ast.FuncDecl
+	// lbrace and rbrace are the offsets of the braces of the body.
+	lbrace, rbrace int
+	mutants        []int
+}
+
+// weaveSchema sets the SchemaCode and SchemaID of the mutations that can share
+// a mutant schema of content, when at least two can. It requires the typed
+// package, whose file is content, to check the schema compiles.
+func (mg *mutagen) weaveSchema(ctx context.Context, pkg *adapter.TypedPackage, content []byte, mutations []m.Mutation) []m.Mutation {
+	if !mg.schemata || pkg == nil {
+		return mutations
+	}
+
+	funcs := schemaFuncs(pkg.Fset, pkg.File)
+	woven := 0
+
+	for i, mutation := range mutations {
+		start, end := mutagens.EditSpan(content, mutation.MutatedCode)
+
+		for _, fn := range funcs {
+			if start > fn.lbrace && end <= fn.rbrace {
+				fn.mutants = append(fn.mutants, i)
+				woven++
+
+				break
+			}
+		}
+	}
+
+	if woven < 2 {
+		return mutations
+	}
+
+	// The package's importer only resolves its own imports, so the schema is
+	// checked with its switch declared rather than read from the environment.
+	if err := mg.TypeCheck(ctx, pkg, schemaCode(pkg.Fset, pkg.File, content, mutations, funcs, false)); err != nil {
+		slog.Debug("Testing mutations on their own as their schema does not type-check", "file", pkg.Fset.Position(pkg.File.Package).Filename, "error", err)
+		return mutations
+	}
+
+	code := schemaCode(pkg.Fset, pkg.File, content, mutations, funcs, true)
+
+	for _, fn := range funcs {
+		for _, i := range fn.mutants {
+			mutations[i].SchemaCode = code
+			mutations[i].SchemaID = schemaID(i)
+		}
+	}
+
+	return mutations
+}
+
+// schemaID is the switch value of the i-th mutation of a file.
+func schemaID(i int) string {
+	return strconv.Itoa(i + 1)
+}
+
+// schemaFuncs returns the functions of file, in source order, whose body can
+// hold copies of itself: a copy nested in the body must not redefine its
+// labels, nor shadow a parameter its top level redeclares.
+func schemaFuncs(fset *token.FileSet, file *ast.File) []*schemaFunc {
+	tokenFile := fset.File(file.Pos())
+
+	var funcs []*schemaFunc
+
+	for _, decl := range file.Decls {
+		fd, ok := decl.(*ast.FuncDecl)
+		if !ok || fd.Body == nil || hasLabels(fd.Body) || redeclaresParams(fd) {
+			continue
+		}
+
+		funcs = append(funcs, &schemaFunc{
+			decl:   fd,
+			lbrace: tokenFile.Offset(fd.Body.Lbrace),
+			rbrace: tokenFile.Offset(fd.Body.Rbrace),
+		})
+	}
+
+	return funcs
+}
+
+func hasLabels(body *ast.BlockStmt) bool {
+	found := false
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		if _, ok := n.(*ast.LabeledStmt); ok {
+			found = true
+		}
+
+		return !found
+	})
+
+	return found
+}
+
+// redeclaresParams reports whether a short variable declaration at the top
+// level of fd's body assigns to one of its receiver, parameters or results.
+func redeclaresParams(fd *ast.FuncDecl) bool {
+	params := map[string]bool{}
+
+	for _, list := range []*ast.FieldList{fd.Recv, fd.Type.Params, fd.Type.Results} {
+		if list == nil {
+			continue
+		}
+
+		for _, field := range list.List {
+			for _, name := range field.Names {
+				params[name.Name] = true
+			}
+		}
+	}
+
+	for _, stmt := range fd.Body.List {
+		assign, ok := stmt.(*ast.AssignStmt)
+		if !ok || assign.Tok != token.DEFINE {
+			continue
+		}
+
+		for _, lhs := range assign.Lhs {
+			if ident, ok := lhs.(*ast.Ident); ok && params[ident.Name] {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// schemaCode weaves the mutants of funcs into content. Each mutated body is
+// copied into an if statement at the start of the original body, returning
+// from the function once it completes. With env, the switch is read from
+// SchemaEnv; otherwise it is only declared.
+func schemaCode(fset *token.FileSet, file *ast.File, content []byte, mutations []m.Mutation, funcs []*schemaFunc, env bool) []byte {
+	var code strings.Builder
+
+	code.Grow(len(content) * 2)
+
+	// The os import goes after the file's last import declaration.
+	tokenFile := fset.File(file.Pos())
+	importAt := tokenFile.Offset(file.Name.End())
+
+	for _, decl := range file.Decls {
+		if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.IMPORT {
+			importAt = tokenFile.Offset(gen.End())
+		}
+	}
+
+	code.Write(content[:importAt])
+
+	if env {
+		fmt.Fprintf(&code, "\n\nimport %s \"os\"", schemaOS)
+	}
+
+	prev := importAt
+
+	for _, fn := range funcs {
+		if len(fn.mutants) == 0 {
+			continue
+		}
+
+		code.Write(content[prev : fn.lbrace+1])
+		prev = fn.lbrace + 1
+
+		for _, i := range fn.mutants {
+			mutated := mutations[i].MutatedCode
+
+			fmt.Fprintf(&code, "\nif %s == %q {", schemaSwitch, schemaID(i))
+			code.Write(mutated[fn.lbrace+1 : len(mutated)-(len(content)-fn.rbrace)])
+			code.WriteString("\n")
+
+			if fn.decl.Type.Results == nil || len(fn.decl.Type.Results.List) == 0 {
+				code.WriteString("return\n")
+			}
+
+			code.WriteString("}\n")
+		}
+	}
+
+	code.Write(content[prev:])
+
+	if env {
+		fmt.Fprintf(&code, "\nvar %s = %s.Getenv(%q)\n", schemaSwitch, schemaOS, SchemaEnv)
+	} else {
+		fmt.Fprintf(&code, "\nvar %s string\n", schemaSwitch)
+	}
+
+	return []byte(code.String())
+}
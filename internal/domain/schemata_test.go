@@ -0,0 +1,231 @@
+package domain
+
+import (
+	"bytes"
+	"context"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gooze.dev/pkg/gooze/internal/adapter"
+	adaptermocks "gooze.dev/pkg/gooze/internal/adapter/mocks"
+	m "gooze.dev/pkg/gooze/internal/model"
+	goozepkg "gooze.dev/pkg/gooze/pkg"
+)
+
+const schemataSource = `package calc
+
+import "fmt"
+
+var limit = 10
+
+func Add(a, b int) int { return a + b }
+
+func Describe(n int) string {
+	if n > limit {
+		return "big"
+	}
+
+	return fmt.Sprint(n)
+}
+
+func Print(n int) {
+	fmt.Println(n + 1)
+}
+
+func Sum(xs []int) (total int) {
+outer:
+	for _, x := range xs {
+		if x < 0 {
+			break outer
+		}
+
+		total += x
+	}
+
+	return
+}
+
+func Double(n int) int {
+	n, k := n*2, 1
+
+	return n * k
+}
+`
+
+func TestMutagen_GenerateMutation_Schemata(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "go.mod"), "module example.com/calc\n\ngo 1.22\n")
+	writeTestFile(t, filepath.Join(dir, "calc.go"), schemataSource)
+	writeTestFile(t, filepath.Join(dir, "calc_test.go"), "package calc\n\nimport \"testing\"\n\n"+
+		"func TestAdd(t *testing.T) {\n\tif got := Add(2, 3); got != 5 {\n\t\tt.Fatalf(\"Add(2, 3) = %d\", got)\n\t}\n}\n")
+
+	source := makeSourceV2(t, filepath.Join(dir, "calc.go"))
+	types := []m.MutationType{m.MutationArithmetic, m.MutationComparison, m.MutationNumbers}
+
+	t.Run("is off by default", func(t *testing.T) {
+		mutations, err := newTestMutagen().GenerateMutation(context.Background(), source, types...)
+		require.NoError(t, err)
+
+		for _, mutation := range mutations {
+			require.Empty(t, mutation.SchemaCode)
+		}
+	})
+
+	mg := NewMutagen(adapter.NewLocalGoFileAdapter(), adapter.NewLocalSourceFSAdapter(), WithSchemata(true))
+
+	mutations, err := mg.GenerateMutation(context.Background(), source, types...)
+	require.NoError(t, err)
+
+	woven := map[string]m.Mutation{}
+	alone := map[string]bool{}
+
+	var schema []byte
+
+	for _, mutation := range mutations {
+		inFunc := funcOfLine(t, mutation.Line)
+
+		switch inFunc {
+		case "Add", "Describe", "Print":
+			require.NotEmpty(t, mutation.SchemaCode, "mutation on line %d", mutation.Line)
+			require.NotContains(t, woven, mutation.SchemaID)
+
+			if schema == nil {
+				schema = mutation.SchemaCode
+			}
+
+			require.Equal(t, schema, mutation.SchemaCode)
+
+			woven[mutation.SchemaID] = mutation
+		default:
+			// Package-level code, labels and redeclared parameters.
+			require.Empty(t, mutation.SchemaCode, "mutation on line %d in %s", mutation.Line, inFunc)
+
+			alone[inFunc] = true
+		}
+	}
+
+	require.NotEmpty(t, schema)
+	require.Equal(t, map[string]bool{"": true, "Sum": true, "Double": true}, alone)
+
+	t.Run("selects the mutant from the environment", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "calc.go"), schema, 0o600))
+
+		runner := adapter.NewLocalTestRunnerAdapter()
+
+		output, err := runner.RunGoTest(context.Background(), dir, ".", "", "")
+		require.NoError(t, err, "the schema with no mutant selected must behave as the original:\n%s", output)
+
+		for id, mutation := range woven {
+			if !bytes.Contains(mutation.MutatedCode, []byte("return a - b")) {
+				continue
+			}
+
+			output, err = runner.RunGoTest(context.Background(), dir, ".", "", "", SchemaEnv+"="+id)
+			require.Error(t, err, "the selected mutant must be killed:\n%s", output)
+
+			return
+		}
+
+		t.Fatal("expected a woven `a - b` mutation of Add")
+	})
+}
+
+// funcOfLine names the function of schemataSource holding line, or returns ""
+// outside functions.
+func funcOfLine(t *testing.T, line int) string {
+	t.Helper()
+
+	fset := token.NewFileSet()
+
+	file, err := parser.ParseFile(fset, "calc.go", schemataSource, 0)
+	require.NoError(t, err)
+
+	for _, decl := range file.Decls {
+		if fd, ok := decl.(*ast.FuncDecl); ok && fset.Position(fd.Pos()).Line <= line && line <= fset.Position(fd.End()).Line {
+			return fd.Name.Name
+		}
+	}
+
+	return ""
+}
+
+func TestSourceBatch_SpillsSchemaOnce(t *testing.T) {
+	schema := bytes.Repeat([]byte("// schema\n"), 1000)
+	source := makeTestMutation().Source
+
+	mutations := make([]m.Mutation, 3)
+	for i := range mutations {
+		mutations[i] = makeTestMutation()
+		mutations[i].ID = schemaID(i)
+	}
+
+	// The last mutation is tested on its own.
+	for i := range mutations[:2] {
+		mutations[i].SchemaCode = schema
+		mutations[i].SchemaID = schemaID(i)
+	}
+
+	spill, err := goozepkg.NewFileSpill[sourceBatch]()
+	require.NoError(t, err)
+
+	defer removeSpill(spill)
+
+	require.NoError(t, spill.Append(newSourceBatch(source, mutations)))
+
+	info, err := os.Stat(spill.Path())
+	require.NoError(t, err)
+	require.Less(t, info.Size(), int64(2*len(schema)))
+
+	batch, err := spill.Get(0)
+	require.NoError(t, err)
+
+	for _, mutation := range batch.Mutations {
+		require.Empty(t, mutation.SchemaCode)
+	}
+
+	batch.restoreSchema()
+
+	require.Equal(t, schema, batch.Mutations[0].SchemaCode)
+	require.Equal(t, schema, batch.Mutations[1].SchemaCode)
+	require.Empty(t, batch.Mutations[2].SchemaCode)
+	// The caller's mutations are left as they were.
+	require.Equal(t, schema, mutations[0].SchemaCode)
+}
+
+func TestOrchestrator_TestMutation_Schema(t *testing.T) {
+	fsAdapter := adaptermocks.NewMockSourceFSAdapter(t)
+	trAdapter := adaptermocks.NewMockTestRunnerAdapter(t)
+	orch := NewOrchestrator(fsAdapter, trAdapter)
+	ctx := context.Background()
+	projectRoot := m.Path("/project")
+	tmpDir := m.Path("/tmp/mut")
+
+	mutation := makeTestMutation()
+	mutation.SchemaCode = []byte("package main\n\n// schema\n")
+	mutation.SchemaID = "2"
+
+	fsAdapter.EXPECT().FindProjectRoot(ctx, mutation.Source.Origin.FullPath).Return(projectRoot, nil)
+	fsAdapter.EXPECT().CreateTempDir(ctx, "gooze-mutation-*").Return(tmpDir, nil)
+	fsAdapter.EXPECT().CopyModule(ctx, projectRoot, tmpDir).Return(tmpDir, nil)
+	fsAdapter.EXPECT().RelPath(ctx, projectRoot, mutation.Source.Origin.FullPath).Return(m.Path("main.go"), nil)
+	fsAdapter.EXPECT().JoinPath(ctx, string(tmpDir), "main.go").Return(m.Path("/tmp/mut/main.go"))
+	fsAdapter.EXPECT().RelPath(ctx, projectRoot, mutation.Source.Tests[0].FullPath).Return(m.Path("main_test.go"), nil)
+	fsAdapter.EXPECT().JoinPath(ctx, string(tmpDir), "main_test.go").Return(m.Path("/tmp/mut/main_test.go"))
+	fsAdapter.EXPECT().ReadFile(ctx, m.Path("/tmp/mut/main.go")).Return([]byte("package main\n"), nil)
+	// The schema is applied instead of the mutated code, and the original
+	// restored afterwards.
+	fsAdapter.EXPECT().WriteFile(ctx, m.Path("/tmp/mut/main.go"), mutation.SchemaCode, os.FileMode(0o600)).Return(nil)
+	fsAdapter.EXPECT().WriteFile(mock.Anything, m.Path("/tmp/mut/main.go"), []byte("package main\n"), os.FileMode(0o600)).Return(nil)
+	trAdapter.EXPECT().RunGoTest(ctx, "/tmp/mut", "/tmp/mut", "", "", SchemaEnv+"=2").Return("", nil)
+	fsAdapter.EXPECT().RemoveAll(mock.Anything, tmpDir).Return(nil)
+
+	result, err := orch.TestMutation(ctx, mutation)
+	require.NoError(t, err)
+	require.Equal(t, m.Survived, result[mutation.Type][0].Status)
+}
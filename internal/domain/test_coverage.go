@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	m "gooze.dev/pkg/gooze/internal/model"
+)
+
+// TestCoverage is a per-test coverage map: for each test package directory, the
+// lines each of its tests reaches. A nil index means the test's coverage is
+// unknown (its profile could not be produced), so it is assumed to reach
+// everything.
+type TestCoverage map[m.Path]map[string]*CoverageIndex
+
+// covering returns, per test package directory of the mutation's source, the
+// tests that reach the mutated line, in name order. Directories without such a
+// test are left out, so an empty result means no test reaches the line.
+func (c TestCoverage) covering(mutation m.Mutation) map[m.Path][]string {
+	covering := map[m.Path][]string{}
+
+	for _, dir := range testPackageDirs(mutation.Source.Tests) {
+		for test, index := range c[dir] {
+			if index == nil || index.Covers(string(mutation.Source.Origin.ShortPath), mutation.Line) {
+				covering[dir] = append(covering[dir], test)
+			}
+		}
+
+		sort.Strings(covering[dir])
+	}
+
+	return covering
+}
+
+// selectTests narrows the tests run for a mutation to those reaching its line.
+// It reports false when no test reaches the line. Higher-order mutants span
+// several lines, so they keep running every test.
+func (c TestCoverage) selectTests(mutation *m.Mutation) bool {
+	if c == nil || len(mutation.Constituents) > 0 || mutation.Source.Origin == nil || len(mutation.Source.Tests) == 0 {
+		return true
+	}
+
+	covering := c.covering(*mutation)
+	if len(covering) == 0 {
+		return false
+	}
+
+	mutation.CoveringTests = covering
+
+	return true
+}
+
+// testPackageDirs returns the package directories of the test files, in order
+// of first appearance.
+func testPackageDirs(tests []*m.File) []m.Path {
+	seen := make(map[m.Path]bool, len(tests))
+	dirs := make([]m.Path, 0, len(tests))
+
+	for _, test := range tests {
+		dir := m.Path(filepath.Dir(string(test.FullPath)))
+		if !seen[dir] {
+			seen[dir] = true
+			dirs = append(dirs, dir)
+		}
+	}
+
+	return dirs
+}
+
+// runPattern builds the `go test -run` expression matching exactly the named
+// top-level tests.
+func runPattern(tests []string) string {
+	quoted := make([]string, len(tests))
+	for i, test := range tests {
+		quoted[i] = regexp.QuoteMeta(test)
+	}
+
+	return "^(" + strings.Join(quoted, "|") + ")$"
+}
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	m "gooze.dev/pkg/gooze/internal/model"
+)
+
+func TestTestCoverage_SelectTests(t *testing.T) {
+	lineTen, err := ParseCoverage([]byte("mode: set\nmod/pkg/foo.go:10.1,12.2 1 1\n"))
+	require.NoError(t, err)
+
+	other, err := ParseCoverage([]byte("mode: set\nmod/pkg/bar.go:10.1,12.2 1 1\n"))
+	require.NoError(t, err)
+
+	coverage := TestCoverage{
+		"/p/pkg":         {"TestB": lineTen, "TestA": lineTen, "TestBar": other},
+		"/p/integration": {"TestUnknown": nil, "TestBar": other},
+		"/p/unrelated":   {"TestFoo": lineTen},
+	}
+
+	source := m.Source{
+		Origin: &m.File{FullPath: "/p/pkg/foo.go", ShortPath: "pkg/foo.go"},
+		Tests:  []*m.File{{FullPath: "/p/pkg/foo_test.go"}, {FullPath: "/p/pkg/api_test.go"}, {FullPath: "/p/integration/int_test.go"}},
+	}
+
+	t.Run("reached line runs the reaching tests per package", func(t *testing.T) {
+		mutation := m.Mutation{Source: source, Line: 11}
+
+		require.True(t, coverage.selectTests(&mutation))
+		assert.Equal(t, map[m.Path][]string{
+			"/p/pkg":         {"TestA", "TestB"},
+			"/p/integration": {"TestUnknown"},
+		}, mutation.CoveringTests)
+	})
+
+	t.Run("tests with unknown coverage keep the mutation", func(t *testing.T) {
+		mutation := m.Mutation{Source: source, Line: 50}
+
+		require.True(t, coverage.selectTests(&mutation))
+		assert.Equal(t, map[m.Path][]string{"/p/integration": {"TestUnknown"}}, mutation.CoveringTests)
+	})
+
+	t.Run("unreached line", func(t *testing.T) {
+		mutation := m.Mutation{Source: m.Source{Origin: source.Origin, Tests: source.Tests[:1]}, Line: 50}
+
+		assert.False(t, coverage.selectTests(&mutation))
+		assert.Nil(t, mutation.CoveringTests)
+	})
+
+	t.Run("higher-order mutants and a missing map run every test", func(t *testing.T) {
+		higherOrder := m.Mutation{Source: source, Line: 50, Constituents: []string{"a", "b"}}
+		assert.True(t, coverage.selectTests(&higherOrder))
+		assert.Nil(t, higherOrder.CoveringTests)
+
+		plain := m.Mutation{Source: source, Line: 50}
+		assert.True(t, TestCoverage(nil).selectTests(&plain))
+		assert.Nil(t, plain.CoveringTests)
+	})
+}
+
+func TestRunPattern(t *testing.T) {
+	assert.Equal(t, "^(TestA|TestB)$", runPattern([]string{"TestA", "TestB"}))
+	assert.Equal(t, "^(Example_x\\.y)$", runPattern([]string{"Example_x.y"}))
+}
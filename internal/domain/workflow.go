@@ -20,7 +20,7 @@ import (
 )
 
 // DefaultMutations defines the default set of mutation types to generate.
-var DefaultMutations = []m.MutationType{m.MutationArithmetic, m.MutationBoolean, m.MutationNumbers, m.MutationComparison, m.MutationLogical, m.MutationUnary}
+var DefaultMutations = []m.MutationType{m.MutationArithmetic, m.MutationBoolean, m.MutationNumbers, m.MutationComparison, m.MutationLogical, m.MutationUnary, m.MutationCollection}
 
 // ShardDirPrefix is the directory name prefix used when storing sharded reports.
 const ShardDirPrefix = "shard_"
@@ -31,6 +31,10 @@ type EstimateArgs struct {
 	Exclude  []string
 	UseCache bool
 	Reports  m.Path
+	// Funcs limits mutation to the selected functions and methods.
+	Funcs FuncFilter
+	// Lines limits mutation to the selected lines of the selected files.
+	Lines LineSelection
 }
 
 // TestArgs contains the arguments for running mutation tests.
@@ -42,6 +46,36 @@ type TestArgs struct {
 	TotalShardCount int
 	MutationTimeout time.Duration
 	CoverageProfile m.Path
+	// DetectEquivalent compiles every surviving mutant and reports it as
+	// equivalent when it compiles to the same code as the original.
+	DetectEquivalent bool
+	// Sampling tests a subset of the mutants and reports the rest as skipped.
+	Sampling Sampling
+	// PerTestCoverage maps the lines each test reaches before testing, then
+	// runs only the tests reaching a mutant's line against it. Mutants no test
+	// reaches are reported as not covered.
+	PerTestCoverage bool
+	// Baseline runs the tests of the affected packages once against the
+	// unmutated code before testing any mutant, and fails when they fail. Each
+	// mutant's timeout is then derived from the measured durations.
+	Baseline bool
+	// TimeoutFactor and TimeoutConstant set a mutant's timeout to
+	// TimeoutFactor*baseline + TimeoutConstant, where baseline is how long its
+	// test packages took in the baseline run. MutationTimeout applies when
+	// there is no baseline.
+	TimeoutFactor   float64
+	TimeoutConstant time.Duration
+	// BaselineCoverage records coverage during the baseline run and writes the
+	// profile to this path. Unless CoverageProfile is set, the profile is then
+	// used in its place.
+	BaselineCoverage m.Path
+	// FlakyReruns runs the tests of every mutant that survived or timed out
+	// this many more times. Mutants whose outcome changes are reported as
+	// flaky, along with the tests involved, and left out of the score.
+	FlakyReruns int
+	// FlakyBaseline also runs the baseline FlakyReruns more times, and fails
+	// when any run fails.
+	FlakyBaseline bool
 }
 
 // ViewArgs contains the arguments for viewing mutation test reports.
@@ -128,12 +162,29 @@ func (w *workflow) Test(ctx context.Context, args TestArgs) error {
 			return err
 		}
 
+		sources = args.Lines.sources(sources)
+
+		cache, stale, err := w.loadResultCache(ctx, args.EstimateArgs, sources)
+		if err != nil {
+			slog.Error("Failed to load cached results", "error", err)
+			return err
+		}
+
 		inThisShard := func(mutation m.Mutation) bool {
-			return inShard(mutation.ID, args.ShardIndex, args.TotalShardCount)
+			return args.Lines.includes(mutation) && inShard(mutation.ID, args.ShardIndex, args.TotalShardCount)
+		}
+
+		// Count mutations up front so the UI can show an accurate progress total,
+		// keeping them so that they are tested without being generated again.
+		generated, err := pkg.NewFileSpill[sourceBatch]()
+		if err != nil {
+			slog.Error("Failed to create mutations filespill", "error", err)
+			return fmt.Errorf("create mutations filespill: %w", err)
 		}
 
-		// Count mutations up front so the UI can show an accurate progress total.
-		estimation, err := w.countMutations(ctx, sources, inThisShard)
+		defer removeSpill(generated)
+
+		estimation, err := w.countMutations(ctx, sources, args.Funcs, inThisShard, generated)
 		if err != nil {
 			slog.Error("Failed to count mutations", "error", err)
 			return fmt.Errorf("generate mutations: %w", err)
@@ -141,16 +192,38 @@ func (w *workflow) Test(ctx context.Context, args TestArgs) error {
 
 		w.progress.DisplayUpcomingTestsInfo(ctx, estimation.Total)
 
+		timeouts, err := w.runBaseline(ctx, args, sources)
+		if err != nil {
+			slog.Error("Failed to run baseline tests", "error", err)
+			return fmt.Errorf("baseline: %w", err)
+		}
+
+		coverageProfile := args.CoverageProfile
+		if coverageProfile == "" {
+			coverageProfile = args.BaselineCoverage
+		}
+
 		var gate *CoverageIndex
-		if args.CoverageProfile != "" {
-			gate, err = w.loadCoverage(ctx, args.CoverageProfile)
+		if coverageProfile != "" {
+			gate, err = w.loadCoverage(ctx, coverageProfile)
 			if err != nil {
 				slog.Error("Failed to load coverage profile", "error", err)
 				return fmt.Errorf("load coverage profile: %w", err)
 			}
 		}
 
-		reports, err := w.testReports(ctx, sources, inThisShard, gate, args.Threads, args.MutationTimeout)
+		var tests TestCoverage
+		if args.PerTestCoverage {
+			tests, err = w.orchestrator.MapTestCoverage(ctx, sources)
+			if err != nil {
+				slog.Error("Failed to map per-test coverage", "error", err)
+				return fmt.Errorf("map per-test coverage: %w", err)
+			}
+
+			slog.Info("Mapped per-test coverage", "packages", len(tests))
+		}
+
+		reports, err := w.testReports(ctx, generated, reportScope{lines: args.Lines, funcs: args.Funcs}, inThisShard, gate, tests, cache, args.Sampling, args.Threads, timeouts, args.DetectEquivalent, args.FlakyReruns)
 		if err != nil {
 			slog.Error("Failed to run mutation tests", "error", err)
 			return fmt.Errorf("run mutation tests: %w", err)
@@ -158,20 +231,25 @@ func (w *workflow) Test(ctx context.Context, args TestArgs) error {
 
 		slog.Info("Completed mutation tests", "reportsCount", reports.Len())
 
-		return w.finalizeReports(ctx, reportsDir, reports)
+		return w.finalizeReports(ctx, reportsDir, reports, stale)
 	})
 }
 
-// finalizeReports computes the mutation score, persists the spilled reports, and
-// regenerates the reports index.
-func (w *workflow) finalizeReports(ctx context.Context, reportsDir m.Path, reports pkg.FileSpill[m.Report]) error {
-	score, err := mutationScoreFromReports(reports)
+// finalizeReports computes the mutation score, replaces the stale stored
+// reports with the spilled reports, and regenerates the reports index.
+func (w *workflow) finalizeReports(ctx context.Context, reportsDir m.Path, reports pkg.FileSpill[m.Report], stale staleReports) error {
+	tally, err := tallyReports(reports)
 	if err != nil {
 		return fmt.Errorf("calculate mutation score: %w", err)
 	}
 
-	slog.Info("Calculated mutation score", "score", score)
-	w.progress.DisplayMutationScore(ctx, score)
+	slog.Info("Calculated mutation score", "score", tally.score())
+	w.displayScore(ctx, tally)
+
+	if err := w.replaceStaleReports(ctx, stale); err != nil {
+		slog.Error("Failed to replace stale reports", "error", err, "path", stale.dir)
+		return err
+	}
 
 	if err := w.reports.SaveSpillReports(ctx, reportsDir, reports); err != nil {
 		slog.Error("Failed to save reports", "error", err, "path", reportsDir)
@@ -220,12 +298,12 @@ func (w *workflow) View(ctx context.Context, args ViewArgs) error {
 
 		slog.Debug("Loaded reports", "reportsCount", reports.Len(), "mutationsCount", len(mutations))
 
-		score, err := mutationScoreFromReports(reports)
+		tally, err := tallyReports(reports)
 		if err != nil {
 			return fmt.Errorf("calculate mutation score: %w", err)
 		}
 
-		slog.Info("Calculated mutation score", "score", score)
+		slog.Info("Calculated mutation score", "score", tally.score())
 		w.progress.DisplayUpcomingTestsInfo(ctx, len(mutations))
 
 		for i, mutation := range mutations {
@@ -233,7 +311,7 @@ func (w *workflow) View(ctx context.Context, args ViewArgs) error {
 			w.progress.DisplayCompletedTestInfo(ctx, mutation, results[i])
 		}
 
-		w.progress.DisplayMutationScore(ctx, score)
+		w.displayScore(ctx, tally)
 
 		return nil
 	})
@@ -278,11 +356,22 @@ func (w *workflow) Merge(ctx context.Context, args MergeArgs) error {
 	}
 
 	// Report the combined score last, so `gooze report merge` ends with it.
-	w.progress.DisplayMutationScore(ctx, mutationScoreFromReportSlice(merged))
+	w.displayScore(ctx, tallyReportSlice(merged))
 
 	return nil
 }
 
+// displayScore shows the mutation score and, when mutants were skipped, the
+// confidence interval of the score of all mutants.
+func (w *workflow) displayScore(ctx context.Context, tally scoreTally) {
+	w.progress.DisplayMutationScore(ctx, tally.score())
+
+	if tally.sampled() {
+		low, high := tally.interval()
+		w.progress.DisplayScoreInterval(ctx, low, high)
+	}
+}
+
 func (w *workflow) findShardDirs(base m.Path) ([]string, error) {
 	shardDirs, err := findShardDirs(string(base))
 	if err != nil {
@@ -310,9 +399,9 @@ func (w *workflow) mergeReports(ctx context.Context, base m.Path, shardDirs []st
 		return nil, fmt.Errorf("load existing reports from base: %w", err)
 	}
 
-	merged = append(merged, existingReports...)
-
 	// Then load and merge reports from all shards.
+	shardReports := make([]m.Report, 0)
+
 	for _, shardDir := range shardDirs {
 		reports, err := w.reports.LoadReports(ctx, m.Path(shardDir))
 		if err != nil {
@@ -320,9 +409,27 @@ func (w *workflow) mergeReports(ctx context.Context, base m.Path, shardDirs []st
 			return nil, fmt.Errorf("load shard reports from %s: %w", shardDir, err)
 		}
 
-		merged = append(merged, reports...)
+		shardReports = append(shardReports, reports...)
+	}
+
+	// Shards run on a line selection only replace the base reports of the
+	// selected lines; the rest of the base stays as it is. The selected files'
+	// base reports are cleaned and the kept ones saved again with the merge.
+	selection := reportSelection(shardReports)
+	if selection.Enabled() && len(existingReports) > 0 {
+		if err := w.reports.CleanReports(ctx, base, selection.files()); err != nil {
+			return nil, fmt.Errorf("clean selected reports: %w", err)
+		}
+	}
+
+	for _, report := range existingReports {
+		if !selection.covers(report) {
+			merged = append(merged, report)
+		}
 	}
 
+	merged = append(merged, shardReports...)
+
 	slog.Debug("Merged reports from shards", "totalReports", len(merged))
 
 	return merged, nil
@@ -475,7 +582,7 @@ func (w *workflow) changedSources(ctx context.Context, args EstimateArgs) ([]m.S
 		return sources, nil
 	}
 
-	changed, err := w.reports.CheckUpdates(ctx, args.Reports, sources)
+	changed, err := w.reports.CheckUpdates(ctx, args.Reports, sources, m.RunScope{Lines: args.Lines, Funcs: m.FuncSelection(args.Funcs)})
 	if err != nil {
 		return nil, fmt.Errorf("check updates: %w", err)
 	}
@@ -547,21 +654,47 @@ func (w *workflow) estimateMutations(ctx context.Context, args EstimateArgs) (Es
 		return Estimation{}, err
 	}
 
-	return w.countMutations(ctx, sources, nil)
+	estimation, err := w.countMutations(ctx, args.Lines.sources(sources), args.Funcs, args.Lines.includes, nil)
+	if err != nil {
+		return Estimation{}, err
+	}
+
+	estimation.Skipped = w.sources.SkippedFiles(ctx)
+
+	return estimation, nil
 }
 
-// countMutations generates the mutations for the given sources and aggregates
-// per-file counts. include, when non-nil, filters which mutations are counted.
-// Mutations are processed one at a time and never retained.
-func (w *workflow) countMutations(ctx context.Context, sources []m.Source, include func(m.Mutation) bool) (Estimation, error) {
+// countMutations generates the mutations in the selected functions of the given
+// sources and aggregates per-file counts. include, when non-nil, filters which
+// mutations are counted. generated, when non-nil, receives every source's
+// mutations, counted or not.
+// Only one source's mutations are held in memory at a time.
+func (w *workflow) countMutations(
+	ctx context.Context,
+	sources []m.Source,
+	funcs FuncFilter,
+	include func(m.Mutation) bool,
+	generated pkg.FileSpill[sourceBatch],
+) (Estimation, error) {
 	byKey := map[string]*FileEstimate{}
 	order := make([]string, 0)
 	total := 0
 
 	for _, source := range sources {
-		err := w.mutagen.StreamMutations(ctx, source, func(mutation m.Mutation) error {
+		mutations, err := w.sourceMutations(ctx, source, funcs)
+		if err != nil {
+			return Estimation{}, err
+		}
+
+		if generated != nil {
+			if err := generated.Append(newSourceBatch(source, mutations)); err != nil {
+				return Estimation{}, fmt.Errorf("spill mutations: %w", err)
+			}
+		}
+
+		for _, mutation := range mutations {
 			if include != nil && !include(mutation) {
-				return nil
+				continue
 			}
 
 			total++
@@ -576,11 +709,6 @@ func (w *workflow) countMutations(ctx context.Context, sources []m.Source, inclu
 			}
 
 			estimate.Count++
-
-			return nil
-		}, DefaultMutations...)
-		if err != nil {
-			return Estimation{}, err
 		}
 	}
 
@@ -638,18 +766,47 @@ type mutationOutcome struct {
 	mutation m.Mutation
 	result   m.Result
 	err      error
+	// flakyTests are the tests involved when the result is flaky.
+	flakyTests []string
+}
+
+// runBaseline runs the baseline when enabled and returns the timeouts of the
+// mutants, adaptive when the baseline ran. With BaselineCoverage, it writes the
+// baseline's coverage profile.
+func (w *workflow) runBaseline(ctx context.Context, args TestArgs, sources []m.Source) (mutationTimeouts, error) {
+	timeouts := mutationTimeouts{Timeouts: Timeouts{
+		Fixed:    args.MutationTimeout,
+		Factor:   args.TimeoutFactor,
+		Constant: args.TimeoutConstant,
+	}}
+
+	if !args.Baseline && args.BaselineCoverage == "" && !args.FlakyBaseline {
+		return timeouts, nil
+	}
+
+	reruns := 0
+	if args.FlakyBaseline {
+		reruns = args.FlakyReruns
+	}
+
+	baseline, err := w.orchestrator.Baseline(ctx, sources, args.BaselineCoverage != "", reruns)
+	if err != nil {
+		return timeouts, err
+	}
+
+	timeouts.durations = baseline.Durations
+
+	slog.Info("Ran baseline tests", "packages", len(baseline.Durations))
+
+	if args.BaselineCoverage != "" {
+		if err := w.sources.WriteFile(ctx, args.BaselineCoverage, baseline.Profile, 0o600); err != nil {
+			return timeouts, fmt.Errorf("write coverage profile: %w", err)
+		}
+	}
+
+	return timeouts, nil
 }
 
-// testReports runs mutation testing as a fan-out/fan-in channel pipeline:
-//
-//	dispatcher --> [ per-thread mutation queues ] --> workers --> results --> collector --> FileSpill
-//
-// The dispatcher streams mutations source-by-source and hands each one to a
-// ready worker queue (one queue per configured thread). Each worker owns a
-// reusable workspace, tests the mutation, and sends the outcome on the shared
-// results channel. A single collector drains results and spills reports to disk.
-// Peak memory is bounded by one file's mutations plus the small channel buffers,
-// not the whole project's mutations.
 // loadCoverage reads and parses the supplied coverage profile.
 func (w *workflow) loadCoverage(ctx context.Context, profile m.Path) (*CoverageIndex, error) {
 	content, err := w.sources.ReadFile(ctx, profile)
@@ -660,13 +817,29 @@ func (w *workflow) loadCoverage(ctx context.Context, profile m.Path) (*CoverageI
 	return ParseCoverage(content)
 }
 
+// testReports runs mutation testing as a fan-out/fan-in channel pipeline:
+//
+//	dispatcher --> [ per-thread mutation queues ] --> workers --> results --> collector --> FileSpill
+//
+// The dispatcher streams mutations source-by-source and hands each one to a
+// ready worker queue (one queue per configured thread). Each worker owns a
+// reusable workspace, tests the mutation, and sends the outcome on the shared
+// results channel. A single collector drains results and spills reports to disk.
+// Peak memory is bounded by one file's mutations plus the small channel buffers,
+// not the whole project's mutations.
 func (w *workflow) testReports(
 	ctx context.Context,
-	sources []m.Source,
+	generated pkg.FileSpill[sourceBatch],
+	scope reportScope,
 	include func(m.Mutation) bool,
 	gate *CoverageIndex,
+	tests TestCoverage,
+	cache resultCache,
+	sampling Sampling,
 	threads int,
-	mutationTimeout time.Duration,
+	timeouts mutationTimeouts,
+	detectEquivalent bool,
+	flakyReruns int,
 ) (pkg.FileSpill[m.Report], error) {
 	reports, err := pkg.NewFileSpill[m.Report]()
 	if err != nil {
@@ -688,15 +861,15 @@ func (w *workflow) testReports(
 	collected := make(chan collectorResult, 1)
 
 	go func() {
-		collected <- w.collectResults(ctx, results, reports)
+		collected <- w.collectResults(ctx, results, reports, scope)
 	}()
 
 	var group errgroup.Group
 
-	group.Go(w.dispatchMutations(ctx, sources, include, gate, queues, results))
+	group.Go(w.dispatchMutations(ctx, generated, include, gate, tests, cache, sampling, queues, results))
 
 	for threadID := range effectiveThreads {
-		group.Go(w.consumeMutations(ctx, queues[threadID], threadID, mutationTimeout, results))
+		group.Go(w.consumeMutations(ctx, queues[threadID], threadID, timeouts, detectEquivalent, flakyReruns, results))
 	}
 
 	runErr := group.Wait()
@@ -730,6 +903,17 @@ func testRunError(runErr error, outcome collectorResult) error {
 	}
 }
 
+// reportScope is the selection of lines and functions a run was limited to,
+// which each of its reports records.
+type reportScope struct {
+	lines LineSelection
+	funcs FuncFilter
+}
+
+func (s reportScope) stamp(report m.Report) m.Report {
+	return s.funcs.stamp(s.lines.stamp(report))
+}
+
 // collectorResult aggregates everything the collector observed: non-fatal
 // per-mutation errors and a fatal error (e.g. a spill write failure).
 type collectorResult struct {
@@ -738,12 +922,14 @@ type collectorResult struct {
 }
 
 // collectResults drains the results channel until it is closed, spilling a
-// report for each successful outcome and accumulating errors. Because a single
-// goroutine owns the report spill and the error slices, no locking is needed.
+// report for each successful outcome, stamped with the run's scope, and
+// accumulating errors. Because a single goroutine owns the report spill and the
+// error slices, no locking is needed.
 func (w *workflow) collectResults(
 	ctx context.Context,
 	results <-chan mutationOutcome,
 	reports pkg.FileSpill[m.Report],
+	scope reportScope,
 ) collectorResult {
 	var collected collectorResult
 
@@ -753,7 +939,7 @@ func (w *workflow) collectResults(
 			continue
 		}
 
-		if err := reports.Append(buildReport(outcome.mutation, outcome.result)); err != nil {
+		if err := appendReports(reports, outcome, scope); err != nil {
 			slog.Error("failed to append report to filespill", "error", err)
 
 			if collected.fatalErr == nil {
@@ -769,10 +955,39 @@ func (w *workflow) collectResults(
 	return collected
 }
 
+// appendReports spills the report of an outcome and one report per duplicate of
+// its mutation, carrying the same status under the duplicate's own mutagen.
+func appendReports(reports pkg.FileSpill[m.Report], outcome mutationOutcome, scope reportScope) error {
+	report := buildReport(outcome.mutation, outcome.result)
+	report.FlakyTests = outcome.flakyTests
+
+	if err := reports.Append(scope.stamp(report)); err != nil {
+		return err
+	}
+
+	status := getMutationStatus(outcome.result, outcome.mutation)
+
+	for _, duplicate := range outcome.mutation.Duplicates {
+		report := buildReport(duplicate, resultForStatus(duplicate, status))
+		report.DuplicateOf = duplicate.DuplicateOf
+		report.FlakyTests = outcome.flakyTests
+
+		if err := reports.Append(scope.stamp(report)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func buildReport(mutation m.Mutation, result m.Result) m.Report {
 	report := m.Report{
-		Source: mutation.Source,
-		Result: result,
+		Source:       mutation.Source,
+		Result:       result,
+		Scope:        mutation.Scope,
+		Constituents: mutation.Constituents,
+		Line:         mutation.Line,
+		Func:         mutation.Func,
 	}
 
 	if getMutationStatus(result, mutation) != m.Killed {
@@ -783,42 +998,159 @@ func buildReport(mutation m.Mutation, result m.Result) m.Report {
 	return report
 }
 
-// dispatchMutations returns a task that generates each source's mutations,
-// shards inline, and dispatches each kept mutation to a ready worker queue,
-// closing every queue when done so the workers terminate.
+// dispatchMutations returns a task that reads back each source's generated
+// mutations, samples and shards inline, and routes each kept mutation, closing
+// every queue when done so the workers terminate.
 func (w *workflow) dispatchMutations(
 	ctx context.Context,
-	sources []m.Source,
+	generated pkg.FileSpill[sourceBatch],
 	include func(m.Mutation) bool,
 	gate *CoverageIndex,
+	tests TestCoverage,
+	cache resultCache,
+	sampling Sampling,
 	queues []chan m.Mutation,
 	results chan<- mutationOutcome,
 ) func() error {
 	return func() error {
 		defer closeQueues(queues)
 
-		for _, source := range sources {
-			err := w.mutagen.StreamMutations(ctx, source, func(mutation m.Mutation) error {
-				if include != nil && !include(mutation) {
-					return nil
+		return generated.Range(func(_ uint64, batch sourceBatch) error {
+			batch.restoreSchema()
+
+			// A cached result costs nothing, so only the mutants without one
+			// are sampled.
+			untested := make([]m.Mutation, 0, len(batch.Mutations))
+
+			for _, mutation := range batch.Mutations {
+				if _, ok := cache.lookup(mutation); !ok {
+					untested = append(untested, mutation)
 				}
+			}
+
+			selected := sampling.sample(sourceKey(batch.Source), untested)
 
-				if gate != nil && !gate.Covers(string(mutation.Source.Origin.ShortPath), mutation.Line) {
-					return sendOutcome(ctx, results, mutationOutcome{
-						mutation: mutation,
-						result:   resultForStatus(mutation, m.NotCovered),
-					})
+			for _, mutation := range batch.Mutations {
+				if include != nil && !include(mutation) {
+					continue
 				}
 
-				return dispatch(ctx, queues, mutation)
-			}, DefaultMutations...)
-			if err != nil {
-				return fmt.Errorf("generate mutations: %w", err)
+				_, cached := cache.lookup(mutation)
+
+				if err := route(ctx, mutation, selected[mutation.ID] || cached, gate, tests, cache, queues, results); err != nil {
+					return err
+				}
 			}
+
+			return nil
+		})
+	}
+}
+
+// route sends a mutation to a worker queue, or straight to the collector when
+// it was sampled out, is not covered, or has a reusable cached result. With a
+// per-test coverage map, the queued mutation only runs the tests reaching it.
+func route(
+	ctx context.Context,
+	mutation m.Mutation,
+	selected bool,
+	gate *CoverageIndex,
+	tests TestCoverage,
+	cache resultCache,
+	queues []chan m.Mutation,
+	results chan<- mutationOutcome,
+) error {
+	if !selected {
+		return sendOutcome(ctx, results, mutationOutcome{
+			mutation: mutation,
+			result:   resultForStatus(mutation, m.Skipped),
+		})
+	}
+
+	if (gate != nil && !gate.Covers(string(mutation.Source.Origin.ShortPath), mutation.Line)) || !tests.selectTests(&mutation) {
+		return sendOutcome(ctx, results, mutationOutcome{
+			mutation: mutation,
+			result:   resultForStatus(mutation, m.NotCovered),
+		})
+	}
+
+	if result, ok := cache.lookup(mutation); ok {
+		return sendOutcome(ctx, results, mutationOutcome{mutation: mutation, result: result})
+	}
+
+	return dispatch(ctx, queues, mutation)
+}
+
+// sourceBatch holds the mutations generated for one source, which are
+// sampled as a whole. The mutant schema they share is held once, in Schema,
+// rather than by every woven mutation.
+type sourceBatch struct {
+	Source    m.Source
+	Schema    []byte
+	Mutations []m.Mutation
+}
+
+// newSourceBatch moves the mutant schema of mutations into the batch, leaving
+// each woven mutation with its SchemaID only.
+func newSourceBatch(source m.Source, mutations []m.Mutation) sourceBatch {
+	batch := sourceBatch{Source: source, Mutations: make([]m.Mutation, len(mutations))}
+
+	for i, mutation := range mutations {
+		if len(mutation.SchemaCode) > 0 {
+			batch.Schema = mutation.SchemaCode
+			mutation.SchemaCode = nil
+		}
+
+		batch.Mutations[i] = mutation
+	}
+
+	return batch
+}
+
+// restoreSchema hands the batch's schema back to its woven mutations, which
+// share the one copy.
+func (b sourceBatch) restoreSchema() {
+	for i := range b.Mutations {
+		if b.Mutations[i].SchemaID != "" {
+			b.Mutations[i].SchemaCode = b.Schema
 		}
+	}
+}
 
+// removeSpill closes a spill and deletes its file.
+func removeSpill[T any](spill pkg.FileSpill[T]) {
+	if err := spill.Close(); err != nil {
+		slog.Warn("Failed to close filespill", "error", err, "path", spill.Path())
+	}
+
+	if err := os.Remove(spill.Path()); err != nil {
+		slog.Warn("Failed to remove filespill", "error", err, "path", spill.Path())
+	}
+}
+
+// sourceMutations collects the mutations of one source.
+func (w *workflow) sourceMutations(ctx context.Context, source m.Source, funcs FuncFilter) ([]m.Mutation, error) {
+	var mutations []m.Mutation
+
+	err := w.mutagen.StreamMutations(ctx, source, funcs, func(mutation m.Mutation) error {
+		mutations = append(mutations, mutation)
 		return nil
+	}, DefaultMutations...)
+
+	return mutations, err
+}
+
+// sourceKey names a source independently of where the project is checked out.
+func sourceKey(source m.Source) string {
+	if source.Origin == nil {
+		return ""
 	}
+
+	if source.Origin.ShortPath != "" {
+		return string(source.Origin.ShortPath)
+	}
+
+	return string(source.Origin.FullPath)
 }
 
 // sendOutcome delivers a precomputed outcome to the collector, respecting cancellation.
@@ -871,7 +1203,9 @@ func (w *workflow) consumeMutations(
 	ctx context.Context,
 	queue <-chan m.Mutation,
 	threadID int,
-	mutationTimeout time.Duration,
+	timeouts mutationTimeouts,
+	detectEquivalent bool,
+	flakyReruns int,
 	results chan<- mutationOutcome,
 ) func() error {
 	return func() error {
@@ -879,7 +1213,7 @@ func (w *workflow) consumeMutations(
 		defer ws.Close(ctx)
 
 		for mutation := range queue {
-			outcome := w.runMutation(ctx, ws, mutation, threadID, mutationTimeout)
+			outcome := w.runMutation(ctx, ws, mutation, threadID, timeouts.forMutation(mutation), detectEquivalent, flakyReruns)
 
 			select {
 			case results <- outcome:
@@ -894,13 +1228,18 @@ func (w *workflow) consumeMutations(
 
 // runMutation tests a single mutation under a per-mutation timeout and returns
 // its outcome. The timeout starts here (at execution time), not when the
-// mutation was queued.
+// mutation was queued. With detectEquivalent, a survivor that compiles to the
+// same code as the original is reported as equivalent. With flakyReruns, a
+// survivor or timeout is tested that many more times and reported as flaky when
+// an outcome differs.
 func (w *workflow) runMutation(
 	ctx context.Context,
 	ws Workspace,
 	mutation m.Mutation,
 	threadID int,
 	mutationTimeout time.Duration,
+	detectEquivalent bool,
+	flakyReruns int,
 ) mutationOutcome {
 	w.progress.DisplayStartingTestInfo(ctx, mutation, threadID)
 
@@ -917,7 +1256,64 @@ func (w *workflow) runMutation(
 		cancel()
 	}
 
-	return mutationOutcome{mutation: mutation, result: result, err: err}
+	if err == nil && detectEquivalent && getMutationStatus(result, mutation) == m.Survived {
+		result = checkEquivalent(ctx, ws, mutation, result, mutationTimeout)
+	}
+
+	outcome := mutationOutcome{mutation: mutation, result: result, err: err}
+	if err == nil && flakyReruns > 0 {
+		w.recheckMutation(ctx, ws, &outcome, flakyReruns, mutationTimeout)
+	}
+
+	return outcome
+}
+
+// checkEquivalent reports a surviving mutant as equivalent when it compiles to
+// the original code. The compile gets a timeout of its own, as the test run
+// may have used up most of the mutant's.
+func checkEquivalent(ctx context.Context, ws Workspace, mutation m.Mutation, result m.Result, timeout time.Duration) m.Result {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	equivalent, err := ws.Equivalent(ctx, mutation)
+	if err != nil {
+		slog.Warn("Failed to check mutation for equivalence", "mutationID", mutation.ID, "error", err)
+		return result
+	}
+
+	if equivalent {
+		return resultForStatus(mutation, m.Equivalent)
+	}
+
+	return result
+}
+
+// recheckMutation runs the tests of a mutant that survived or timed out again
+// and marks its outcome flaky when a run disagrees.
+func (w *workflow) recheckMutation(ctx context.Context, ws Workspace, outcome *mutationOutcome, runs int, timeout time.Duration) {
+	mutation := outcome.mutation
+
+	status := getMutationStatus(outcome.result, mutation)
+	if len(mutation.Source.Tests) == 0 || (status != m.Survived && status != m.Timeout) {
+		return
+	}
+
+	tests, flaky, err := ws.Recheck(ctx, mutation, runs, timeout)
+	if err != nil {
+		slog.Warn("Failed to run mutation again", "mutationID", mutation.ID, "error", err)
+		return
+	}
+
+	if flaky {
+		slog.Info("Mutation outcome is flaky", "mutationID", mutation.ID, "tests", tests)
+
+		outcome.result = resultForStatus(mutation, m.Flaky)
+		outcome.flakyTests = tests
+	}
 }
 
 func getMutationStatus(result m.Result, mutation m.Mutation) m.TestStatus {
@@ -0,0 +1,105 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"gooze.dev/pkg/gooze/internal/domain"
+	m "gooze.dev/pkg/gooze/internal/model"
+)
+
+func TestWorkflow_Test_BaselineFailureAborts(t *testing.T) {
+	ctx := context.Background()
+
+	source := m.Source{
+		Origin: &m.File{FullPath: "pkg/foo.go", ShortPath: "pkg/foo.go", Hash: "h"},
+		Tests:  []*m.File{{FullPath: "pkg/foo_test.go", Hash: "th"}},
+	}
+
+	mocks := newWorkflowMocks(ctx, t, []m.Source{source}, []m.Mutation{{ID: "m1", Source: source, Type: m.MutationArithmetic, Line: 10}})
+
+	mocks.orchestrator.EXPECT().Baseline(ctx, []m.Source{source}, false, 0).
+		Return(domain.Baseline{}, errors.New("tests fail without any mutation in pkg")).Once()
+
+	err := mocks.workflow().Test(ctx, domain.TestArgs{
+		EstimateArgs:    domain.EstimateArgs{Paths: []m.Path{"pkg"}},
+		Reports:         "reports",
+		Threads:         1,
+		TotalShardCount: 1,
+		Baseline:        true,
+	})
+	require.ErrorContains(t, err, "baseline: tests fail without any mutation in pkg")
+
+	// No mutant is tested and no report is written.
+	mocks.orchestrator.AssertNotCalled(t, "NewWorkspace")
+	mocks.reportStore.AssertNotCalled(t, "SaveSpillReports", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestWorkflow_Test_BaselineSetsTimeoutsAndCoverage(t *testing.T) {
+	ctx := context.Background()
+
+	source := m.Source{
+		Origin: &m.File{FullPath: "pkg/foo.go", ShortPath: "pkg/foo.go", Hash: "h"},
+		Tests:  []*m.File{{FullPath: "pkg/foo_test.go", Hash: "th"}},
+	}
+
+	covered := m.Mutation{ID: "covered", Source: source, Type: m.MutationArithmetic, Line: 10}
+	uncovered := m.Mutation{ID: "uncovered", Source: source, Type: m.MutationArithmetic, Line: 20}
+	profile := []byte("mode: set\nmod/pkg/foo.go:10.1,10.20 1 1\nmod/pkg/foo.go:20.1,20.20 1 0\n")
+
+	mocks := newWorkflowMocks(ctx, t, []m.Source{source}, []m.Mutation{covered, uncovered})
+
+	mocks.fsAdapter.EXPECT().WriteFile(ctx, m.Path("coverage.out"), profile, os.FileMode(0o600)).Return(nil).Once()
+	mocks.fsAdapter.EXPECT().ReadFile(ctx, m.Path("coverage.out")).Return(profile, nil).Once()
+
+	mocks.orchestrator.EXPECT().Baseline(ctx, []m.Source{source}, true, 0).Return(domain.Baseline{
+		Durations: map[m.Path]time.Duration{"pkg": 2 * time.Second},
+		Profile:   profile,
+	}, nil).Once()
+
+	var timeout time.Duration
+
+	mocks.workspace.EXPECT().
+		Run(mock.Anything, mock.MatchedBy(func(mut m.Mutation) bool { return mut.ID == "covered" })).
+		RunAndReturn(func(ctx context.Context, _ m.Mutation) (m.Result, error) {
+			deadline, ok := ctx.Deadline()
+			require.True(t, ok)
+
+			timeout = time.Until(deadline)
+
+			return m.Result{m.MutationArithmetic: {{MutationID: "covered", Status: m.Killed}}}, nil
+		}).
+		Once()
+
+	err := mocks.workflow().Test(ctx, domain.TestArgs{
+		EstimateArgs:     domain.EstimateArgs{Paths: []m.Path{"pkg"}},
+		Reports:          "reports",
+		Threads:          1,
+		TotalShardCount:  1,
+		MutationTimeout:  time.Hour,
+		Baseline:         true,
+		TimeoutFactor:    3,
+		TimeoutConstant:  10 * time.Second,
+		BaselineCoverage: "coverage.out",
+	})
+	require.NoError(t, err)
+
+	// 3 * 2s + 10s instead of the fixed hour.
+	assert.InDelta(t, float64(16*time.Second), float64(timeout), float64(time.Second))
+
+	statusByID := mocks.statusByID()
+
+	assert.Equal(t, m.Killed, statusByID["covered"])
+	assert.Equal(t, m.NotCovered, statusByID["uncovered"])
+
+	mocks.workspace.AssertExpectations(t)
+	mocks.orchestrator.AssertExpectations(t)
+	mocks.fsAdapter.AssertExpectations(t)
+}
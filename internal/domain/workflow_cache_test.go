@@ -0,0 +1,145 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"gooze.dev/pkg/gooze/internal/domain"
+	m "gooze.dev/pkg/gooze/internal/model"
+)
+
+func TestWorkflow_Test_ReusesResultsOfUnchangedScopes(t *testing.T) {
+	ctx := context.Background()
+
+	stored := m.Source{
+		Origin: &m.File{FullPath: "pkg/foo.go", ShortPath: "pkg/foo.go", Hash: "old"},
+		Tests:  []*m.File{{FullPath: "pkg/foo_test.go", Hash: "th"}},
+	}
+	source := m.Source{
+		Origin: &m.File{FullPath: "pkg/foo.go", ShortPath: "pkg/foo.go", Hash: "new"},
+		Tests:  []*m.File{{FullPath: "pkg/foo_test.go", Hash: "th"}},
+	}
+
+	unchanged := m.Mutation{ID: "unchanged", Source: source, Type: m.MutationArithmetic, Scope: "same"}
+	edited := m.Mutation{ID: "edited", Source: source, Type: m.MutationArithmetic, Scope: "new-scope"}
+
+	mocks := newWorkflowMocks(ctx, t, []m.Source{source}, []m.Mutation{unchanged, edited})
+
+	mocks.reportStore.EXPECT().CheckUpdates(ctx, m.Path("reports"), mock.Anything, mock.Anything).Return([]m.Source{source}, nil)
+	mocks.reportStore.EXPECT().LoadReports(ctx, m.Path("reports")).Return([]m.Report{
+		{Source: stored, Scope: "same", Result: m.Result{m.MutationArithmetic: {{MutationID: "unchanged", Status: m.Survived}}}},
+		{Source: stored, Scope: "old-scope", Result: m.Result{m.MutationArithmetic: {{MutationID: "edited", Status: m.Survived}}}},
+	}, nil)
+	mocks.reportStore.EXPECT().CleanReports(ctx, m.Path("reports"), []m.Source{source}).Return(nil).Once()
+
+	// Only the mutation in the edited declaration is re-tested.
+	mocks.workspace.EXPECT().
+		Run(mock.Anything, mock.MatchedBy(func(mut m.Mutation) bool { return mut.ID == "edited" })).
+		Return(m.Result{m.MutationArithmetic: {{MutationID: "edited", Status: m.Killed}}}, nil).
+		Once()
+
+	err := mocks.workflow().Test(ctx, domain.TestArgs{
+		EstimateArgs:    domain.EstimateArgs{Paths: []m.Path{"pkg"}, UseCache: true, Reports: "reports"},
+		Reports:         "reports",
+		Threads:         1,
+		TotalShardCount: 1,
+	})
+	require.NoError(t, err)
+
+	statusByID := mocks.statusByID()
+	scopeByID := map[string]string{}
+	for _, r := range mocks.saved {
+		for _, entries := range r.Result {
+			for _, e := range entries {
+				scopeByID[e.MutationID] = r.Scope
+			}
+		}
+	}
+
+	assert.Equal(t, m.Survived, statusByID["unchanged"], "result of the unchanged declaration should be reused")
+	assert.Equal(t, m.Killed, statusByID["edited"])
+	assert.Equal(t, "same", scopeByID["unchanged"])
+	assert.Equal(t, "new-scope", scopeByID["edited"])
+
+	mocks.workspace.AssertExpectations(t)
+	mocks.reportStore.AssertExpectations(t)
+}
+
+func TestWorkflow_Test_KeepsReportsOutsideTheFuncFilter(t *testing.T) {
+	ctx := context.Background()
+
+	source := m.Source{Origin: &m.File{FullPath: "pkg/foo.go", ShortPath: "pkg/foo.go", Hash: "h"}}
+	parse := m.Mutation{ID: "parse", Source: source, Type: m.MutationArithmetic, Line: 5, Scope: "s", Func: "Config.Parse"}
+
+	outside := m.Report{Source: source, Scope: "s", Line: 20, Func: "helper", Result: m.Result{m.MutationArithmetic: {{MutationID: "helper", Status: m.Survived}}}}
+	inside := m.Report{Source: source, Scope: "old", Line: 5, Func: "Config.Parse", Result: m.Result{m.MutationArithmetic: {{MutationID: "parse", Status: m.Survived}}}}
+
+	funcs := domain.FuncFilter{Match: []string{"^Parse$"}}
+
+	mocks := newWorkflowMocks(ctx, t, []m.Source{source}, []m.Mutation{parse})
+
+	// Stored reports are checked against the function filter.
+	scope := m.RunScope{Funcs: m.FuncSelection{Match: []string{"^Parse$"}}}
+	mocks.reportStore.EXPECT().CheckUpdates(ctx, m.Path("reports"), mock.Anything, scope).Return([]m.Source{source}, nil)
+	mocks.reportStore.EXPECT().LoadReports(ctx, m.Path("reports")).Return([]m.Report{outside, inside}, nil)
+	mocks.reportStore.EXPECT().CleanReports(ctx, m.Path("reports"), []m.Source{source}).Return(nil).Once()
+	// The report of the mutant outside the filter survives the clean.
+	mocks.reportStore.EXPECT().SaveReports(ctx, m.Path("reports"), []m.Report{outside}).Return(nil).Once()
+
+	mocks.workspace.EXPECT().
+		Run(mock.Anything, mock.MatchedBy(func(mut m.Mutation) bool { return mut.ID == "parse" })).
+		Return(m.Result{m.MutationArithmetic: {{MutationID: "parse", Status: m.Killed}}}, nil).
+		Once()
+
+	err := mocks.workflow().Test(ctx, domain.TestArgs{
+		EstimateArgs:    domain.EstimateArgs{Paths: []m.Path{"pkg"}, UseCache: true, Reports: "reports", Funcs: funcs},
+		Reports:         "reports",
+		Threads:         1,
+		TotalShardCount: 1,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, mocks.saved, 1)
+	assert.Equal(t, "Config.Parse", mocks.saved[0].Func)
+	assert.Equal(t, m.FuncSelection{Match: []string{"^Parse$"}}, mocks.saved[0].Funcs)
+
+	mocks.workspace.AssertExpectations(t)
+	mocks.reportStore.AssertExpectations(t)
+}
+
+func TestWorkflow_Test_FailedBaselineKeepsStoredReports(t *testing.T) {
+	ctx := context.Background()
+
+	source := m.Source{
+		Origin: &m.File{FullPath: "pkg/foo.go", ShortPath: "pkg/foo.go", Hash: "new"},
+		Tests:  []*m.File{{FullPath: "pkg/foo_test.go", Hash: "th"}},
+	}
+
+	mocks := newWorkflowMocks(ctx, t, []m.Source{source}, []m.Mutation{{ID: "m1", Source: source, Type: m.MutationArithmetic, Scope: "same"}})
+
+	mocks.reportStore.EXPECT().CheckUpdates(ctx, m.Path("reports"), mock.Anything, mock.Anything).Return([]m.Source{source}, nil)
+	mocks.reportStore.EXPECT().LoadReports(ctx, m.Path("reports")).Return([]m.Report{
+		{Source: source, Scope: "same", Result: m.Result{m.MutationArithmetic: {{MutationID: "m1", Status: m.Killed}}}},
+	}, nil)
+
+	mocks.orchestrator.EXPECT().Baseline(ctx, []m.Source{source}, false, 0).
+		Return(domain.Baseline{}, errors.New("tests fail without any mutation in pkg")).Once()
+
+	err := mocks.workflow().Test(ctx, domain.TestArgs{
+		EstimateArgs:    domain.EstimateArgs{Paths: []m.Path{"pkg"}, UseCache: true, Reports: "reports"},
+		Reports:         "reports",
+		Threads:         1,
+		TotalShardCount: 1,
+		Baseline:        true,
+	})
+	require.ErrorContains(t, err, "baseline")
+
+	// The previous run's reports survive a run that tested nothing.
+	mocks.reportStore.AssertNotCalled(t, "CleanReports", mock.Anything, mock.Anything, mock.Anything)
+	mocks.reportStore.AssertNotCalled(t, "SaveReports", mock.Anything, mock.Anything, mock.Anything)
+}
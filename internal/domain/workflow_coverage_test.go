@@ -27,7 +27,7 @@ func TestWorkflow_Test_CoverageGateSkipsUncovered(t *testing.T) {
 
 	source := m.Source{
 		Origin: &m.File{FullPath: "pkg/foo.go", ShortPath: "pkg/foo.go", Hash: "h"},
-		Test:   &m.File{FullPath: "pkg/foo_test.go", Hash: "th"},
+		Tests:  []*m.File{{FullPath: "pkg/foo_test.go", Hash: "th"}},
 	}
 
 	covered := m.Mutation{ID: "covered", Source: source, Type: m.MutationArithmetic, Line: 10}
@@ -48,7 +48,7 @@ func TestWorkflow_Test_CoverageGateSkipsUncovered(t *testing.T) {
 	mockFSAdapter.EXPECT().ReadFile(ctx, m.Path("cov.out")).Return(profile, nil)
 
 	mockMutagen.EXPECT().
-		StreamMutations(ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		StreamMutations(ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 		RunAndReturn(streamMutationsFn([]m.Mutation{covered, uncovered}))
 
 	// Only the covered mutation reaches a workspace.
@@ -93,3 +93,52 @@ func TestWorkflow_Test_CoverageGateSkipsUncovered(t *testing.T) {
 	mockWorkspace.AssertExpectations(t)
 	mockMutagen.AssertExpectations(t)
 }
+
+func TestWorkflow_Test_PerTestCoverageRunsReachingTests(t *testing.T) {
+	ctx := context.Background()
+
+	source := m.Source{
+		Origin: &m.File{FullPath: "pkg/foo.go", ShortPath: "pkg/foo.go", Hash: "h"},
+		Tests:  []*m.File{{FullPath: "pkg/foo_test.go", Hash: "th"}},
+	}
+
+	reached := m.Mutation{ID: "reached", Source: source, Type: m.MutationArithmetic, Line: 10}
+	unreached := m.Mutation{ID: "unreached", Source: source, Type: m.MutationArithmetic, Line: 20}
+
+	lineTen, err := domain.ParseCoverage([]byte("mode: set\nmod/pkg/foo.go:10.1,10.20 1 1\n"))
+	require.NoError(t, err)
+
+	lineThirty, err := domain.ParseCoverage([]byte("mode: set\nmod/pkg/foo.go:30.1,30.20 1 1\n"))
+	require.NoError(t, err)
+
+	mocks := newWorkflowMocks(ctx, t, []m.Source{source}, []m.Mutation{reached, unreached})
+
+	mocks.orchestrator.EXPECT().MapTestCoverage(ctx, []m.Source{source}).Return(domain.TestCoverage{
+		"pkg": {"TestA": lineTen, "TestB": lineThirty, "TestC": lineTen},
+	}, nil).Once()
+
+	// Only the reached mutation runs, and only with the tests reaching it.
+	mocks.workspace.EXPECT().
+		Run(mock.Anything, mock.MatchedBy(func(mut m.Mutation) bool {
+			return mut.ID == "reached" && assert.ObjectsAreEqual(map[m.Path][]string{"pkg": {"TestA", "TestC"}}, mut.CoveringTests)
+		})).
+		Return(m.Result{m.MutationArithmetic: {{MutationID: "reached", Status: m.Killed}}}, nil).
+		Once()
+
+	err = mocks.workflow().Test(ctx, domain.TestArgs{
+		EstimateArgs:    domain.EstimateArgs{Paths: []m.Path{"pkg"}},
+		Reports:         "reports",
+		Threads:         1,
+		TotalShardCount: 1,
+		PerTestCoverage: true,
+	})
+	require.NoError(t, err)
+
+	statusByID := mocks.statusByID()
+
+	assert.Equal(t, m.Killed, statusByID["reached"])
+	assert.Equal(t, m.NotCovered, statusByID["unreached"])
+
+	mocks.workspace.AssertExpectations(t)
+	mocks.orchestrator.AssertExpectations(t)
+}
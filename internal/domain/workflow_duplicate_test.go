@@ -0,0 +1,57 @@
+package domain_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"gooze.dev/pkg/gooze/internal/domain"
+	m "gooze.dev/pkg/gooze/internal/model"
+)
+
+func TestWorkflow_Test_RecordsDuplicatesWithoutRunningThem(t *testing.T) {
+	ctx := context.Background()
+
+	source := m.Source{Origin: &m.File{FullPath: "pkg/foo.go", ShortPath: "pkg/foo.go", Hash: "h"}}
+
+	mutation := m.Mutation{
+		ID:     "comparison",
+		Source: source,
+		Type:   m.MutationComparison,
+		Duplicates: []m.Mutation{
+			{ID: "branch", Source: source, Type: m.MutationBranch, DuplicateOf: "comparison"},
+		},
+	}
+
+	mocks := newWorkflowMocks(ctx, t, []m.Source{source}, []m.Mutation{mutation})
+
+	mocks.workspace.EXPECT().
+		Run(mock.Anything, mock.Anything).
+		Return(m.Result{m.MutationComparison: {{MutationID: "comparison", Status: m.Survived}}}, nil).
+		Once()
+
+	err := mocks.workflow().Test(ctx, domain.TestArgs{
+		EstimateArgs:    domain.EstimateArgs{Paths: []m.Path{"pkg"}},
+		Reports:         "reports",
+		Threads:         1,
+		TotalShardCount: 1,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, mocks.saved, 2)
+
+	for _, report := range mocks.saved {
+		if entries, ok := report.Result[m.MutationBranch]; ok {
+			assert.Equal(t, "comparison", report.DuplicateOf)
+			assert.Equal(t, "branch", entries[0].MutationID)
+			assert.Equal(t, m.Survived, entries[0].Status)
+		} else {
+			assert.Empty(t, report.DuplicateOf)
+		}
+	}
+
+	mocks.workspace.AssertExpectations(t)
+}
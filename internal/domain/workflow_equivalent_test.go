@@ -0,0 +1,100 @@
+package domain_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"gooze.dev/pkg/gooze/internal/domain"
+	m "gooze.dev/pkg/gooze/internal/model"
+)
+
+func TestWorkflow_Test_DetectEquivalentReclassifiesSurvivors(t *testing.T) {
+	ctx := context.Background()
+
+	source := m.Source{
+		Origin: &m.File{FullPath: "pkg/foo.go", ShortPath: "pkg/foo.go", Hash: "h"},
+		Tests:  []*m.File{{FullPath: "pkg/foo_test.go", Hash: "th"}},
+	}
+
+	killed := m.Mutation{ID: "killed", Source: source, Type: m.MutationArithmetic}
+	equivalent := m.Mutation{ID: "equivalent", Source: source, Type: m.MutationArithmetic}
+	survived := m.Mutation{ID: "survived", Source: source, Type: m.MutationArithmetic}
+
+	mocks := newWorkflowMocks(ctx, t, []m.Source{source}, []m.Mutation{killed, equivalent, survived})
+
+	mocks.workspace.EXPECT().Run(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, mut m.Mutation) (m.Result, error) {
+			status := m.Survived
+			if mut.ID == "killed" {
+				status = m.Killed
+			}
+
+			return m.Result{mut.Type: {{MutationID: mut.ID, Status: status}}}, nil
+		})
+
+	// Only survivors are compiled and compared.
+	mocks.workspace.EXPECT().
+		Equivalent(mock.Anything, mock.MatchedBy(func(mut m.Mutation) bool { return mut.ID == "equivalent" })).
+		Return(true, nil).
+		Once()
+	mocks.workspace.EXPECT().
+		Equivalent(mock.Anything, mock.MatchedBy(func(mut m.Mutation) bool { return mut.ID == "survived" })).
+		Return(false, nil).
+		Once()
+
+	err := mocks.workflow().Test(ctx, domain.TestArgs{
+		EstimateArgs:     domain.EstimateArgs{Paths: []m.Path{"pkg"}},
+		Reports:          "reports",
+		Threads:          1,
+		TotalShardCount:  1,
+		DetectEquivalent: true,
+	})
+	require.NoError(t, err)
+
+	statusByID := mocks.statusByID()
+
+	assert.Equal(t, m.Killed, statusByID["killed"])
+	assert.Equal(t, m.Equivalent, statusByID["equivalent"])
+	assert.Equal(t, m.Survived, statusByID["survived"])
+
+	mocks.workspace.AssertExpectations(t)
+}
+
+func TestWorkflow_Test_DetectEquivalentGetsItsOwnTimeout(t *testing.T) {
+	ctx := context.Background()
+
+	source := m.Source{Origin: &m.File{FullPath: "pkg/foo.go", ShortPath: "pkg/foo.go", Hash: "h"}}
+	mutation := m.Mutation{ID: "equivalent", Source: source, Type: m.MutationArithmetic}
+
+	mocks := newWorkflowMocks(ctx, t, []m.Source{source}, []m.Mutation{mutation})
+
+	// The test run uses up the mutant's whole timeout.
+	mocks.workspace.EXPECT().Run(mock.Anything, mock.Anything).
+		RunAndReturn(func(runCtx context.Context, mut m.Mutation) (m.Result, error) {
+			<-runCtx.Done()
+
+			return m.Result{mut.Type: {{MutationID: mut.ID, Status: m.Survived}}}, nil
+		})
+	mocks.workspace.EXPECT().Equivalent(mock.Anything, mock.Anything).
+		RunAndReturn(func(eqCtx context.Context, _ m.Mutation) (bool, error) {
+			return eqCtx.Err() == nil, eqCtx.Err()
+		}).
+		Once()
+
+	err := mocks.workflow().Test(ctx, domain.TestArgs{
+		EstimateArgs:     domain.EstimateArgs{Paths: []m.Path{"pkg"}},
+		Reports:          "reports",
+		Threads:          1,
+		TotalShardCount:  1,
+		MutationTimeout:  50 * time.Millisecond,
+		DetectEquivalent: true,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, m.Equivalent, mocks.statusByID()["equivalent"])
+}
@@ -0,0 +1,90 @@
+package domain_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"gooze.dev/pkg/gooze/internal/domain"
+	m "gooze.dev/pkg/gooze/internal/model"
+)
+
+func TestWorkflow_Test_FlakyRerunsReclassifySurvivorsAndTimeouts(t *testing.T) {
+	ctx := context.Background()
+
+	source := m.Source{
+		Origin: &m.File{FullPath: "pkg/foo.go", ShortPath: "pkg/foo.go", Hash: "h"},
+		Tests:  []*m.File{{FullPath: "pkg/foo_test.go", Hash: "th"}},
+	}
+
+	killed := m.Mutation{ID: "killed", Source: source, Type: m.MutationArithmetic}
+	flaky := m.Mutation{ID: "flaky", Source: source, Type: m.MutationArithmetic}
+	stable := m.Mutation{ID: "stable", Source: source, Type: m.MutationArithmetic}
+	timedOut := m.Mutation{ID: "timeout", Source: source, Type: m.MutationArithmetic}
+
+	mocks := newWorkflowMocks(ctx, t, []m.Source{source}, []m.Mutation{killed, flaky, stable, timedOut})
+
+	mocks.workspace.EXPECT().Run(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, mut m.Mutation) (m.Result, error) {
+			status := m.Survived
+
+			switch mut.ID {
+			case "killed":
+				status = m.Killed
+			case "timeout":
+				status = m.Timeout
+			}
+
+			return m.Result{mut.Type: {{MutationID: mut.ID, Status: status}}}, nil
+		})
+
+	// Killed mutants are never run again.
+	mocks.workspace.EXPECT().
+		Recheck(ctx, mock.MatchedBy(func(mut m.Mutation) bool { return mut.ID == "flaky" }), 2, time.Minute).
+		Return([]string{"TestRace"}, true, nil).
+		Once()
+	mocks.workspace.EXPECT().
+		Recheck(ctx, mock.MatchedBy(func(mut m.Mutation) bool { return mut.ID == "stable" }), 2, time.Minute).
+		Return(nil, false, nil).
+		Once()
+	mocks.workspace.EXPECT().
+		Recheck(ctx, mock.MatchedBy(func(mut m.Mutation) bool { return mut.ID == "timeout" }), 2, time.Minute).
+		Return([]string{"TestSlow"}, true, nil).
+		Once()
+
+	err := mocks.workflow().Test(ctx, domain.TestArgs{
+		EstimateArgs:    domain.EstimateArgs{Paths: []m.Path{"pkg"}},
+		Reports:         "reports",
+		Threads:         1,
+		TotalShardCount: 1,
+		MutationTimeout: time.Minute,
+		FlakyReruns:     2,
+	})
+	require.NoError(t, err)
+
+	statusByID := map[string]m.TestStatus{}
+	flakyTestsByID := map[string][]string{}
+
+	for _, r := range mocks.saved {
+		for _, entries := range r.Result {
+			for _, e := range entries {
+				statusByID[e.MutationID] = e.Status
+				flakyTestsByID[e.MutationID] = r.FlakyTests
+			}
+		}
+	}
+
+	assert.Equal(t, m.Killed, statusByID["killed"])
+	assert.Equal(t, m.Flaky, statusByID["flaky"])
+	assert.Equal(t, []string{"TestRace"}, flakyTestsByID["flaky"])
+	assert.Equal(t, m.Survived, statusByID["stable"])
+	assert.Empty(t, flakyTestsByID["stable"])
+	assert.Equal(t, m.Flaky, statusByID["timeout"])
+	assert.Equal(t, []string{"TestSlow"}, flakyTestsByID["timeout"])
+
+	mocks.workspace.AssertExpectations(t)
+}
@@ -0,0 +1,113 @@
+package domain_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	adaptermocks "gooze.dev/pkg/gooze/internal/adapter/mocks"
+	"gooze.dev/pkg/gooze/internal/domain"
+	domainmocks "gooze.dev/pkg/gooze/internal/domain/mocks"
+	m "gooze.dev/pkg/gooze/internal/model"
+)
+
+func TestWorkflow_Test_LimitsMutationToSelectedLines(t *testing.T) {
+	ctx := context.Background()
+
+	foo := m.Source{Origin: &m.File{FullPath: "/abs/pkg/foo.go", ShortPath: "pkg/foo.go", Hash: "new"}}
+	bar := m.Source{Origin: &m.File{FullPath: "/abs/pkg/bar.go", ShortPath: "pkg/bar.go", Hash: "bar"}}
+	stored := m.Source{Origin: &m.File{FullPath: "/abs/pkg/foo.go", ShortPath: "pkg/foo.go", Hash: "old"}}
+
+	selected := m.Mutation{ID: "selected", Source: foo, Type: m.MutationArithmetic, Line: 45, Scope: "s"}
+	unselected := m.Mutation{ID: "unselected", Source: foo, Type: m.MutationArithmetic, Line: 10, Scope: "s"}
+
+	outside := m.Report{Source: stored, Scope: "s", Line: 10, Result: m.Result{m.MutationArithmetic: {{MutationID: "unselected", Status: m.Survived}}}}
+	inside := m.Report{Source: stored, Scope: "old", Line: 45, Result: m.Result{m.MutationArithmetic: {{MutationID: "selected", Status: m.Survived}}}}
+
+	mocks := newWorkflowMocks(ctx, t, []m.Source{foo, bar}, nil)
+
+	// Stored reports are checked against the selected lines.
+	scope := m.RunScope{Lines: map[m.Path][]m.LineRange{"/abs/pkg/foo.go": {{Start: 40, End: 80}}}}
+	mocks.reportStore.EXPECT().CheckUpdates(ctx, m.Path("reports"), mock.Anything, scope).Return([]m.Source{foo, bar}, nil)
+	mocks.reportStore.EXPECT().LoadReports(ctx, m.Path("reports")).Return([]m.Report{outside, inside}, nil)
+	mocks.reportStore.EXPECT().CleanReports(ctx, m.Path("reports"), []m.Source{foo}).Return(nil).Once()
+	// The report of the mutant outside the selection survives the clean.
+	mocks.reportStore.EXPECT().SaveReports(ctx, m.Path("reports"), []m.Report{outside}).Return(nil).Once()
+
+	// bar.go is not selected, so its mutations are never generated.
+	mocks.mutagen.EXPECT().
+		StreamMutations(ctx, foo, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(streamMutationsFn([]m.Mutation{selected, unselected}))
+
+	mocks.workspace.EXPECT().
+		Run(mock.Anything, mock.MatchedBy(func(mut m.Mutation) bool { return mut.ID == "selected" })).
+		Return(m.Result{m.MutationArithmetic: {{MutationID: "selected", Status: m.Killed}}}, nil).
+		Once()
+
+	err := mocks.workflow().Test(ctx, domain.TestArgs{
+		EstimateArgs: domain.EstimateArgs{
+			Paths:    []m.Path{"./..."},
+			UseCache: true,
+			Reports:  "reports",
+			Lines:    domain.LineSelection{"/abs/pkg/foo.go": {{Start: 40, End: 80}}},
+		},
+		Reports:         "reports",
+		Threads:         1,
+		TotalShardCount: 1,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, mocks.saved, 1)
+	assert.Equal(t, 45, mocks.saved[0].Line)
+	assert.Equal(t, []m.LineRange{{Start: 40, End: 80}}, mocks.saved[0].Selection)
+
+	mocks.reporter.AssertCalled(t, "DisplayUpcomingTestsInfo", ctx, 1)
+	mocks.workspace.AssertExpectations(t)
+	mocks.reportStore.AssertExpectations(t)
+	mocks.mutagen.AssertExpectations(t)
+}
+
+func TestWorkflow_Merge_KeepsBaselineReportsOutsideTheSelection(t *testing.T) {
+	ctx := context.Background()
+
+	base := t.TempDir()
+	shardDir := filepath.Join(base, domain.ShardDirPrefix+"0")
+	require.NoError(t, os.MkdirAll(shardDir, 0o750))
+
+	mockReportStore := new(adaptermocks.MockReportStore)
+	mockReporter := new(domainmocks.MockReporter)
+
+	foo := m.Source{Origin: &m.File{FullPath: "/abs/pkg/foo.go", Hash: "foo"}}
+	bar := m.Source{Origin: &m.File{FullPath: "/abs/pkg/bar.go", Hash: "bar"}}
+
+	baseOutside := m.Report{Source: foo, Line: 10, Result: m.Result{m.MutationArithmetic: {{MutationID: "a", Status: m.Killed}}}}
+	baseInside := m.Report{Source: foo, Line: 45, Result: m.Result{m.MutationArithmetic: {{MutationID: "b", Status: m.Survived}}}}
+	baseOther := m.Report{Source: bar, Line: 45, Result: m.Result{m.MutationArithmetic: {{MutationID: "c", Status: m.Killed}}}}
+	shard := m.Report{
+		Source:    foo,
+		Line:      45,
+		Selection: []m.LineRange{{Start: 40, End: 80}},
+		Result:    m.Result{m.MutationArithmetic: {{MutationID: "b", Status: m.Killed}}},
+	}
+
+	mockReportStore.EXPECT().LoadReports(ctx, m.Path(base)).Return([]m.Report{baseOutside, baseInside, baseOther}, nil)
+	mockReportStore.EXPECT().LoadReports(ctx, m.Path(shardDir)).Return([]m.Report{shard}, nil)
+	mockReportStore.EXPECT().
+		CleanReports(ctx, m.Path(base), []m.Source{{Origin: &m.File{FullPath: "/abs/pkg/foo.go"}}}).
+		Return(nil).Once()
+	mockReportStore.EXPECT().SaveReports(ctx, m.Path(base), []m.Report{baseOutside, baseOther, shard}).Return(nil).Once()
+	mockReportStore.EXPECT().RegenerateIndex(ctx, m.Path(base)).Return(nil).Once()
+	mockReporter.EXPECT().DisplayMutationScore(ctx, 1.0).Return().Once()
+
+	wf := domain.NewWorkflow(nil, mockReportStore, mockReporter, nil, nil)
+
+	require.NoError(t, wf.Merge(ctx, domain.MergeArgs{Reports: m.Path(base)}))
+
+	mockReportStore.AssertExpectations(t)
+	mockReporter.AssertExpectations(t)
+}
@@ -61,9 +61,9 @@ func collectSpillReports(t *testing.T, reports pkg.FileSpill[m.Report]) []m.Repo
 
 // streamMutationsFn returns a RunAndReturn callback that streams the given
 // mutations through the StreamMutations fn argument. It is safe to invoke
-// multiple times (the Test flow streams twice: once to count, once to run).
-func streamMutationsFn(mutations []m.Mutation) func(context.Context, m.Source, func(m.Mutation) error, ...m.MutationType) error {
-	return func(_ context.Context, _ m.Source, fn func(m.Mutation) error, _ ...m.MutationType) error {
+// multiple times, once per source.
+func streamMutationsFn(mutations []m.Mutation) func(context.Context, m.Source, domain.FuncFilter, func(m.Mutation) error, ...m.MutationType) error {
+	return func(_ context.Context, _ m.Source, _ domain.FuncFilter, fn func(m.Mutation) error, _ ...m.MutationType) error {
 		for _, mut := range mutations {
 			if err := fn(mut); err != nil {
 				return err
@@ -89,7 +89,7 @@ func TestWorkflow_Test_Success(t *testing.T) {
 	sources := []m.Source{
 		{
 			Origin: &m.File{FullPath: "test.go", Hash: "hash1"},
-			Test:   &m.File{FullPath: "test_test.go", Hash: "test_hash1"},
+			Tests:  []*m.File{{FullPath: "test_test.go", Hash: "test_hash1"}},
 		},
 	}
 
@@ -106,7 +106,7 @@ func TestWorkflow_Test_Success(t *testing.T) {
 	mockReporter.EXPECT().DisplayCompletedTestInfo(ctx, mock.Anything, mock.Anything).Return().Once()
 	mockFSAdapter.EXPECT().Stream(ctx, mock.Anything).Return(streamSources(sources))
 	mockMutagen.EXPECT().
-		StreamMutations(ctx, mock.Anything, mock.Anything, domain.DefaultMutations[0], domain.DefaultMutations[1], domain.DefaultMutations[2], domain.DefaultMutations[3], domain.DefaultMutations[4], domain.DefaultMutations[5]).
+		StreamMutations(ctx, mock.Anything, mock.Anything, mock.Anything, domain.DefaultMutations[0], domain.DefaultMutations[1], domain.DefaultMutations[2], domain.DefaultMutations[3], domain.DefaultMutations[4], domain.DefaultMutations[5], domain.DefaultMutations[6]).
 		RunAndReturn(streamMutationsFn(mutations))
 	mockWorkspace.EXPECT().Run(mock.Anything, mock.Anything).Return(m.Result{}, nil)
 	mockWorkspace.EXPECT().Close(mock.Anything).Return().Maybe()
@@ -193,7 +193,7 @@ func TestWorkflow_Test_GenerateMutationsError(t *testing.T) {
 	mockReporter.EXPECT().DisplayConcurrencyInfo(ctx, mock.Anything, mock.Anything, mock.Anything).Return()
 	mockFSAdapter.EXPECT().Stream(ctx, mock.Anything).Return(streamSources(sources))
 	mockMutagen.EXPECT().
-		StreamMutations(ctx, mock.Anything, mock.Anything, domain.DefaultMutations[0], domain.DefaultMutations[1], domain.DefaultMutations[2], domain.DefaultMutations[3], domain.DefaultMutations[4], domain.DefaultMutations[5]).
+		StreamMutations(ctx, mock.Anything, mock.Anything, mock.Anything, domain.DefaultMutations[0], domain.DefaultMutations[1], domain.DefaultMutations[2], domain.DefaultMutations[3], domain.DefaultMutations[4], domain.DefaultMutations[5], domain.DefaultMutations[6]).
 		Return(testErr)
 	mockWorkspace := new(domainmocks.MockWorkspace)
 	mockWorkspace.EXPECT().Close(mock.Anything).Return().Maybe()
@@ -243,7 +243,7 @@ func TestWorkflow_Test_TestMutationError(t *testing.T) {
 	mockReporter.EXPECT().DisplayStartingTestInfo(ctx, mock.Anything, mock.Anything).Return().Once()
 	mockFSAdapter.EXPECT().Stream(ctx, mock.Anything).Return(streamSources(sources))
 	mockMutagen.EXPECT().
-		StreamMutations(ctx, mock.Anything, mock.Anything, domain.DefaultMutations[0], domain.DefaultMutations[1], domain.DefaultMutations[2], domain.DefaultMutations[3], domain.DefaultMutations[4], domain.DefaultMutations[5]).
+		StreamMutations(ctx, mock.Anything, mock.Anything, mock.Anything, domain.DefaultMutations[0], domain.DefaultMutations[1], domain.DefaultMutations[2], domain.DefaultMutations[3], domain.DefaultMutations[4], domain.DefaultMutations[5], domain.DefaultMutations[6]).
 		RunAndReturn(streamMutationsFn(mutations))
 	mockWorkspace.EXPECT().Run(mock.Anything, mock.Anything).Return(nil, testErr)
 	mockWorkspace.EXPECT().Close(mock.Anything).Return().Maybe()
@@ -296,7 +296,7 @@ func TestWorkflow_Test_SaveReportsError(t *testing.T) {
 	mockReporter.EXPECT().DisplayCompletedTestInfo(ctx, mock.Anything, mock.Anything).Return().Once()
 	mockFSAdapter.EXPECT().Stream(ctx, mock.Anything).Return(streamSources(sources))
 	mockMutagen.EXPECT().
-		StreamMutations(ctx, mock.Anything, mock.Anything, domain.DefaultMutations[0], domain.DefaultMutations[1], domain.DefaultMutations[2], domain.DefaultMutations[3], domain.DefaultMutations[4], domain.DefaultMutations[5]).
+		StreamMutations(ctx, mock.Anything, mock.Anything, mock.Anything, domain.DefaultMutations[0], domain.DefaultMutations[1], domain.DefaultMutations[2], domain.DefaultMutations[3], domain.DefaultMutations[4], domain.DefaultMutations[5], domain.DefaultMutations[6]).
 		RunAndReturn(streamMutationsFn(mutations))
 	mockWorkspace.EXPECT().Run(mock.Anything, mock.Anything).Return(m.Result{}, nil)
 	mockWorkspace.EXPECT().Close(mock.Anything).Return().Maybe()
@@ -348,7 +348,7 @@ func TestWorkflow_Test_NoMutations(t *testing.T) {
 	mockReporter.EXPECT().DisplayUpcomingTestsInfo(ctx, mock.Anything).Return()
 	mockFSAdapter.EXPECT().Stream(ctx, mock.Anything).Return(streamSources(sources))
 	mockMutagen.EXPECT().
-		StreamMutations(ctx, mock.Anything, mock.Anything, domain.DefaultMutations[0], domain.DefaultMutations[1], domain.DefaultMutations[2], domain.DefaultMutations[3], domain.DefaultMutations[4], domain.DefaultMutations[5]).
+		StreamMutations(ctx, mock.Anything, mock.Anything, mock.Anything, domain.DefaultMutations[0], domain.DefaultMutations[1], domain.DefaultMutations[2], domain.DefaultMutations[3], domain.DefaultMutations[4], domain.DefaultMutations[5], domain.DefaultMutations[6]).
 		RunAndReturn(streamMutationsFn([]m.Mutation{}))
 	mockWorkspace.EXPECT().Close(mock.Anything).Return().Maybe()
 	mockOrchestrator.EXPECT().NewWorkspace().Return(mockWorkspace).Maybe()
@@ -410,7 +410,7 @@ func TestWorkflow_Test_MultipleThreads(t *testing.T) {
 	mockReporter.EXPECT().DisplayCompletedTestInfo(ctx, mock.Anything, mock.Anything).Return().Times(3)
 	mockFSAdapter.EXPECT().Stream(ctx, mock.Anything).Return(streamSources(sources))
 	mockMutagen.EXPECT().
-		StreamMutations(ctx, mock.Anything, mock.Anything, domain.DefaultMutations[0], domain.DefaultMutations[1], domain.DefaultMutations[2], domain.DefaultMutations[3], domain.DefaultMutations[4], domain.DefaultMutations[5]).
+		StreamMutations(ctx, mock.Anything, mock.Anything, mock.Anything, domain.DefaultMutations[0], domain.DefaultMutations[1], domain.DefaultMutations[2], domain.DefaultMutations[3], domain.DefaultMutations[4], domain.DefaultMutations[5], domain.DefaultMutations[6]).
 		RunAndReturn(streamMutationsFn(mutations))
 	mockWorkspace.EXPECT().Run(mock.Anything, mock.Anything).Return(m.Result{}, nil).Times(3)
 	mockWorkspace.EXPECT().Close(mock.Anything).Return().Maybe()
@@ -476,7 +476,7 @@ func TestWorkflow_Test_WithSharding(t *testing.T) {
 	mockReporter.EXPECT().DisplayCompletedTestInfo(ctx, mock.Anything, mock.Anything).Return().Maybe()
 	mockFSAdapter.EXPECT().Stream(ctx, mock.Anything).Return(streamSources([]m.Source{source}))
 	mockMutagen.EXPECT().
-		StreamMutations(ctx, mock.Anything, mock.Anything, domain.DefaultMutations[0], domain.DefaultMutations[1], domain.DefaultMutations[2], domain.DefaultMutations[3], domain.DefaultMutations[4], domain.DefaultMutations[5]).
+		StreamMutations(ctx, mock.Anything, mock.Anything, mock.Anything, domain.DefaultMutations[0], domain.DefaultMutations[1], domain.DefaultMutations[2], domain.DefaultMutations[3], domain.DefaultMutations[4], domain.DefaultMutations[5], domain.DefaultMutations[6]).
 		RunAndReturn(streamMutationsFn(mutations))
 	// With hash-based sharding, the number of mutations in shard 0 may vary
 	mockWorkspace.EXPECT().Run(mock.Anything, mock.Anything).Return(m.Result{}, nil).Maybe()
@@ -536,7 +536,7 @@ func TestWorkflow_TestThreadsZeroDoesNotPanic(t *testing.T) {
 	mockReporter.EXPECT().DisplayCompletedTestInfo(ctx, mutations[0], mock.Anything).Return().Once()
 	mockFSAdapter.EXPECT().Stream(ctx, mock.Anything).Return(streamSources([]m.Source{source}))
 	mockMutagen.EXPECT().
-		StreamMutations(ctx, mock.Anything, mock.Anything, domain.DefaultMutations[0], domain.DefaultMutations[1], domain.DefaultMutations[2], domain.DefaultMutations[3], domain.DefaultMutations[4], domain.DefaultMutations[5]).
+		StreamMutations(ctx, mock.Anything, mock.Anything, mock.Anything, domain.DefaultMutations[0], domain.DefaultMutations[1], domain.DefaultMutations[2], domain.DefaultMutations[3], domain.DefaultMutations[4], domain.DefaultMutations[5], domain.DefaultMutations[6]).
 		RunAndReturn(streamMutationsFn(mutations))
 	mockWorkspace.EXPECT().Run(mock.Anything, mutations[0]).Return(m.Result{}, nil)
 	mockWorkspace.EXPECT().Close(mock.Anything).Return().Maybe()
@@ -590,7 +590,7 @@ func TestWorkflow_TestThreadIDWithinBounds(t *testing.T) {
 	mockReporter.EXPECT().DisplayCompletedTestInfo(ctx, mock.Anything, mock.Anything).Return().Times(2)
 	mockFSAdapter.EXPECT().Stream(ctx, mock.Anything).Return(streamSources([]m.Source{source}))
 	mockMutagen.EXPECT().
-		StreamMutations(ctx, mock.Anything, mock.Anything, domain.DefaultMutations[0], domain.DefaultMutations[1], domain.DefaultMutations[2], domain.DefaultMutations[3], domain.DefaultMutations[4], domain.DefaultMutations[5]).
+		StreamMutations(ctx, mock.Anything, mock.Anything, mock.Anything, domain.DefaultMutations[0], domain.DefaultMutations[1], domain.DefaultMutations[2], domain.DefaultMutations[3], domain.DefaultMutations[4], domain.DefaultMutations[5], domain.DefaultMutations[6]).
 		RunAndReturn(streamMutationsFn(mutations))
 	mockWorkspace.EXPECT().Run(mock.Anything, mock.Anything).Return(m.Result{}, nil).Times(2)
 	mockWorkspace.EXPECT().Close(mock.Anything).Return().Maybe()
@@ -657,7 +657,7 @@ func TestWorkflow_TestThreadIDIsUniqueForThreadsTwo(t *testing.T) {
 	mockReporter.EXPECT().DisplayCompletedTestInfo(ctx, mock.Anything, mock.Anything).Return().Times(2)
 	mockFSAdapter.EXPECT().Stream(ctx, mock.Anything).Return(streamSources([]m.Source{source}))
 	mockMutagen.EXPECT().
-		StreamMutations(ctx, mock.Anything, mock.Anything, domain.DefaultMutations[0], domain.DefaultMutations[1], domain.DefaultMutations[2], domain.DefaultMutations[3], domain.DefaultMutations[4], domain.DefaultMutations[5]).
+		StreamMutations(ctx, mock.Anything, mock.Anything, mock.Anything, domain.DefaultMutations[0], domain.DefaultMutations[1], domain.DefaultMutations[2], domain.DefaultMutations[3], domain.DefaultMutations[4], domain.DefaultMutations[5], domain.DefaultMutations[6]).
 		RunAndReturn(streamMutationsFn(mutations))
 	mockWorkspace.EXPECT().Run(mock.Anything, mock.Anything).Run(func(_ context.Context, _ m.Mutation) {
 		// Signal arrival, then wait until both Runs have arrived so each
@@ -709,7 +709,7 @@ func TestWorkflow_TestWithSkippedMutation(t *testing.T) {
 	sources := []m.Source{
 		{
 			Origin: &m.File{FullPath: "test.go", Hash: "hash1"},
-			Test:   &m.File{FullPath: "test_test.go", Hash: "test_hash1"},
+			Tests:  []*m.File{{FullPath: "test_test.go", Hash: "test_hash1"}},
 		},
 	}
 
@@ -737,10 +737,11 @@ func TestWorkflow_TestWithSkippedMutation(t *testing.T) {
 	mockReporter.EXPECT().DisplayUpcomingTestsInfo(ctx, 1).Return()
 	mockReporter.EXPECT().DisplayStartingTestInfo(ctx, mutations[0], 0).Return().Once()
 	mockReporter.EXPECT().DisplayCompletedTestInfo(ctx, mutations[0], skippedResult).Return().Once()
+	mockReporter.EXPECT().DisplayScoreInterval(ctx, 0.0, 1.0).Return().Once()
 
 	mockFSAdapter.EXPECT().Stream(ctx, mock.Anything).Return(streamSources(sources))
 	mockMutagen.EXPECT().
-		StreamMutations(ctx, mock.Anything, mock.Anything, domain.DefaultMutations[0], domain.DefaultMutations[1], domain.DefaultMutations[2], domain.DefaultMutations[3], domain.DefaultMutations[4], domain.DefaultMutations[5]).
+		StreamMutations(ctx, mock.Anything, mock.Anything, mock.Anything, domain.DefaultMutations[0], domain.DefaultMutations[1], domain.DefaultMutations[2], domain.DefaultMutations[3], domain.DefaultMutations[4], domain.DefaultMutations[5], domain.DefaultMutations[6]).
 		RunAndReturn(streamMutationsFn(mutations))
 	mockWorkspace.EXPECT().Run(mock.Anything, mutations[0]).Return(skippedResult, nil)
 	mockWorkspace.EXPECT().Close(mock.Anything).Return().Maybe()
@@ -787,7 +788,7 @@ func TestWorkflow_TestMutationIDExactMatchDoesNotUseHigherID(t *testing.T) {
 	sources := []m.Source{
 		{
 			Origin: &m.File{FullPath: "test.go", Hash: "hash1"},
-			Test:   &m.File{FullPath: "test_test.go", Hash: "test_hash1"},
+			Tests:  []*m.File{{FullPath: "test_test.go", Hash: "test_hash1"}},
 		},
 	}
 
@@ -821,7 +822,7 @@ func TestWorkflow_TestMutationIDExactMatchDoesNotUseHigherID(t *testing.T) {
 
 	mockFSAdapter.EXPECT().Stream(ctx, mock.Anything).Return(streamSources(sources))
 	mockMutagen.EXPECT().
-		StreamMutations(ctx, mock.Anything, mock.Anything, domain.DefaultMutations[0], domain.DefaultMutations[1], domain.DefaultMutations[2], domain.DefaultMutations[3], domain.DefaultMutations[4], domain.DefaultMutations[5]).
+		StreamMutations(ctx, mock.Anything, mock.Anything, mock.Anything, domain.DefaultMutations[0], domain.DefaultMutations[1], domain.DefaultMutations[2], domain.DefaultMutations[3], domain.DefaultMutations[4], domain.DefaultMutations[5], domain.DefaultMutations[6]).
 		RunAndReturn(streamMutationsFn(mutations))
 	mockWorkspace.EXPECT().Run(mock.Anything, mutations[0]).Return(result, nil)
 	mockWorkspace.EXPECT().Close(mock.Anything).Return().Maybe()
@@ -868,7 +869,7 @@ func TestWorkflow_TestEmptyResultEntriesReturnsError(t *testing.T) {
 	sources := []m.Source{
 		{
 			Origin: &m.File{FullPath: "test.go", Hash: "hash1"},
-			Test:   &m.File{FullPath: "test_test.go", Hash: "test_hash1"},
+			Tests:  []*m.File{{FullPath: "test_test.go", Hash: "test_hash1"}},
 		},
 	}
 
@@ -899,7 +900,7 @@ func TestWorkflow_TestEmptyResultEntriesReturnsError(t *testing.T) {
 
 	mockFSAdapter.EXPECT().Stream(ctx, mock.Anything).Return(streamSources(sources))
 	mockMutagen.EXPECT().
-		StreamMutations(ctx, mock.Anything, mock.Anything, domain.DefaultMutations[0], domain.DefaultMutations[1], domain.DefaultMutations[2], domain.DefaultMutations[3], domain.DefaultMutations[4], domain.DefaultMutations[5]).
+		StreamMutations(ctx, mock.Anything, mock.Anything, mock.Anything, domain.DefaultMutations[0], domain.DefaultMutations[1], domain.DefaultMutations[2], domain.DefaultMutations[3], domain.DefaultMutations[4], domain.DefaultMutations[5], domain.DefaultMutations[6]).
 		RunAndReturn(streamMutationsFn(mutations))
 	mockWorkspace.EXPECT().Run(mock.Anything, mutations[0]).Return(result, nil)
 	mockWorkspace.EXPECT().Close(mock.Anything).Return().Maybe()
@@ -964,12 +965,13 @@ func TestWorkflow_Test_MultipleSources(t *testing.T) {
 	mockReporter.EXPECT().DisplayStartingTestInfo(ctx, mock.Anything, mock.Anything).Return().Times(3)
 	mockReporter.EXPECT().DisplayCompletedTestInfo(ctx, mock.Anything, mock.Anything).Return().Times(3)
 	mockFSAdapter.EXPECT().Stream(ctx, mock.Anything).Return(streamSources([]m.Source{source1, source2}))
+	// Each source's mutations are generated once, to be counted and tested.
 	mockMutagen.EXPECT().
-		StreamMutations(ctx, source1, mock.Anything, domain.DefaultMutations[0], domain.DefaultMutations[1], domain.DefaultMutations[2], domain.DefaultMutations[3], domain.DefaultMutations[4], domain.DefaultMutations[5]).
-		RunAndReturn(streamMutationsFn(mutations1))
+		StreamMutations(ctx, source1, mock.Anything, mock.Anything, domain.DefaultMutations[0], domain.DefaultMutations[1], domain.DefaultMutations[2], domain.DefaultMutations[3], domain.DefaultMutations[4], domain.DefaultMutations[5], domain.DefaultMutations[6]).
+		RunAndReturn(streamMutationsFn(mutations1)).Once()
 	mockMutagen.EXPECT().
-		StreamMutations(ctx, source2, mock.Anything, domain.DefaultMutations[0], domain.DefaultMutations[1], domain.DefaultMutations[2], domain.DefaultMutations[3], domain.DefaultMutations[4], domain.DefaultMutations[5]).
-		RunAndReturn(streamMutationsFn(mutations2))
+		StreamMutations(ctx, source2, mock.Anything, mock.Anything, domain.DefaultMutations[0], domain.DefaultMutations[1], domain.DefaultMutations[2], domain.DefaultMutations[3], domain.DefaultMutations[4], domain.DefaultMutations[5], domain.DefaultMutations[6]).
+		RunAndReturn(streamMutationsFn(mutations2)).Once()
 	mockWorkspace.EXPECT().Run(mock.Anything, mock.Anything).Return(m.Result{}, nil).Times(3)
 	mockWorkspace.EXPECT().Close(mock.Anything).Return().Maybe()
 	mockOrchestrator.EXPECT().NewWorkspace().Return(mockWorkspace).Maybe()
@@ -1033,7 +1035,7 @@ func TestWorkflow_TestWithSurvivedMutation(t *testing.T) {
 	sources := []m.Source{
 		{
 			Origin: &m.File{FullPath: "test.go", Hash: "hash1"},
-			Test:   &m.File{FullPath: "test_test.go", Hash: "test_hash1"},
+			Tests:  []*m.File{{FullPath: "test_test.go", Hash: "test_hash1"}},
 		},
 	}
 
@@ -1065,7 +1067,7 @@ func TestWorkflow_TestWithSurvivedMutation(t *testing.T) {
 
 	mockFSAdapter.EXPECT().Stream(ctx, mock.Anything).Return(streamSources(sources))
 	mockMutagen.EXPECT().
-		StreamMutations(ctx, mock.Anything, mock.Anything, domain.DefaultMutations[0], domain.DefaultMutations[1], domain.DefaultMutations[2], domain.DefaultMutations[3], domain.DefaultMutations[4], domain.DefaultMutations[5]).
+		StreamMutations(ctx, mock.Anything, mock.Anything, mock.Anything, domain.DefaultMutations[0], domain.DefaultMutations[1], domain.DefaultMutations[2], domain.DefaultMutations[3], domain.DefaultMutations[4], domain.DefaultMutations[5], domain.DefaultMutations[6]).
 		RunAndReturn(streamMutationsFn(mutations))
 	mockWorkspace.EXPECT().Run(mock.Anything, mutations[0]).Return(survivedResult, nil)
 	mockWorkspace.EXPECT().Close(mock.Anything).Return().Maybe()
@@ -1119,7 +1121,7 @@ func TestWorkflow_TestWithKilledMutation(t *testing.T) {
 	sources := []m.Source{
 		{
 			Origin: &m.File{FullPath: "test.go", Hash: "hash1"},
-			Test:   &m.File{FullPath: "test_test.go", Hash: "test_hash1"},
+			Tests:  []*m.File{{FullPath: "test_test.go", Hash: "test_hash1"}},
 		},
 	}
 
@@ -1151,7 +1153,7 @@ func TestWorkflow_TestWithKilledMutation(t *testing.T) {
 
 	mockFSAdapter.EXPECT().Stream(ctx, mock.Anything).Return(streamSources(sources))
 	mockMutagen.EXPECT().
-		StreamMutations(ctx, mock.Anything, mock.Anything, domain.DefaultMutations[0], domain.DefaultMutations[1], domain.DefaultMutations[2], domain.DefaultMutations[3], domain.DefaultMutations[4], domain.DefaultMutations[5]).
+		StreamMutations(ctx, mock.Anything, mock.Anything, mock.Anything, domain.DefaultMutations[0], domain.DefaultMutations[1], domain.DefaultMutations[2], domain.DefaultMutations[3], domain.DefaultMutations[4], domain.DefaultMutations[5], domain.DefaultMutations[6]).
 		RunAndReturn(streamMutationsFn(mutations))
 	mockWorkspace.EXPECT().Run(mock.Anything, mutations[0]).Return(killedResult, nil)
 	mockWorkspace.EXPECT().Close(mock.Anything).Return().Maybe()
@@ -1209,14 +1211,15 @@ func TestWorkflow_Estimate_Success(t *testing.T) {
 
 	mockReporter.EXPECT().StartEstimate(ctx).Return(nil).Once()
 	mockReporter.EXPECT().DisplayEstimation(ctx, mock.MatchedBy(func(e domain.Estimation) bool {
-		return e.Total == 1
+		return e.Total == 1 && e.Skipped["generated"] == 2
 	}), nil).Return(nil).Once()
 	mockReporter.EXPECT().Wait(ctx).Return().Once()
 	mockReporter.EXPECT().Close(ctx).Return().Once()
 
 	mockFSAdapter.EXPECT().Stream(ctx, mock.Anything).Return(streamSources(sources))
+	mockFSAdapter.EXPECT().SkippedFiles(ctx).Return(map[string]int{"generated": 2}).Once()
 	mockMutagen.EXPECT().
-		StreamMutations(ctx, mock.Anything, mock.Anything, domain.DefaultMutations[0], domain.DefaultMutations[1], domain.DefaultMutations[2], domain.DefaultMutations[3], domain.DefaultMutations[4], domain.DefaultMutations[5]).
+		StreamMutations(ctx, mock.Anything, mock.Anything, mock.Anything, domain.DefaultMutations[0], domain.DefaultMutations[1], domain.DefaultMutations[2], domain.DefaultMutations[3], domain.DefaultMutations[4], domain.DefaultMutations[5], domain.DefaultMutations[6]).
 		RunAndReturn(streamMutationsFn(mutations))
 
 	wf := domain.NewWorkflow(mockFSAdapter, mockReportStore, mockReporter, mockOrchestrator, mockMutagen)
@@ -1305,8 +1308,9 @@ func TestWorkflow_Estimate_DisplayError(t *testing.T) {
 	mockReporter.EXPECT().Close(ctx).Return().Once()
 
 	mockFSAdapter.EXPECT().Stream(ctx, mock.Anything).Return(streamSources(sources))
+	mockFSAdapter.EXPECT().SkippedFiles(ctx).Return(nil).Once()
 	mockMutagen.EXPECT().
-		StreamMutations(ctx, mock.Anything, mock.Anything, domain.DefaultMutations[0], domain.DefaultMutations[1], domain.DefaultMutations[2], domain.DefaultMutations[3], domain.DefaultMutations[4], domain.DefaultMutations[5]).
+		StreamMutations(ctx, mock.Anything, mock.Anything, mock.Anything, domain.DefaultMutations[0], domain.DefaultMutations[1], domain.DefaultMutations[2], domain.DefaultMutations[3], domain.DefaultMutations[4], domain.DefaultMutations[5], domain.DefaultMutations[6]).
 		RunAndReturn(streamMutationsFn(mutations))
 
 	wf := domain.NewWorkflow(mockFSAdapter, mockReportStore, mockReporter, mockOrchestrator, mockMutagen)
@@ -1352,7 +1356,7 @@ func TestWorkflow_TestThreadIDStartsAtZero(t *testing.T) {
 	mockReporter.EXPECT().DisplayCompletedTestInfo(ctx, mutations[0], mock.Anything).Return().Once()
 	mockFSAdapter.EXPECT().Stream(ctx, mock.Anything).Return(streamSources([]m.Source{source}))
 	mockMutagen.EXPECT().
-		StreamMutations(ctx, mock.Anything, mock.Anything, domain.DefaultMutations[0], domain.DefaultMutations[1], domain.DefaultMutations[2], domain.DefaultMutations[3], domain.DefaultMutations[4], domain.DefaultMutations[5]).
+		StreamMutations(ctx, mock.Anything, mock.Anything, mock.Anything, domain.DefaultMutations[0], domain.DefaultMutations[1], domain.DefaultMutations[2], domain.DefaultMutations[3], domain.DefaultMutations[4], domain.DefaultMutations[5], domain.DefaultMutations[6]).
 		RunAndReturn(streamMutationsFn(mutations))
 	mockWorkspace.EXPECT().Run(mock.Anything, mutations[0]).Return(m.Result{}, nil)
 	mockWorkspace.EXPECT().Close(mock.Anything).Return().Maybe()
@@ -1392,7 +1396,7 @@ func TestWorkflow_TestExactMutationIDMatch(t *testing.T) {
 	sources := []m.Source{
 		{
 			Origin: &m.File{FullPath: "test.go", Hash: "hash1"},
-			Test:   &m.File{FullPath: "test_test.go", Hash: "test_hash1"},
+			Tests:  []*m.File{{FullPath: "test_test.go", Hash: "test_hash1"}},
 		},
 	}
 
@@ -1426,7 +1430,7 @@ func TestWorkflow_TestExactMutationIDMatch(t *testing.T) {
 
 	mockFSAdapter.EXPECT().Stream(ctx, mock.Anything).Return(streamSources(sources))
 	mockMutagen.EXPECT().
-		StreamMutations(ctx, mock.Anything, mock.Anything, domain.DefaultMutations[0], domain.DefaultMutations[1], domain.DefaultMutations[2], domain.DefaultMutations[3], domain.DefaultMutations[4], domain.DefaultMutations[5]).
+		StreamMutations(ctx, mock.Anything, mock.Anything, mock.Anything, domain.DefaultMutations[0], domain.DefaultMutations[1], domain.DefaultMutations[2], domain.DefaultMutations[3], domain.DefaultMutations[4], domain.DefaultMutations[5], domain.DefaultMutations[6]).
 		RunAndReturn(streamMutationsFn(mutations))
 	mockWorkspace.EXPECT().Run(mock.Anything, mutations[0]).Return(result, nil)
 	mockWorkspace.EXPECT().Close(mock.Anything).Return().Maybe()
@@ -1455,3 +1459,166 @@ func TestWorkflow_TestExactMutationIDMatch(t *testing.T) {
 	// Assert
 	assert.NoError(t, err)
 }
+
+func TestWorkflow_TestSamplingSkipsUnselectedMutations(t *testing.T) {
+	ctx := context.Background()
+
+	source := m.Source{
+		Origin: &m.File{FullPath: "test.go", ShortPath: "test.go", Hash: "hash1"},
+		Tests:  []*m.File{{FullPath: "test_test.go", Hash: "test_hash1"}},
+	}
+
+	mutations := []m.Mutation{
+		{ID: "hash-0", Source: source, Type: m.MutationArithmetic},
+		{ID: "hash-1", Source: source, Type: m.MutationArithmetic},
+		{ID: "hash-2", Source: source, Type: m.MutationArithmetic},
+	}
+
+	mocks := newWorkflowMocks(ctx, t, []m.Source{source}, mutations)
+
+	mocks.reporter.EXPECT().DisplayScoreInterval(ctx, mock.Anything, mock.Anything).Return().Once()
+	mocks.workspace.EXPECT().Run(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, mutation m.Mutation) (m.Result, error) {
+		return m.Result{mutation.Type: {{MutationID: mutation.ID, Status: m.Killed}}}, nil
+	}).Once()
+
+	err := mocks.workflow().Test(ctx, domain.TestArgs{
+		Reports:         "reports.json",
+		Threads:         1,
+		TotalShardCount: 1,
+		Sampling:        domain.Sampling{MaxPerFile: 1, Seed: 42},
+	})
+	require.NoError(t, err)
+
+	var statuses []m.TestStatus
+	for _, status := range mocks.statusByID() {
+		statuses = append(statuses, status)
+	}
+
+	assert.ElementsMatch(t, []m.TestStatus{m.Killed, m.Skipped, m.Skipped}, statuses)
+	mocks.reporter.AssertCalled(t, "DisplayMutationScore", ctx, 1.0)
+	mocks.workspace.AssertExpectations(t)
+}
+
+func TestWorkflow_TestSamplingKeepsCachedResults(t *testing.T) {
+	ctx := context.Background()
+
+	source := m.Source{
+		Origin: &m.File{FullPath: "test.go", ShortPath: "test.go", Hash: "new"},
+		Tests:  []*m.File{{FullPath: "test_test.go", ShortPath: "test_test.go", Hash: "th"}},
+	}
+	stored := m.Source{
+		Origin: &m.File{FullPath: "test.go", ShortPath: "test.go", Hash: "old"},
+		Tests:  source.Tests,
+	}
+
+	cached := m.Mutation{ID: "cached", Source: source, Type: m.MutationArithmetic, Scope: "s"}
+	untested := m.Mutation{ID: "untested", Source: source, Type: m.MutationArithmetic, Scope: "s"}
+
+	mocks := newWorkflowMocks(ctx, t, []m.Source{source}, []m.Mutation{cached, untested})
+
+	mocks.reportStore.EXPECT().CheckUpdates(ctx, m.Path("reports"), mock.Anything, mock.Anything).Return([]m.Source{source}, nil)
+	mocks.reportStore.EXPECT().LoadReports(ctx, m.Path("reports")).Return([]m.Report{
+		{Source: stored, Scope: "s", Result: m.Result{m.MutationArithmetic: {{MutationID: "cached", Status: m.Survived}}}},
+	}, nil)
+	mocks.reportStore.EXPECT().CleanReports(ctx, m.Path("reports"), []m.Source{source}).Return(nil).Once()
+
+	// The cached mutant takes no share of the sample, so the other one runs.
+	mocks.workspace.EXPECT().
+		Run(mock.Anything, mock.MatchedBy(func(mut m.Mutation) bool { return mut.ID == "untested" })).
+		Return(m.Result{m.MutationArithmetic: {{MutationID: "untested", Status: m.Killed}}}, nil).
+		Once()
+
+	err := mocks.workflow().Test(ctx, domain.TestArgs{
+		EstimateArgs:    domain.EstimateArgs{UseCache: true, Reports: "reports"},
+		Reports:         "reports",
+		Threads:         1,
+		TotalShardCount: 1,
+		Sampling:        domain.Sampling{MaxPerFile: 1, Seed: 42},
+	})
+	require.NoError(t, err)
+
+	statusByID := mocks.statusByID()
+
+	assert.Equal(t, m.Survived, statusByID["cached"])
+	assert.Equal(t, m.Killed, statusByID["untested"])
+	mocks.workspace.AssertExpectations(t)
+}
+
+// workflowMocks are the collaborators of a workflow under test.
+type workflowMocks struct {
+	fsAdapter    *adaptermocks.MockSourceFSAdapter
+	reportStore  *adaptermocks.MockReportStore
+	reporter     *domainmocks.MockReporter
+	orchestrator *domainmocks.MockOrchestrator
+	workspace    *domainmocks.MockWorkspace
+	mutagen      *domainmocks.MockMutagen
+	// saved holds the reports the run saved.
+	saved []m.Report
+}
+
+// newWorkflowMocks returns the mocks of a Test run that scans sources and, when
+// mutations is not nil, generates them for every source. The reporter accepts
+// the calls of any run, workspaces are handed out on demand and the saved
+// reports are collected, so tests only add the expectations they are about.
+func newWorkflowMocks(ctx context.Context, t *testing.T, sources []m.Source, mutations []m.Mutation) *workflowMocks {
+	t.Helper()
+
+	mocks := &workflowMocks{
+		fsAdapter:    new(adaptermocks.MockSourceFSAdapter),
+		reportStore:  new(adaptermocks.MockReportStore),
+		reporter:     new(domainmocks.MockReporter),
+		orchestrator: new(domainmocks.MockOrchestrator),
+		workspace:    new(domainmocks.MockWorkspace),
+		mutagen:      new(domainmocks.MockMutagen),
+	}
+
+	mocks.reporter.EXPECT().StartTest(ctx).Return(nil).Once()
+	mocks.reporter.EXPECT().Wait(ctx).Return().Maybe()
+	mocks.reporter.EXPECT().Close(ctx).Return().Once()
+	mocks.reporter.EXPECT().DisplayConcurrencyInfo(ctx, mock.Anything, mock.Anything, mock.Anything).Return()
+	mocks.reporter.EXPECT().DisplayUpcomingTestsInfo(ctx, mock.Anything).Return()
+	mocks.reporter.EXPECT().DisplayMutationScore(ctx, mock.Anything).Return().Maybe()
+	mocks.reporter.EXPECT().DisplayStartingTestInfo(ctx, mock.Anything, mock.Anything).Return().Maybe()
+	mocks.reporter.EXPECT().DisplayCompletedTestInfo(ctx, mock.Anything, mock.Anything).Return().Maybe()
+
+	mocks.fsAdapter.EXPECT().Stream(ctx, mock.Anything).Return(streamSources(sources))
+
+	if mutations != nil {
+		mocks.mutagen.EXPECT().
+			StreamMutations(ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			RunAndReturn(streamMutationsFn(mutations))
+	}
+
+	mocks.orchestrator.EXPECT().NewWorkspace().Return(mocks.workspace).Maybe()
+	mocks.workspace.EXPECT().Close(mock.Anything).Return().Maybe()
+
+	mocks.reportStore.EXPECT().SaveSpillReports(ctx, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ m.Path, reports pkg.FileSpill[m.Report]) error {
+			mocks.saved = collectSpillReports(t, reports)
+			return nil
+		}).
+		Maybe()
+	mocks.reportStore.EXPECT().RegenerateIndex(ctx, mock.Anything).Return(nil).Maybe()
+
+	return mocks
+}
+
+// workflow returns a workflow running on the mocks.
+func (w *workflowMocks) workflow() domain.Workflow {
+	return domain.NewWorkflow(w.fsAdapter, w.reportStore, w.reporter, w.orchestrator, w.mutagen)
+}
+
+// statusByID returns the status of every mutation in the saved reports.
+func (w *workflowMocks) statusByID() map[string]m.TestStatus {
+	statuses := map[string]m.TestStatus{}
+
+	for _, report := range w.saved {
+		for _, entries := range report.Result {
+			for _, entry := range entries {
+				statuses[entry.MutationID] = entry.Status
+			}
+		}
+	}
+
+	return statuses
+}
@@ -31,6 +31,12 @@ var (
 	MutationStatement = MutationType{Name: "statement", Version: 1}
 	// MutationLoop represents loop mutations (boundary conditions, loop body removal, break/continue removal).
 	MutationLoop = MutationType{Name: "loop", Version: 1}
+	// MutationCollection represents sort, slices, maps and cmp standard-library call mutations
+	// (reversed comparators, negated lookups, Min/Max swaps, Stable -> Sort, Clone/Compact removal).
+	MutationCollection = MutationType{Name: "collection", Version: 1}
+	// MutationHigherOrder represents higher-order mutants combining several
+	// first-order mutants of the same function.
+	MutationHigherOrder = MutationType{Name: "higher_order", Version: 1}
 )
 
 // Mutation represents a code mutation with its details.
@@ -43,4 +49,28 @@ type Mutation struct {
 	MutatedCode []byte
 	DiffCode    []byte
 	Line        int
+	// Scope is a hash of the source of the top-level declaration the mutation
+	// is in. Cached results are reused while it (and the tests) are unchanged.
+	Scope string
+	// Func names the function the mutation is in as function filters match
+	// it: Receiver.Method for a method. Empty outside functions.
+	Func string
+	// Duplicates are mutations of other mutagens with byte-identical
+	// MutatedCode. They are not tested; they record this mutation's result.
+	Duplicates []Mutation
+	// DuplicateOf is the ID of the mutation a duplicate shares its result with.
+	DuplicateOf string
+	// Constituents are the IDs of the first-order mutations a higher-order
+	// mutation combines.
+	Constituents []string
+	// CoveringTests narrows the tests run against the mutation to those that
+	// reach its line, keyed by test package directory. Nil runs every test.
+	CoveringTests map[Path][]string
+	// SchemaCode is the source of the mutation's file with it and the file's
+	// other mutations woven in, each behind a runtime switch (a mutant
+	// schema), and SchemaID is the switch value selecting this mutation.
+	// Mutations sharing a schema are tested against one build, and share
+	// the bytes of its code. Empty when the mutation is tested on its own.
+	SchemaCode []byte
+	SchemaID   string
 }
@@ -1,5 +1,7 @@
 package model
 
+import "slices"
+
 // TestStatus represents the status of a mutation test.
 type TestStatus int
 
@@ -17,6 +19,12 @@ const (
 	// NotCovered indicates the mutated line is not exercised by any test, so the
 	// mutation survives without running tests.
 	NotCovered
+	// Equivalent indicates the mutation survived but compiles to the same code as
+	// the original, so no test can ever kill it.
+	Equivalent
+	// Flaky indicates the mutation's tests gave different outcomes when run
+	// again, so its status cannot be trusted.
+	Flaky
 )
 
 func (t TestStatus) String() string {
@@ -33,6 +41,10 @@ func (t TestStatus) String() string {
 		return "timeout"
 	case NotCovered:
 		return "not_covered"
+	case Equivalent:
+		return "equivalent"
+	case Flaky:
+		return "flaky"
 	default:
 		return "unknown"
 	}
@@ -50,4 +62,52 @@ type Report struct {
 	Source Source
 	Result Result
 	Diff   *[]byte
+	// Scope is the Scope of the mutation the report is for.
+	Scope string
+	// DuplicateOf is set when the mutation duplicates (and shares the result
+	// of) the mutation with this ID. Duplicates are left out of the score.
+	DuplicateOf string
+	// Constituents are the IDs of the first-order mutations combined into the
+	// higher-order mutation the report is for.
+	Constituents []string
+	// Line is the source line of the mutation, 0 when unknown.
+	Line int
+	// FlakyTests are the tests that failed, or were cut off by a timeout, in
+	// some runs of a flaky mutation but not in others.
+	FlakyTests []string
+	// Selection holds the line ranges of the source the run that produced the
+	// report was limited to. Empty when the whole file was mutated.
+	Selection []LineRange
+	// Func is the Func of the mutation the report is for.
+	Func string
+	// Funcs holds the function filter of the run that produced the report.
+	// Empty when every function was mutated.
+	Funcs FuncSelection
+}
+
+// FuncSelection holds the patterns of a function filter: the functions
+// matching one of Match (all of them when there are none) and none of Exclude.
+type FuncSelection struct {
+	Match   []string `yaml:"match,omitempty"`
+	Exclude []string `yaml:"exclude,omitempty"`
+}
+
+// Enabled reports whether the filter limits mutation at all.
+func (s FuncSelection) Enabled() bool {
+	return len(s.Match) > 0 || len(s.Exclude) > 0
+}
+
+// Equal reports whether s and other hold the same patterns.
+func (s FuncSelection) Equal(other FuncSelection) bool {
+	return slices.Equal(s.Match, other.Match) && slices.Equal(s.Exclude, other.Exclude)
+}
+
+// RunScope is the part of the sources a run mutates. Stored reports produced
+// under a narrower scope do not stand in for the run.
+type RunScope struct {
+	// Lines holds the selected line ranges of each file, keyed by absolute
+	// path. Nil when every line is mutated.
+	Lines map[Path][]LineRange
+	// Funcs is the function filter of the run.
+	Funcs FuncSelection
 }
@@ -1,5 +1,10 @@
 package model
 
+import (
+	"crypto/sha256"
+	"fmt"
+)
+
 // Path represents a file system path.
 type Path string
 
@@ -27,10 +32,54 @@ type File struct {
 	Hash      string
 }
 
-// Source represents a pair of source and test files along with their package name.
-// Source represents a Go source file and its optional test file metadata.
+// Source represents a Go source file, the test files that cover it and its
+// package name.
 type Source struct {
-	Origin  *File
-	Test    *File
+	Origin *File
+	// Tests are the test files of the source's package, external _test
+	// packages included, and of any package mapped to it by configuration.
+	Tests   []*File
 	Package *string
+	// Module is the path of the Go module the source belongs to.
+	Module string `yaml:"module,omitempty"`
+	// TestConfig fingerprints the go test flags, build tags and environment
+	// the tests run with; it is empty when there are none.
+	TestConfig string `yaml:"testconfig,omitempty"`
+}
+
+// TestHash fingerprints the content of the source's test files and the
+// settings they run with; it changes whenever a test file is added, removed or
+// edited, or the settings change. Test files are identified by their path
+// relative to the project root, so the hash survives moving the checkout. It
+// is empty for a source with neither tests nor settings.
+func (s Source) TestHash() string {
+	if len(s.Tests) == 0 && s.TestConfig == "" {
+		return ""
+	}
+
+	h := sha256.New()
+	if s.TestConfig != "" {
+		h.Write([]byte(s.TestConfig))
+		h.Write([]byte{0})
+	}
+
+	for _, test := range s.Tests {
+		h.Write([]byte(test.ShortPath))
+		h.Write([]byte{0})
+		h.Write([]byte(test.Hash))
+		h.Write([]byte{0})
+	}
+
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+// LineRange is an inclusive range of 1-based source lines.
+type LineRange struct {
+	Start int `yaml:"start"`
+	End   int `yaml:"end"`
+}
+
+// Contains reports whether line is within the range.
+func (r LineRange) Contains(line int) bool {
+	return line >= r.Start && line <= r.End
 }
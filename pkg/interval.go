@@ -0,0 +1,31 @@
+package pkg
+
+import "math"
+
+// z95 is the standard normal quantile for a two-sided 95% interval.
+const z95 = 1.959963984540054
+
+// ScoreInterval returns the 95% Wilson score interval of a proportion observed
+// as successes out of trials, drawn without replacement from a population of the
+// given size. The finite population correction narrows the interval as the
+// sample approaches the whole population; a full census returns [p, p].
+func ScoreInterval(successes, trials, population int) (low, high float64) {
+	if trials <= 0 {
+		return 0, 1
+	}
+
+	p := float64(successes) / float64(trials)
+	if population <= trials {
+		return p, p
+	}
+
+	// Scale the sample size by the inverse of the finite population correction.
+	n := float64(trials) * float64(population-1) / float64(population-trials)
+	z2 := z95 * z95
+
+	denominator := 1 + z2/n
+	center := (p + z2/(2*n)) / denominator
+	half := z95 * math.Sqrt(p*(1-p)/n+z2/(4*n*n)) / denominator
+
+	return math.Max(0, center-half), math.Min(1, center+half)
+}
@@ -0,0 +1,39 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScoreInterval(t *testing.T) {
+	t.Run("no trials spans everything", func(t *testing.T) {
+		low, high := ScoreInterval(0, 0, 10)
+		require.Equal(t, 0.0, low)
+		require.Equal(t, 1.0, high)
+	})
+
+	t.Run("census is exact", func(t *testing.T) {
+		low, high := ScoreInterval(3, 4, 4)
+		require.Equal(t, 0.75, low)
+		require.Equal(t, 0.75, high)
+	})
+
+	t.Run("sample brackets the observed score", func(t *testing.T) {
+		low, high := ScoreInterval(80, 100, 100000)
+		require.InDelta(t, 0.711, low, 0.001)
+		require.InDelta(t, 0.867, high, 0.001)
+	})
+
+	t.Run("larger share of the population narrows the interval", func(t *testing.T) {
+		smallLow, smallHigh := ScoreInterval(80, 100, 100000)
+		largeLow, largeHigh := ScoreInterval(80, 100, 200)
+		require.Less(t, largeHigh-largeLow, smallHigh-smallLow)
+	})
+
+	t.Run("extremes stay within bounds", func(t *testing.T) {
+		low, high := ScoreInterval(10, 10, 1000)
+		require.Less(t, low, 1.0)
+		require.Equal(t, 1.0, high)
+	})
+}